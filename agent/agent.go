@@ -3,12 +3,43 @@
 package agent
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/schema"
 )
 
+// jsonCodeBlockRegexp matches a fenced JSON code block in the output of a model.
+var jsonCodeBlockRegexp = regexp.MustCompile("(?s)```(?:json)?(.*?)```")
+
+// runManagerKey is the context key of the callback manager of the current executor run.
+type runManagerKey struct{}
+
+// RunManagerFromContext returns the callback manager of the executor run, if the agent is planning within an Executor.
+// Agents that call chains or models use it to pass the callbacks and the run ID to the nested runs.
+func RunManagerFromContext(ctx context.Context) (schema.CallbackManagerForChainRun, bool) {
+	rm, ok := ctx.Value(runManagerKey{}).(schema.CallbackManagerForChainRun)
+	return rm, ok
+}
+
+// contextWithRunManager returns a copy of the context that carries the callback manager of the executor run.
+func contextWithRunManager(ctx context.Context, rm schema.CallbackManagerForChainRun) context.Context {
+	return context.WithValue(ctx, runManagerKey{}, rm)
+}
+
+// runManager returns the callback manager of the executor run or a noop manager, if the agent is not
+// planning within an Executor.
+func runManager(ctx context.Context) schema.CallbackManagerForChainRun {
+	if rm, ok := RunManagerFromContext(ctx); ok {
+		return rm
+	}
+
+	return &callback.NoopManager{}
+}
+
 // toolNames returns a comma-separated string containing the names of the tools
 // in the provided slice of schema.Tool.
 func toolNames(tools []schema.Tool) string {
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/hupe1980/golc/model/chatmodel"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
 )
@@ -35,6 +36,25 @@ func TestToolDescriptions(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+// newSequenceFake returns a fake chat model that answers with the outputs in order. If prompts is not nil,
+// it records the content of the last message of every prompt.
+func newSequenceFake(prompts *[]string, outputs ...string) *chatmodel.Fake {
+	responses := make([]*schema.AIChatMessage, len(outputs))
+	for i, output := range outputs {
+		responses[i] = schema.NewAIChatMessage(output)
+	}
+
+	fake := chatmodel.NewSequenceFake(responses)
+
+	return chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+		if prompts != nil {
+			*prompts = append(*prompts, messages[len(messages)-1].Content())
+		}
+
+		return fake.Generate(ctx, messages)
+	})
+}
+
 // Compile time check to ensure mockTool satisfies the Tool interface.
 var _ schema.Tool = (*mockTool)(nil)
 
@@ -52,6 +52,7 @@ Thought:{{.agentScratchpad}}`
 )
 
 type ConversationalReactDescriptionOptions struct {
+	RunOptions
 	Prefix        string
 	Instructions  string
 	Suffix        string
@@ -61,6 +62,7 @@ type ConversationalReactDescriptionOptions struct {
 }
 
 type ConversationalReactDescription struct {
+	model schema.Model
 	chain schema.Chain
 	tools []schema.Tool
 	opts  ConversationalReactDescriptionOptions
@@ -89,20 +91,27 @@ func NewConversationalReactDescription(llm schema.Model, tools []schema.Tool, op
 	}
 
 	agent := &ConversationalReactDescription{
+		model: llm,
 		chain: llmChain,
 		tools: tools,
 		opts:  opts,
 	}
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
 		o.MaxIterations = opts.MaxIterations
 	})
 }
 
 func (a *ConversationalReactDescription) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
 	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
 
-	resp, err := golc.Call(ctx, a.chain, inputs)
+	resp, err := golc.Call(ctx, a.chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = rm.GetInheritableCallbacks()
+		co.ParentRunID = rm.RunID()
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -115,6 +124,11 @@ func (a *ConversationalReactDescription) Plan(ctx context.Context, intermediateS
 	return a.parseOutput(output)
 }
 
+// Model returns the model of the agent.
+func (a *ConversationalReactDescription) Model() schema.Model {
+	return a.model
+}
+
 func (a *ConversationalReactDescription) InputKeys() []string {
 	chainInputs := a.chain.InputKeys()
 
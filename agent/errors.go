@@ -7,4 +7,8 @@ var (
 	ErrNotFinished            = errors.New("agent not finished before max iterations")
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
 	ErrUnableToParseOutput    = errors.New("unable to parse agent output")
+	ErrIteratorFinished       = errors.New("agent iterator already finished")
+	ErrNoPendingAction        = errors.New("no pending agent action")
+	ErrApprovalRequired       = errors.New("agent action requires approval")
+	ErrPlanTooDeep            = errors.New("plan needs more rounds of tool calls than max iterations")
 )
@@ -2,12 +2,15 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tool"
+	"golang.org/x/sync/errgroup"
 )
 
 // Compile time check to ensure Executor satisfies the chain interface.
@@ -15,9 +18,42 @@ var _ schema.Chain = (*Executor)(nil)
 
 const DefaultMaxIterations = 5
 
+// Early stopping methods of the Executor.
+const (
+	// EarlyStoppingMethodForce returns a constant output when the agent is stopped.
+	EarlyStoppingMethodForce = "force"
+	// EarlyStoppingMethodGenerate asks the agent for a final answer based on the previous steps when the agent is stopped.
+	EarlyStoppingMethodGenerate = "generate"
+)
+
+const (
+	stoppedOutput         = "Agent stopped due to iteration limit or time limit."
+	stoppedObservation    = "You have reached the maximum number of steps or the time limit. Now you must respond with the final answer based on the previous steps."
+	invalidOutputToolName = "_Exception"
+)
+
+// RunOptions holds the options that control how the Executor runs an agent. They can
+// be set on the Executor and on all agents.
+type RunOptions struct {
+	// HandleToolErrors turns errors returned by tools into observations instead of aborting the run.
+	HandleToolErrors bool
+	// HandleParsingErrors feeds outputs of the agent that cannot be parsed back to the agent
+	// as observation instead of aborting the run.
+	HandleParsingErrors bool
+	// MaxExecutionTime is the maximum time the agent may run. Planning and tools still running when
+	// the limit is reached are canceled. A value of zero means no limit.
+	MaxExecutionTime time.Duration
+	// EarlyStoppingMethod is the method used when the agent does not finish within MaxIterations or
+	// MaxExecutionTime: force or generate. If it is empty, the run fails with ErrNotFinished.
+	EarlyStoppingMethod string
+	// ReturnIntermediateSteps adds the []schema.AgentStep taken by the agent to the outputs.
+	ReturnIntermediateSteps bool
+}
+
 // ExecutorOptions holds configuration options for the Executor.
 type ExecutorOptions struct {
 	*schema.CallbackOptions
+	RunOptions
 	MaxIterations  int
 	Memory         schema.Memory
 	AgentChainType string
@@ -26,6 +62,7 @@ type ExecutorOptions struct {
 // Executor represents an agent executor that executes a chain of actions based on inputs and a defined agent model.
 type Executor struct {
 	agent    schema.Agent
+	tools    []schema.Tool
 	toolsMap map[string]schema.Tool
 	opts     ExecutorOptions
 }
@@ -52,6 +89,7 @@ func NewExecutor(agent schema.Agent, tools []schema.Tool, optFns ...func(o *Exec
 
 	return &Executor{
 		agent:    agent,
+		tools:    tools,
 		toolsMap: toolsMap,
 		opts:     opts,
 	}, nil
@@ -68,63 +106,278 @@ func (e Executor) Call(ctx context.Context, inputs schema.ChainValues, optFns ..
 		fn(&opts)
 	}
 
-	steps := []schema.AgentStep{}
+	// The run context cancels planning and tools that exceed MaxExecutionTime. The parent
+	// context is used for the early stopping, which must still be able to reach the agent.
+	runCtx, cancel := e.withTimeLimit(ctx, time.Now())
+	defer cancel()
 
-	for i := 0; i <= e.opts.MaxIterations; i++ {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			actions, finish, err := e.agent.Plan(ctx, steps, inputs.Clone())
-			if err != nil {
-				return nil, err
+	state := &IteratorState{
+		Inputs: inputs,
+		Steps:  []schema.AgentStep{},
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := e.step(ctx, runCtx, state, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.outputs != nil {
+			return result.outputs, nil
+		}
+
+		if result.errStep != nil {
+			continue
+		}
+
+		for _, action := range result.actions {
+			if cbErr := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{
+				Action: action,
+			}); cbErr != nil {
+				return nil, cbErr
 			}
+		}
 
-			if len(actions) == 0 && finish == nil {
-				return nil, ErrAgentNoReturn
+		observations, err := e.runTools(runCtx, result.actions, opts)
+		if err != nil {
+			// The next step stops the run.
+			if timeLimitReached(ctx, runCtx) {
+				continue
 			}
 
-			if finish != nil {
-				if cbErr := opts.CallbackManger.OnAgentFinish(ctx, &schema.AgentFinishManagerInput{
-					Finish: finish,
-				}); cbErr != nil {
-					return nil, cbErr
-				}
+			return nil, err
+		}
 
-				return finish.ReturnValues, nil
-			}
+		for i, action := range result.actions {
+			state.Steps = append(state.Steps, schema.AgentStep{
+				Action:      action,
+				Observation: observations[i],
+			})
+		}
+	}
+}
 
-			for _, action := range actions {
-				if cbErr := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{
-					Action: action,
-				}); cbErr != nil {
-					return nil, cbErr
-				}
+// stepResult is the outcome of a planning step of a run.
+type stepResult struct {
+	// actions are the actions planned by the agent.
+	actions []*schema.AgentAction
+	// errStep is the step recorded for an output of the agent that could not be parsed.
+	errStep *schema.AgentStep
+	// outputs are the outputs of the finished run.
+	outputs schema.ChainValues
+}
+
+// step lets the agent plan the next step of the run with the given state. Once MaxIterations or
+// MaxExecutionTime is exceeded, it stops the run according to the early stopping method instead.
+// Executor.Call and the Iterator share it.
+func (e Executor) step(ctx, runCtx context.Context, state *IteratorState, opts schema.CallOptions) (*stepResult, error) {
+	if state.Iterations > e.opts.MaxIterations || timeLimitReached(ctx, runCtx) {
+		return e.stopStep(ctx, state, opts)
+	}
 
-				t, ok := e.toolsMap[action.Tool]
-				if !ok {
-					steps = append(steps, schema.AgentStep{
-						Action:      action,
-						Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-					})
+	state.Iterations++
 
-					continue
-				}
+	actions, finish, errStep, err := e.plan(runCtx, state.Steps, state.Inputs, opts)
+	if err != nil {
+		if timeLimitReached(ctx, runCtx) {
+			return e.stopStep(ctx, state, opts)
+		}
+
+		return nil, err
+	}
+
+	if errStep != nil {
+		state.Steps = append(state.Steps, *errStep)
+		return &stepResult{errStep: errStep}, nil
+	}
+
+	if finish != nil {
+		outputs, err := e.finish(ctx, finish, state.Steps, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &stepResult{outputs: outputs}, nil
+	}
+
+	return &stepResult{actions: actions}, nil
+}
+
+// stopStep stops the run with the given state according to the early stopping method.
+func (e Executor) stopStep(ctx context.Context, state *IteratorState, opts schema.CallOptions) (*stepResult, error) {
+	outputs, err := e.stop(ctx, state.Steps, state.Inputs, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stepResult{outputs: outputs}, nil
+}
+
+// withTimeLimit returns a run context that is canceled once MaxExecutionTime has passed since start.
+func (e Executor) withTimeLimit(ctx context.Context, start time.Time) (context.Context, context.CancelFunc) {
+	if e.opts.MaxExecutionTime <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithDeadline(ctx, start.Add(e.opts.MaxExecutionTime))
+}
+
+// timeLimitReached reports whether the run context exceeded MaxExecutionTime while the parent context is still active.
+func timeLimitReached(ctx, runCtx context.Context) bool {
+	return ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded)
+}
 
-				observation, err := tool.Run(ctx, t, action.ToolInput)
-				if err != nil {
-					return nil, err
+// plan lets the agent plan the next actions. If HandleParsingErrors is enabled, an output of
+// the agent that cannot be parsed is returned as step with the parsing error as observation.
+func (e Executor) plan(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues, opts schema.CallOptions) ([]*schema.AgentAction, *schema.AgentFinish, *schema.AgentStep, error) {
+	actions, finish, err := e.agent.Plan(contextWithRunManager(ctx, opts.CallbackManger), steps, inputs.Clone())
+	if err != nil {
+		if !e.opts.HandleParsingErrors || !errors.Is(err, ErrUnableToParseOutput) {
+			return nil, nil, nil, err
+		}
+
+		return nil, nil, &schema.AgentStep{
+			Action: &schema.AgentAction{
+				Tool:      invalidOutputToolName,
+				ToolInput: schema.NewToolInputFromString("Invalid or incomplete response"),
+				Log:       err.Error(),
+			},
+			Observation: fmt.Sprintf("Invalid or incomplete response: %s. Please respond in the required format.", err),
+		}, nil
+	}
+
+	if len(actions) == 0 && finish == nil {
+		return nil, nil, nil, ErrAgentNoReturn
+	}
+
+	return actions, finish, nil, nil
+}
+
+// stop returns the outputs of an agent that did not finish within the iteration or time limit
+// according to the early stopping method.
+func (e Executor) stop(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues, opts schema.CallOptions) (schema.ChainValues, error) {
+	switch e.opts.EarlyStoppingMethod {
+	case EarlyStoppingMethodForce:
+		return e.finish(ctx, e.stoppedFinish(), steps, opts)
+	case EarlyStoppingMethodGenerate:
+		stopSteps := append(append([]schema.AgentStep{}, steps...), schema.AgentStep{
+			Action: &schema.AgentAction{
+				Tool:      invalidOutputToolName,
+				ToolInput: schema.NewToolInputFromString("Stop"),
+				Log:       "",
+			},
+			Observation: stoppedObservation,
+		})
+
+		_, finish, err := e.agent.Plan(contextWithRunManager(ctx, opts.CallbackManger), stopSteps, inputs.Clone())
+		if err != nil && !errors.Is(err, ErrUnableToParseOutput) {
+			return nil, err
+		}
+
+		if finish == nil {
+			finish = e.stoppedFinish()
+		}
+
+		return e.finish(ctx, finish, steps, opts)
+	default:
+		return nil, ErrNotFinished
+	}
+}
+
+// stoppedFinish returns the constant agent finish of a stopped agent.
+func (e Executor) stoppedFinish() *schema.AgentFinish {
+	returnValues := make(map[string]any, len(e.agent.OutputKeys()))
+	for _, key := range e.agent.OutputKeys() {
+		returnValues[key] = stoppedOutput
+	}
+
+	return &schema.AgentFinish{
+		ReturnValues: returnValues,
+		Log:          stoppedOutput,
+	}
+}
+
+// finish reports the agent finish to the callbacks and returns the outputs.
+func (e Executor) finish(ctx context.Context, finish *schema.AgentFinish, steps []schema.AgentStep, opts schema.CallOptions) (schema.ChainValues, error) {
+	if cbErr := opts.CallbackManger.OnAgentFinish(ctx, &schema.AgentFinishManagerInput{
+		Finish: finish,
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	if !e.opts.ReturnIntermediateSteps {
+		return finish.ReturnValues, nil
+	}
+
+	outputs := make(schema.ChainValues, len(finish.ReturnValues)+1)
+	for k, v := range finish.ReturnValues {
+		outputs[k] = v
+	}
+
+	outputs["intermediateSteps"] = steps
+
+	return outputs, nil
+}
+
+// runTools runs the tools of the given actions and returns the observations in the order of the actions.
+// Multiple actions, e.g. the tool calls of a single model turn, are executed in parallel.
+func (e Executor) runTools(ctx context.Context, actions []*schema.AgentAction, opts schema.CallOptions) ([]string, error) {
+	observations := make([]string, len(actions))
+
+	errs, errctx := errgroup.WithContext(ctx)
+
+	for i, action := range actions {
+		i, action := i, action
+
+		t, ok := e.toolsMap[action.Tool]
+		if !ok {
+			observations[i] = fmt.Sprintf("%s is not a valid tool, try another one", action.Tool)
+			continue
+		}
+
+		errs.Go(func() error {
+			observation, err := tool.Run(errctx, t, action.ToolInput, func(o *tool.Options) {
+				o.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+				o.ParentRunID = opts.CallbackManger.RunID()
+			})
+			if err != nil {
+				if !e.opts.HandleToolErrors {
+					return err
 				}
 
-				steps = append(steps, schema.AgentStep{
-					Action:      action,
-					Observation: observation,
-				})
+				observation = fmt.Sprintf("Error: %s", err)
 			}
-		}
+
+			observations[i] = observation
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
 	}
 
-	return nil, ErrNotFinished
+	return observations, nil
+}
+
+// Agent returns the agent executed by the Executor.
+func (e Executor) Agent() schema.Agent {
+	return e.agent
+}
+
+// Tools returns the tools available to the agent.
+func (e Executor) Tools() []schema.Tool {
+	return e.tools
+}
+
+// MaxIterations returns the maximum number of iterations of the agent.
+func (e Executor) MaxIterations() int {
+	return e.opts.MaxIterations
 }
 
 // Memory returns the memory associated with the chain.
@@ -154,5 +407,10 @@ func (e Executor) InputKeys() []string {
 
 // OutputKeys returns the output keys the chain will return.
 func (e Executor) OutputKeys() []string {
+	if e.opts.ReturnIntermediateSteps {
+		keys := append([]string{}, e.agent.OutputKeys()...)
+		return append(keys, "intermediateSteps")
+	}
+
 	return e.agent.OutputKeys()
 }
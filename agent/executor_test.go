@@ -3,7 +3,10 @@ package agent
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
@@ -65,6 +68,249 @@ func TestExecutor(t *testing.T) {
 		assert.ErrorContains(t, err, "executor error")
 	})
 
+	t.Run("Call_RunManagerInContext", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &mockAgent{
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				rm, ok := RunManagerFromContext(ctx)
+				if !ok || rm == nil {
+					return nil, nil, errors.New("missing run manager")
+				}
+
+				return nil, &schema.AgentFinish{
+					ReturnValues: schema.ChainValues{"outputKey": "outputValue"},
+				}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{tool})
+		assert.NoError(t, err)
+
+		_, err = executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Call_ParallelActions", func(t *testing.T) {
+		t.Parallel()
+
+		started := sync.WaitGroup{}
+		started.Add(2)
+
+		// Each tool waits until both tools are running, which only succeeds if they run in parallel.
+		parallelTool := func(name string) *mockTool {
+			return &mockTool{
+				ToolName: name,
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					started.Done()
+					started.Wait()
+
+					return fmt.Sprintf("%s: %s", name, input), nil
+				},
+			}
+		}
+
+		agent := &mockAgent{
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) == 0 {
+					return []*schema.AgentAction{
+						{Tool: "A", ToolInput: schema.NewToolInputFromString("a")},
+						{Tool: "B", ToolInput: schema.NewToolInputFromString("b")},
+						{Tool: "C", ToolInput: schema.NewToolInputFromString("c")},
+					}, nil, nil
+				}
+
+				assert.Len(t, steps, 3)
+				assert.Equal(t, "A: a", steps[0].Observation)
+				assert.Equal(t, "B: b", steps[1].Observation)
+				assert.Equal(t, "C is not a valid tool, try another one", steps[2].Observation)
+
+				return nil, &schema.AgentFinish{
+					ReturnValues: schema.ChainValues{"output": "done"},
+				}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{parallelTool("A"), parallelTool("B")})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, schema.ChainValues{"output": "done"}, outputs)
+	})
+
+	t.Run("Call_HandleToolErrors", func(t *testing.T) {
+		t.Parallel()
+
+		failingTool := &mockTool{
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				return "", errors.New("tool failed")
+			},
+		}
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) == 0 {
+					return []*schema.AgentAction{{Tool: "Mock", ToolInput: schema.NewToolInputFromString("input")}}, nil, nil
+				}
+
+				return nil, &schema.AgentFinish{ReturnValues: schema.ChainValues{"output": steps[0].Observation}}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{failingTool})
+		assert.NoError(t, err)
+
+		_, err = executor.Call(context.Background(), schema.ChainValues{})
+		assert.EqualError(t, err, "tool failed")
+
+		executor, err = NewExecutor(agent, []schema.Tool{failingTool}, func(o *ExecutorOptions) {
+			o.HandleToolErrors = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, "Error: tool failed", outputs["output"])
+	})
+
+	t.Run("Call_HandleParsingErrors", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) == 0 {
+					return nil, nil, fmt.Errorf("%w: invalid", ErrUnableToParseOutput)
+				}
+
+				assert.Equal(t, "_Exception", steps[0].Action.Tool)
+				assert.Contains(t, steps[0].Observation, "unable to parse agent output: invalid")
+
+				return nil, &schema.AgentFinish{ReturnValues: schema.ChainValues{"output": "recovered"}}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{tool})
+		assert.NoError(t, err)
+
+		_, err = executor.Call(context.Background(), schema.ChainValues{})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+
+		executor, err = NewExecutor(agent, []schema.Tool{tool}, func(o *ExecutorOptions) {
+			o.HandleParsingErrors = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, "recovered", outputs["output"])
+	})
+
+	t.Run("Call_EarlyStopping", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) > 0 && steps[len(steps)-1].Observation == stoppedObservation {
+					return nil, &schema.AgentFinish{ReturnValues: schema.ChainValues{"output": "generated"}}, nil
+				}
+
+				return []*schema.AgentAction{{Tool: "Mock", ToolInput: schema.NewToolInputFromString("input")}}, nil, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{tool})
+		assert.NoError(t, err)
+
+		_, err = executor.Call(context.Background(), schema.ChainValues{})
+		assert.ErrorIs(t, err, ErrNotFinished)
+
+		executor, err = NewExecutor(agent, []schema.Tool{tool}, func(o *ExecutorOptions) {
+			o.EarlyStoppingMethod = EarlyStoppingMethodForce
+		})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, stoppedOutput, outputs["output"])
+
+		executor, err = NewExecutor(agent, []schema.Tool{tool}, func(o *ExecutorOptions) {
+			o.EarlyStoppingMethod = EarlyStoppingMethodGenerate
+			o.ReturnIntermediateSteps = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err = executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, "generated", outputs["output"])
+		assert.Len(t, outputs["intermediateSteps"], DefaultMaxIterations+1)
+		assert.Equal(t, []string{"output", "intermediateSteps"}, executor.OutputKeys())
+	})
+
+	t.Run("Call_MaxExecutionTime", func(t *testing.T) {
+		t.Parallel()
+
+		slowTool := &mockTool{
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return "Observation", nil
+			},
+		}
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				return []*schema.AgentAction{{Tool: "Mock", ToolInput: schema.NewToolInputFromString("input")}}, nil, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{slowTool}, func(o *ExecutorOptions) {
+			o.MaxIterations = 100
+			o.MaxExecutionTime = 10 * time.Millisecond
+			o.EarlyStoppingMethod = EarlyStoppingMethodForce
+			o.ReturnIntermediateSteps = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, stoppedOutput, outputs["output"])
+		assert.Len(t, outputs["intermediateSteps"], 1)
+	})
+
+	t.Run("Call_MaxExecutionTimeCancelsTools", func(t *testing.T) {
+		t.Parallel()
+
+		blockingTool := &mockTool{
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			},
+		}
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				return []*schema.AgentAction{{Tool: "Mock", ToolInput: schema.NewToolInputFromString("input")}}, nil, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{blockingTool}, func(o *ExecutorOptions) {
+			o.MaxExecutionTime = 10 * time.Millisecond
+			o.EarlyStoppingMethod = EarlyStoppingMethodForce
+			o.ReturnIntermediateSteps = true
+		})
+		assert.NoError(t, err)
+
+		outputs, err := executor.Call(context.Background(), schema.ChainValues{})
+		assert.NoError(t, err)
+		assert.Equal(t, stoppedOutput, outputs["output"])
+		assert.Empty(t, outputs["intermediateSteps"])
+	})
+
 	t.Run("InputKeys", func(t *testing.T) {
 		agent := &mockAgent{
 			IKeys: []string{"foo", "bar"},
@@ -0,0 +1,347 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ActionDecision is the decision of the caller about a planned action.
+type ActionDecision string
+
+const (
+	// ActionDecisionNone means that no decision was made for the action yet.
+	ActionDecisionNone ActionDecision = ""
+	// ActionDecisionApproved means that the action may be executed.
+	ActionDecisionApproved ActionDecision = "approved"
+	// ActionDecisionRejected means that the action must not be executed.
+	ActionDecisionRejected ActionDecision = "rejected"
+)
+
+// PendingAction is a planned action that has not been executed yet.
+type PendingAction struct {
+	// Action is the planned action.
+	Action *schema.AgentAction `json:"action"`
+	// Decision is the decision of the caller about the action.
+	Decision ActionDecision `json:"decision,omitempty"`
+	// Reason is the reason for rejecting the action. It is passed to the agent as observation.
+	Reason string `json:"reason,omitempty"`
+	// Yielded reports whether the action was already yielded by the iterator.
+	Yielded bool `json:"yielded,omitempty"`
+}
+
+// IteratorState is the state of a step-wise agent run. It can be serialized to pause a run
+// and resumed later with Executor.Resume.
+type IteratorState struct {
+	// Inputs are the inputs of the run.
+	Inputs schema.ChainValues `json:"inputs"`
+	// Steps are the steps taken by the agent so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// PendingActions are the planned actions that have not been executed yet.
+	PendingActions []PendingAction `json:"pendingActions,omitempty"`
+	// Iterations is the number of times the agent planned its next actions.
+	Iterations int `json:"iterations"`
+	// Outputs are the outputs of the run, once the agent finished.
+	Outputs schema.ChainValues `json:"outputs,omitempty"`
+}
+
+// Finished reports whether the run has finished.
+func (s *IteratorState) Finished() bool {
+	return s.Outputs != nil
+}
+
+// IteratorEventType is the type of an event yielded by the Iterator.
+type IteratorEventType string
+
+const (
+	// IteratorEventAction is yielded after the agent planned an action. The action can be
+	// approved, edited or rejected before it is executed.
+	IteratorEventAction IteratorEventType = "action"
+	// IteratorEventObservation is yielded after an action was executed or rejected.
+	IteratorEventObservation IteratorEventType = "observation"
+	// IteratorEventFinish is yielded after the agent finished.
+	IteratorEventFinish IteratorEventType = "finish"
+)
+
+// IteratorEvent is an event yielded by the Iterator.
+type IteratorEvent struct {
+	// Type is the type of the event.
+	Type IteratorEventType
+	// Action is the planned action of action events.
+	Action *schema.AgentAction
+	// Step is the executed step of observation events.
+	Step *schema.AgentStep
+	// Outputs are the outputs of finish events.
+	Outputs schema.ChainValues
+}
+
+// IteratorOptions holds configuration options for the Iterator.
+type IteratorOptions struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+	// RequireApproval reports whether an action must be approved, edited or rejected explicitly
+	// before it is executed. Actions without decision are approved implicitly if RequireApproval
+	// is nil or returns false.
+	RequireApproval func(action *schema.AgentAction) bool
+}
+
+// Iterator runs an agent step by step. It yields after every planned action and every
+// observation, so that callers can approve, edit or reject actions before they are executed.
+// In contrast to Executor.Call, the actions of a single plan are executed one after another.
+type Iterator struct {
+	executor Executor
+	state    *IteratorState
+	start    time.Time
+	rm       schema.CallbackManagerForChainRun
+	opts     IteratorOptions
+}
+
+// Iter creates an iterator that runs the agent of the executor step by step with the given inputs.
+// Like golc.Call, the iterator loads the memory of the executor when the run starts and saves the
+// outputs to it when the run finishes.
+func (e Executor) Iter(inputs schema.ChainValues, optFns ...func(o *IteratorOptions)) *Iterator {
+	return e.Resume(&IteratorState{
+		Inputs: inputs,
+		Steps:  []schema.AgentStep{},
+	}, optFns...)
+}
+
+// Resume creates an iterator that continues a paused run from the given state. The
+// MaxExecutionTime of the executor applies to the resumed part of the run only.
+func (e Executor) Resume(state *IteratorState, optFns ...func(o *IteratorOptions)) *Iterator {
+	opts := IteratorOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Iterator{
+		executor: e,
+		state:    state,
+		start:    time.Now(),
+		opts:     opts,
+	}
+}
+
+// State returns the current state of the run.
+func (it *Iterator) State() *IteratorState {
+	return it.state
+}
+
+// Approve approves the current pending action.
+func (it *Iterator) Approve() error {
+	return it.decide(ActionDecisionApproved, nil, "")
+}
+
+// Edit replaces the current pending action with the given action and approves it.
+func (it *Iterator) Edit(action *schema.AgentAction) error {
+	return it.decide(ActionDecisionApproved, action, "")
+}
+
+// Reject rejects the current pending action. The reason is passed to the agent as observation.
+func (it *Iterator) Reject(reason string) error {
+	return it.decide(ActionDecisionRejected, nil, reason)
+}
+
+func (it *Iterator) decide(decision ActionDecision, action *schema.AgentAction, reason string) error {
+	if len(it.state.PendingActions) == 0 || !it.state.PendingActions[0].Yielded {
+		return ErrNoPendingAction
+	}
+
+	pending := &it.state.PendingActions[0]
+
+	pending.Decision = decision
+	pending.Reason = reason
+
+	if action != nil {
+		pending.Action = action
+	}
+
+	return nil
+}
+
+// Next advances the run and returns the next event. It returns ErrIteratorFinished
+// if the run has already finished.
+func (it *Iterator) Next(ctx context.Context) (*IteratorEvent, error) {
+	if it.state.Finished() {
+		return nil, ErrIteratorFinished
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if it.rm == nil {
+		if err := it.begin(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	runCtx, cancel := it.executor.withTimeLimit(ctx, it.start)
+	defer cancel()
+
+	event, err := it.next(ctx, runCtx, schema.CallOptions{CallbackManger: it.rm})
+	if err != nil {
+		// A missing approval pauses the run, it can be continued after a decision was made.
+		if errors.Is(err, ErrApprovalRequired) {
+			return nil, err
+		}
+
+		if cbErr := it.rm.OnChainError(ctx, &schema.ChainErrorManagerInput{
+			Error: err,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		return nil, err
+	}
+
+	if event.Type == IteratorEventFinish {
+		if memory := it.executor.Memory(); memory != nil {
+			if err := memory.SaveContext(ctx, it.state.Inputs, event.Outputs); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := it.rm.OnChainEnd(ctx, &schema.ChainEndManagerInput{
+			Outputs: event.Outputs,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return event, nil
+}
+
+// begin starts the run like golc.Call starts a chain. The variables of the memory of the executor
+// are loaded into the inputs, unless a paused run is resumed.
+func (it *Iterator) begin(ctx context.Context) error {
+	cm := callback.NewManager(it.opts.Callbacks, it.executor.Callbacks(), it.executor.Verbose(), func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = it.opts.ParentRunID
+	})
+
+	rm, err := cm.OnChainStart(ctx, &schema.ChainStartManagerInput{
+		ChainType: it.executor.Type(),
+		Inputs:    it.state.Inputs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if memory := it.executor.Memory(); memory != nil && it.state.Iterations == 0 {
+		inputs := it.state.Inputs.Clone()
+
+		vars, err := memory.LoadMemoryVariables(ctx, inputs)
+		if err != nil {
+			return err
+		}
+
+		for k, v := range vars {
+			inputs[k] = v
+		}
+
+		it.state.Inputs = inputs
+	}
+
+	it.rm = rm
+
+	return nil
+}
+
+func (it *Iterator) next(ctx, runCtx context.Context, opts schema.CallOptions) (*IteratorEvent, error) {
+	if len(it.state.PendingActions) > 0 {
+		pending := &it.state.PendingActions[0]
+
+		if !pending.Yielded {
+			pending.Yielded = true
+			return &IteratorEvent{Type: IteratorEventAction, Action: pending.Action}, nil
+		}
+
+		return it.execute(ctx, runCtx, pending, opts)
+	}
+
+	result, err := it.executor.step(ctx, runCtx, it.state, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.outputs != nil {
+		return it.finish(result.outputs), nil
+	}
+
+	if result.errStep != nil {
+		return &IteratorEvent{Type: IteratorEventObservation, Action: result.errStep.Action, Step: result.errStep}, nil
+	}
+
+	for _, action := range result.actions {
+		it.state.PendingActions = append(it.state.PendingActions, PendingAction{Action: action})
+	}
+
+	it.state.PendingActions[0].Yielded = true
+
+	return &IteratorEvent{Type: IteratorEventAction, Action: it.state.PendingActions[0].Action}, nil
+}
+
+// execute executes or rejects the given pending action and records the step.
+func (it *Iterator) execute(ctx, runCtx context.Context, pending *PendingAction, opts schema.CallOptions) (*IteratorEvent, error) {
+	var observation string
+
+	switch pending.Decision {
+	case ActionDecisionRejected:
+		observation = fmt.Sprintf("The action %s was rejected", pending.Action.Tool)
+		if pending.Reason != "" {
+			observation = fmt.Sprintf("%s: %s", observation, pending.Reason)
+		}
+	case ActionDecisionNone:
+		if it.opts.RequireApproval != nil && it.opts.RequireApproval(pending.Action) {
+			return nil, fmt.Errorf("%w: %s", ErrApprovalRequired, pending.Action.Tool)
+		}
+
+		fallthrough
+	default:
+		if cbErr := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{
+			Action: pending.Action,
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		observations, err := it.executor.runTools(runCtx, []*schema.AgentAction{pending.Action}, opts)
+		if err != nil {
+			if !timeLimitReached(ctx, runCtx) {
+				return nil, err
+			}
+
+			// The remaining actions are dropped and the run is stopped.
+			it.state.PendingActions = nil
+
+			result, err := it.executor.stopStep(ctx, it.state, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			return it.finish(result.outputs), nil
+		}
+
+		observation = observations[0]
+	}
+
+	step := schema.AgentStep{
+		Action:      pending.Action,
+		Observation: observation,
+	}
+
+	it.state.Steps = append(it.state.Steps, step)
+	it.state.PendingActions = it.state.PendingActions[1:]
+
+	return &IteratorEvent{Type: IteratorEventObservation, Action: step.Action, Step: &step}, nil
+}
+
+// finish stores the outputs in the state and returns the finish event.
+func (it *Iterator) finish(outputs schema.ChainValues) *IteratorEvent {
+	it.state.Outputs = outputs
+	return &IteratorEvent{Type: IteratorEventFinish, Outputs: outputs}
+}
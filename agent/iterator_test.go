@@ -0,0 +1,210 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/memory"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T) *Executor {
+		tools := []schema.Tool{
+			&mockTool{
+				ToolName: "Search",
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					return "result for " + input.(string), nil
+				},
+			},
+			&mockTool{
+				ToolName: "Delete",
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					t.Fatal("delete must not be executed")
+					return "", nil
+				},
+			},
+		}
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				if len(steps) == 0 {
+					return []*schema.AgentAction{
+						{Tool: "Search", ToolInput: schema.NewToolInputFromString("golc"), Log: "search"},
+						{Tool: "Delete", ToolInput: schema.NewToolInputFromString("prod"), Log: "delete"},
+					}, nil, nil
+				}
+
+				observations := ""
+				for _, step := range steps {
+					observations += step.Observation + ";"
+				}
+
+				return nil, &schema.AgentFinish{ReturnValues: schema.ChainValues{"output": observations}}, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, tools)
+		require.NoError(t, err)
+
+		return executor
+	}
+
+	t.Run("ApproveRejectAndResume", func(t *testing.T) {
+		t.Parallel()
+
+		executor := newExecutor(t)
+
+		it := executor.Iter(schema.ChainValues{"input": "cleanup"})
+
+		event, err := it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventAction, event.Type)
+		assert.Equal(t, "Search", event.Action.Tool)
+
+		require.NoError(t, it.Edit(&schema.AgentAction{Tool: "Search", ToolInput: schema.NewToolInputFromString("golang")}))
+
+		event, err = it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventObservation, event.Type)
+		assert.Equal(t, "result for golang", event.Step.Observation)
+
+		event, err = it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventAction, event.Type)
+		assert.Equal(t, "Delete", event.Action.Tool)
+
+		// Pause the run and resume it from the serialized state.
+		data, err := json.Marshal(it.State())
+		require.NoError(t, err)
+
+		state := &IteratorState{}
+		require.NoError(t, json.Unmarshal(data, state))
+
+		it = executor.Resume(state)
+
+		require.NoError(t, it.Reject("not allowed in production"))
+
+		event, err = it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventObservation, event.Type)
+		assert.Equal(t, "The action Delete was rejected: not allowed in production", event.Step.Observation)
+
+		event, err = it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventFinish, event.Type)
+		assert.Equal(t, "result for golang;The action Delete was rejected: not allowed in production;", event.Outputs["output"])
+		assert.True(t, it.State().Finished())
+
+		_, err = it.Next(context.Background())
+		assert.ErrorIs(t, err, ErrIteratorFinished)
+	})
+
+	t.Run("RequireApproval", func(t *testing.T) {
+		t.Parallel()
+
+		it := newExecutor(t).Iter(schema.ChainValues{"input": "cleanup"}, func(o *IteratorOptions) {
+			o.RequireApproval = func(action *schema.AgentAction) bool {
+				return action.Tool == "Delete"
+			}
+		})
+
+		assert.ErrorIs(t, it.Approve(), ErrNoPendingAction)
+
+		for _, typ := range []IteratorEventType{IteratorEventAction, IteratorEventObservation, IteratorEventAction} {
+			event, err := it.Next(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, typ, event.Type)
+		}
+
+		_, err := it.Next(context.Background())
+		assert.ErrorIs(t, err, ErrApprovalRequired)
+
+		require.NoError(t, it.Reject(""))
+
+		event, err := it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "The action Delete was rejected", event.Step.Observation)
+	})
+	t.Run("MaxExecutionTime", func(t *testing.T) {
+		t.Parallel()
+
+		blockingTool := &mockTool{
+			ToolName: "Block",
+			ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			},
+		}
+
+		agent := &mockAgent{
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				return []*schema.AgentAction{{Tool: "Block", ToolInput: schema.NewToolInputFromString("input")}}, nil, nil
+			},
+		}
+
+		executor, err := NewExecutor(agent, []schema.Tool{blockingTool}, func(o *ExecutorOptions) {
+			o.MaxExecutionTime = 10 * time.Millisecond
+			o.EarlyStoppingMethod = EarlyStoppingMethodForce
+		})
+		require.NoError(t, err)
+
+		it := executor.Iter(schema.ChainValues{})
+
+		event, err := it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventAction, event.Type)
+
+		event, err = it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventFinish, event.Type)
+		assert.Equal(t, stoppedOutput, event.Outputs["output"])
+		assert.Empty(t, it.State().PendingActions)
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &mockAgent{
+			IKeys: []string{"input"},
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				return nil, &schema.AgentFinish{ReturnValues: schema.ChainValues{"output": fmt.Sprintf("history: %v", inputs["history"])}}, nil
+			},
+		}
+
+		buffer := memory.NewConversationBuffer(func(o *memory.ConversationBufferOptions) {
+			o.InputKey = "input"
+		})
+
+		executor, err := NewExecutor(agent, nil, func(o *ExecutorOptions) {
+			o.Memory = buffer
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), executor, schema.ChainValues{"input": "first"})
+		require.NoError(t, err)
+		assert.Equal(t, "history: ", outputs["output"])
+
+		it := executor.Iter(schema.ChainValues{"input": "second"})
+
+		event, err := it.Next(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, IteratorEventFinish, event.Type)
+		assert.Equal(t, "history: Human: first\nAI: history: ", event.Outputs["output"])
+
+		vars, err := buffer.LoadMemoryVariables(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Human: first\nAI: history: \nHuman: second\nAI: history: Human: first\nAI: history: ", vars["history"])
+	})
+}
@@ -18,6 +18,7 @@ var _ schema.Agent = (*OpenAIFunctions)(nil)
 // OpenAIFunctionsOptions represents the configuration options for the OpenAIFunctions agent.
 type OpenAIFunctionsOptions struct {
 	*schema.CallbackOptions
+	RunOptions
 	// OutputKey is the key to store the output of the agent in the ChainValues.
 	OutputKey     string
 	SystemMessage *prompt.SystemMessageTemplate
@@ -34,6 +35,7 @@ type OpenAIFunctions struct {
 
 // NewOpenAIFunctions creates a new instance of the OpenAIFunctions agent with the given model and tools.
 // It returns an error if the model is not an OpenAI chatModel or fails to convert tools to function definitions.
+// NewToolCalling provides the same functionality for all chatModels with tool calling support.
 func NewOpenAIFunctions(model schema.ChatModel, tools []schema.Tool, optFns ...func(o *OpenAIFunctionsOptions)) (*Executor, error) {
 	opts := OpenAIFunctionsOptions{
 		CallbackOptions: &schema.CallbackOptions{
@@ -71,6 +73,7 @@ func NewOpenAIFunctions(model schema.ChatModel, tools []schema.Tool, optFns ...f
 	}
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
 		o.MaxIterations = opts.MaxIterations
 		o.AgentChainType = "OpenAIFunctions"
 	})
@@ -79,6 +82,8 @@ func NewOpenAIFunctions(model schema.ChatModel, tools []schema.Tool, optFns ...f
 // Plan executes the agent with the given context, intermediate steps, and inputs.
 // It returns the agent actions, agent finish, or an error, if any.
 func (a *OpenAIFunctions) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
 	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
 
 	templates := []prompt.MessageTemplate{a.opts.SystemMessage}
@@ -97,6 +102,8 @@ func (a *OpenAIFunctions) Plan(ctx context.Context, intermediateSteps []schema.A
 	}
 
 	result, err := model.ChatModelGenerate(ctx, a.model, prompt.Messages(), func(o *model.Options) {
+		o.Callbacks = rm.GetInheritableCallbacks()
+		o.ParentRunID = rm.RunID()
 		o.Functions = a.functions
 	})
 	if err != nil {
@@ -122,9 +129,21 @@ func (a *OpenAIFunctions) Plan(ctx context.Context, intermediateSteps []schema.A
 
 		log := fmt.Sprintf("\nInvoking `%s` with `%s`\n%s\n", ext.FunctionCall.Name, toolInput, msgContent)
 
-		return []*schema.AgentAction{
-			{Tool: ext.FunctionCall.Name, ToolInput: toolInput, Log: log, MessageLog: schema.ChatMessages{aiMsg}},
-		}, nil, nil
+		action := &schema.AgentAction{Tool: ext.FunctionCall.Name, ToolInput: toolInput, Log: log, MessageLog: schema.ChatMessages{aiMsg}}
+
+		// The agent only invokes the first tool call. The logged message keeps only this call, so that
+		// every tool call of the history is answered by a tool message.
+		if len(ext.ToolCalls) > 0 {
+			toolCall := ext.ToolCalls[0]
+
+			action.ToolCallID = toolCall.ID
+			action.MessageLog = schema.ChatMessages{schema.NewAIChatMessage(aiMsg.Content(), func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = ext.FunctionCall
+				o.ToolCalls = []schema.ToolCall{toolCall}
+			})}
+		}
+
+		return []*schema.AgentAction{action}, nil, nil
 	}
 
 	return nil, &schema.AgentFinish{
@@ -135,6 +154,11 @@ func (a *OpenAIFunctions) Plan(ctx context.Context, intermediateSteps []schema.A
 	}, nil
 }
 
+// Model returns the chat model of the agent.
+func (a *OpenAIFunctions) Model() schema.Model {
+	return a.model
+}
+
 // InputKeys returns the expected input keys for the agent.
 func (a *OpenAIFunctions) InputKeys() []string {
 	return []string{"input"}
@@ -152,7 +176,12 @@ func (a *OpenAIFunctions) constructScratchPad(steps []schema.AgentStep) schema.C
 	for _, step := range steps {
 		if step.Action.MessageLog != nil {
 			messages = append(messages, step.Action.MessageLog...)
-			messages = append(messages, schema.NewFunctionChatMessage(step.Action.Tool, step.Observation))
+
+			if step.Action.ToolCallID != "" {
+				messages = append(messages, schema.NewToolChatMessage(step.Action.ToolCallID, step.Action.Tool, step.Observation))
+			} else {
+				messages = append(messages, schema.NewFunctionChatMessage(step.Action.Tool, step.Observation))
+			}
 		} else {
 			messages = append(messages, schema.NewAIChatMessage(step.Action.Log))
 		}
@@ -67,6 +67,61 @@ func TestOpenAIFunctions(t *testing.T) {
 		assert.Equal(t, "finish text", output[agent.OutputKeys()[0]])
 	})
 
+	t.Run("TestPlanToolCalls", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewOpenAIFunctions(chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			var generation schema.Generation
+
+			if len(messages) == 2 {
+				generation = schema.Generation{
+					Message: schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+						o.ToolCalls = []schema.ToolCall{
+							{ID: "call_1", Name: "Mock", Arguments: `{"__arg1": "tool input"}`},
+							{ID: "call_2", Name: "Mock", Arguments: `{"__arg1": "ignored"}`},
+						}
+						o.FunctionCall = &schema.FunctionCall{Name: "Mock", Arguments: `{"__arg1": "tool input"}`}
+					}),
+				}
+			} else {
+				assert.Len(t, messages, 4)
+
+				// Every tool call of the history must be answered by a tool message.
+				aiMsg, ok := messages[2].(*schema.AIChatMessage)
+				assert.True(t, ok)
+				assert.Equal(t, []schema.ToolCall{{ID: "call_1", Name: "Mock", Arguments: `{"__arg1": "tool input"}`}}, aiMsg.Extension().ToolCalls)
+
+				toolMsg, ok := messages[3].(*schema.ToolChatMessage)
+				assert.True(t, ok)
+				assert.Equal(t, "call_1", toolMsg.ToolCallID())
+				assert.Equal(t, "tool output", toolMsg.Content())
+
+				generation = schema.Generation{
+					Text:    "finish text",
+					Message: schema.NewAIChatMessage("finish text"),
+				}
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{generation},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}, func(o *chatmodel.FakeOptions) {
+			o.ChatModelType = "chatmodel.OpenAI"
+		}), []schema.Tool{
+			&mockTool{
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					return "tool output", nil
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{"input": "user Input"})
+		assert.NoError(t, err)
+		assert.Equal(t, "finish text", output[agent.OutputKeys()[0]])
+	})
+
 	t.Run("TestPlanInvalidModel", func(t *testing.T) {
 		t.Parallel()
 
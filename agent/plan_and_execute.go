@@ -0,0 +1,370 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PlanAndExecute satisfies the chain interface.
+var _ schema.Chain = (*PlanAndExecute)(nil)
+
+const (
+	defaultPlannerTemplate = `Let's first understand the problem and devise a plan to solve the problem.
+Please output the plan starting with the header "Plan:" and then followed by a numbered list of steps.
+Please make the plan the minimum number of steps required to accurately complete the task.
+If the task is a question, the final step should almost always be "Given the above steps taken, please respond to the users original question".
+
+Objective: {{.input}}`
+
+	defaultReplannerTemplate = `For the given objective, come up with a simple step by step plan.
+This plan should involve individual tasks, that if executed correctly will yield the correct answer. Do not add any superfluous steps.
+The result of the final step should be the final answer. Make sure that each step has all the information needed - do not skip steps.
+
+Your objective was this:
+{{.input}}
+
+Your original plan was this:
+{{.plan}}
+
+You have currently done the following steps:
+{{.pastSteps}}
+
+Update your plan accordingly. If no more steps are needed and you can return to the user, respond with "Final Answer:" followed by the answer.
+Otherwise, respond with "Plan:" followed by a numbered list of the remaining steps. Only add steps to the plan that still NEED to be done. Do not return previously done steps as part of the plan.`
+
+	defaultStepTemplate = `Objective: {{.input}}
+
+Previous steps:
+{{.pastSteps}}
+
+Current step: {{.step}}`
+
+	// DefaultMaxSteps is the default maximum number of steps executed by the PlanAndExecute agent.
+	DefaultMaxSteps = 10
+)
+
+// PlanStep represents an executed step of a plan and its result.
+type PlanStep struct {
+	// Step is the description of the step.
+	Step string
+	// Result is the output of the executor for the step.
+	Result string
+}
+
+// PlanAndExecuteOptions represents the configuration options for the PlanAndExecute agent.
+type PlanAndExecuteOptions struct {
+	*schema.CallbackOptions
+	// InputKey is the key of the objective in the ChainValues.
+	InputKey string
+	// OutputKey is the key to store the final answer in the ChainValues.
+	OutputKey string
+	// PlanKey is the key to store the last plan in the ChainValues.
+	PlanKey string
+	// StepsKey is the key to store the executed steps in the ChainValues.
+	StepsKey string
+	// PlannerPrompt creates the initial plan from the input.
+	PlannerPrompt schema.PromptTemplate
+	// ReplannerPrompt revises the remaining steps from the input, plan and pastSteps.
+	ReplannerPrompt schema.PromptTemplate
+	// StepPrompt creates the input of the executor from the input, pastSteps and step.
+	StepPrompt schema.PromptTemplate
+	// MaxSteps is the maximum number of steps to execute.
+	MaxSteps int
+}
+
+// PlanAndExecute is an agent that first plans an ordered list of steps for the objective and then
+// executes the steps one after another with an executor, e.g. an agent.Executor. After every step
+// a replanner revises the remaining steps or returns the final answer.
+type PlanAndExecute struct {
+	planner   schema.Chain
+	replanner schema.Chain
+	executor  schema.Chain
+	opts      PlanAndExecuteOptions
+}
+
+// NewPlanAndExecute creates a new instance of the PlanAndExecute agent that uses the given model for
+// planning and the given executor to carry out the steps.
+func NewPlanAndExecute(model schema.Model, executor schema.Chain, optFns ...func(o *PlanAndExecuteOptions)) (*PlanAndExecute, error) {
+	opts := PlanAndExecuteOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey:  "input",
+		OutputKey: "output",
+		PlanKey:   "plan",
+		StepsKey:  "steps",
+		MaxSteps:  DefaultMaxSteps,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.PlannerPrompt == nil {
+		opts.PlannerPrompt = prompt.NewTemplate(defaultPlannerTemplate)
+	}
+
+	if opts.ReplannerPrompt == nil {
+		opts.ReplannerPrompt = prompt.NewTemplate(defaultReplannerTemplate)
+	}
+
+	if opts.StepPrompt == nil {
+		opts.StepPrompt = prompt.NewTemplate(defaultStepTemplate)
+	}
+
+	planner, err := chain.NewLLM(model, opts.PlannerPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	replanner, err := chain.NewLLM(model, opts.ReplannerPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlanAndExecute{
+		planner:   planner,
+		replanner: replanner,
+		executor:  executor,
+		opts:      opts,
+	}, nil
+}
+
+// Call executes the PlanAndExecute agent with the given context and inputs.
+// It returns the final answer, the last plan and the executed steps or an error, if any.
+func (a *PlanAndExecute) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	input, err := inputs.GetString(a.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := a.callLLM(ctx, a.planner, map[string]any{"input": input}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := parsePlan(output)
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+	}
+
+	if cbErr := a.onPlan(ctx, plan, opts); cbErr != nil {
+		return nil, cbErr
+	}
+
+	steps := []PlanStep{}
+
+	for len(steps) < a.opts.MaxSteps {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		step, err := a.executeStep(ctx, input, plan[0], steps, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		steps = append(steps, *step)
+
+		output, err := a.callLLM(ctx, a.replanner, map[string]any{
+			"input":     input,
+			"plan":      formatPlan(plan),
+			"pastSteps": formatPastSteps(steps),
+		}, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if answer, ok := parseFinalAnswer(output); ok {
+			return a.finish(ctx, answer, plan, steps, opts)
+		}
+
+		plan = parsePlan(output)
+		if len(plan) == 0 {
+			// Without remaining steps, the result of the last step is the answer.
+			return a.finish(ctx, step.Result, plan, steps, opts)
+		}
+
+		if cbErr := a.onPlan(ctx, plan, opts); cbErr != nil {
+			return nil, cbErr
+		}
+	}
+
+	return nil, ErrNotFinished
+}
+
+// executeStep carries out a single step of the plan with the executor.
+func (a *PlanAndExecute) executeStep(ctx context.Context, input, step string, pastSteps []PlanStep, opts schema.CallOptions) (*PlanStep, error) {
+	stepInput, err := a.opts.StepPrompt.Format(map[string]any{
+		"input":     input,
+		"pastSteps": formatPastSteps(pastSteps),
+		"step":      step,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := golc.Call(ctx, a.executor, schema.ChainValues{a.executor.InputKeys()[0]: stepInput}, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := resp.GetString(a.executor.OutputKeys()[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("\nStep: %s\nResult: %s", step, result),
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	return &PlanStep{Step: step, Result: result}, nil
+}
+
+// callLLM calls the planner or replanner chain and returns its text output.
+func (a *PlanAndExecute) callLLM(ctx context.Context, llmChain schema.Chain, inputs schema.ChainValues, opts schema.CallOptions) (string, error) {
+	resp, err := golc.Call(ctx, llmChain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	output, ok := resp[llmChain.OutputKeys()[0]].(string)
+	if !ok {
+		return "", ErrInvalidChainReturnType
+	}
+
+	return output, nil
+}
+
+// onPlan reports a new plan to the callbacks.
+func (a *PlanAndExecute) onPlan(ctx context.Context, plan []string, opts schema.CallOptions) error {
+	return opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("\nPlan:\n%s", formatPlan(plan)),
+	})
+}
+
+// finish reports the final answer to the callbacks and creates the outputs.
+func (a *PlanAndExecute) finish(ctx context.Context, answer string, plan []string, steps []PlanStep, opts schema.CallOptions) (schema.ChainValues, error) {
+	outputs := schema.ChainValues{
+		a.opts.OutputKey: answer,
+		a.opts.PlanKey:   plan,
+		a.opts.StepsKey:  steps,
+	}
+
+	if cbErr := opts.CallbackManger.OnAgentFinish(ctx, &schema.AgentFinishManagerInput{
+		Finish: &schema.AgentFinish{
+			ReturnValues: outputs,
+			Log:          answer,
+		},
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	return outputs, nil
+}
+
+// Memory returns the memory associated with the chain.
+func (a *PlanAndExecute) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (a *PlanAndExecute) Type() string {
+	return "PlanAndExecute"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (a *PlanAndExecute) Verbose() bool {
+	return a.opts.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (a *PlanAndExecute) Callbacks() []schema.Callback {
+	return a.opts.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (a *PlanAndExecute) InputKeys() []string {
+	return []string{a.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (a *PlanAndExecute) OutputKeys() []string {
+	return []string{a.opts.OutputKey, a.opts.PlanKey, a.opts.StepsKey}
+}
+
+var planStepRegexp = regexp.MustCompile(`^\s*\d+[.)]\s+(.+)$`)
+
+// parsePlan parses the numbered list of steps from the output of the planner.
+func parsePlan(output string) []string {
+	plan := []string{}
+
+	for _, line := range strings.Split(output, "\n") {
+		if matches := planStepRegexp.FindStringSubmatch(line); len(matches) == 2 {
+			plan = append(plan, strings.TrimSpace(matches[1]))
+		}
+	}
+
+	return plan
+}
+
+// parseFinalAnswer returns the final answer of the replanner, if any.
+func parseFinalAnswer(output string) (string, bool) {
+	if !strings.Contains(output, finalAnswerAction) {
+		return "", false
+	}
+
+	splits := strings.Split(output, finalAnswerAction)
+
+	return strings.TrimSpace(splits[len(splits)-1]), true
+}
+
+// formatPlan formats the steps of a plan as numbered list.
+func formatPlan(plan []string) string {
+	lines := make([]string, len(plan))
+	for i, step := range plan {
+		lines[i] = fmt.Sprintf("%d. %s", i+1, step)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatPastSteps formats the executed steps and their results.
+func formatPastSteps(steps []PlanStep) string {
+	if len(steps) == 0 {
+		return "None"
+	}
+
+	lines := make([]string, len(steps))
+	for i, step := range steps {
+		lines[i] = fmt.Sprintf("Step: %s\nResult: %s", step.Step, step.Result)
+	}
+
+	return strings.Join(lines, "\n\n")
+}
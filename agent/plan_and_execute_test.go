@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanAndExecute(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T) *Executor {
+		executor, err := NewExecutor(&mockAgent{
+			IKeys: []string{"input"},
+			OKeys: []string{"output"},
+			PlanFunc: func(ctx context.Context, steps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+				input, err := inputs.GetString("input")
+				require.NoError(t, err)
+
+				lines := strings.Split(input, "\n")
+				step := strings.TrimPrefix(lines[len(lines)-1], "Current step: ")
+
+				return nil, &schema.AgentFinish{
+					ReturnValues: schema.ChainValues{"output": "done: " + step},
+				}, nil
+			},
+		}, nil)
+		require.NoError(t, err)
+
+		return executor
+	}
+
+	t.Run("FinalAnswer", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(newSequenceFake(nil,
+			"Plan:\n1. Search the weather\n2. Respond to the user",
+			"Plan:\n1. Respond to the user",
+			"Final Answer: It is sunny.",
+		), newExecutor(t))
+		require.NoError(t, err)
+
+		outputs, err := agent.Call(context.Background(), schema.ChainValues{"input": "How is the weather?"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "It is sunny.", outputs["output"])
+		assert.Equal(t, []string{"Respond to the user"}, outputs["plan"])
+		assert.Equal(t, []PlanStep{
+			{Step: "Search the weather", Result: "done: Search the weather"},
+			{Step: "Respond to the user", Result: "done: Respond to the user"},
+		}, outputs["steps"])
+	})
+
+	t.Run("EmptyReplan", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(newSequenceFake(nil,
+			"Plan:\n1) Answer the question",
+			"Nothing left to do.",
+		), newExecutor(t))
+		require.NoError(t, err)
+
+		outputs, err := agent.Call(context.Background(), schema.ChainValues{"input": "question"})
+		require.NoError(t, err)
+		assert.Equal(t, "done: Answer the question", outputs["output"])
+	})
+
+	t.Run("MaxSteps", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(newSequenceFake(nil,
+			"1. First",
+			"1. Second",
+			"1. Third",
+		), newExecutor(t), func(o *PlanAndExecuteOptions) {
+			o.MaxSteps = 2
+		})
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "question"})
+		assert.ErrorIs(t, err, ErrNotFinished)
+	})
+
+	t.Run("InvalidPlan", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(newSequenceFake(nil, "I have no plan."), newExecutor(t))
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "question"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewPlanAndExecute(newSequenceFake(nil), newExecutor(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, "PlanAndExecute", agent.Type())
+		assert.Equal(t, []string{"input"}, agent.InputKeys())
+		assert.Equal(t, []string{"output", "plan", "steps"}, agent.OutputKeys())
+	})
+}
@@ -39,6 +39,7 @@ Thought: {{.agentScratchpad}}`
 )
 
 type ReactDescriptionOptions struct {
+	RunOptions
 	Prefix        string
 	Instructions  string
 	Suffix        string
@@ -47,6 +48,7 @@ type ReactDescriptionOptions struct {
 }
 
 type ReactDescription struct {
+	model schema.Model
 	chain schema.Chain
 	tools []schema.Tool
 	opts  ReactDescriptionOptions
@@ -73,21 +75,28 @@ func NewReactDescription(llm schema.Model, tools []schema.Tool, optFns ...func(o
 	}
 
 	agent := &ReactDescription{
+		model: llm,
 		chain: llmChain,
 		tools: tools,
 		opts:  opts,
 	}
 
 	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
 		o.MaxIterations = opts.MaxIterations
 		o.AgentChainType = "ReactDescription"
 	})
 }
 
 func (a *ReactDescription) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
 	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
 
-	resp, err := golc.Call(ctx, a.chain, inputs)
+	resp, err := golc.Call(ctx, a.chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = rm.GetInheritableCallbacks()
+		co.ParentRunID = rm.RunID()
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,6 +109,11 @@ func (a *ReactDescription) Plan(ctx context.Context, intermediateSteps []schema.
 	return a.parseOutput(output)
 }
 
+// Model returns the model of the agent.
+func (a *ReactDescription) Model() schema.Model {
+	return a.model
+}
+
 func (a *ReactDescription) InputKeys() []string {
 	chainInputs := a.chain.InputKeys()
 
@@ -0,0 +1,349 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure ReWOO satisfies the agent interface.
+var _ schema.Agent = (*ReWOO)(nil)
+
+const (
+	defaultReWOOPlannerTemplate = `For the following task, make plans that can solve the problem step by step. For each plan, indicate which external tool together with tool input to retrieve evidence. You can store the evidence into a variable #E that can be called by later tools. (Plan, #E1, Plan, #E2, Plan, ...)
+
+Tools can be one of the following:
+{{.toolDescriptions}}
+
+For example,
+Task: Thomas, Toby, and Rebecca worked a total of 157 hours in one week. Thomas worked x hours. Toby worked 10 hours less than twice what Thomas worked, and Rebecca worked 8 hours less than Toby. How many hours did Rebecca work?
+Plan: Given Thomas worked x hours, translate the problem into algebraic expressions and solve it with a calculator.
+#E1 = Calculator[Solve x + (2x - 10) + ((2x - 10) - 8) = 157]
+Plan: Calculate the number of hours Rebecca worked.
+#E2 = Calculator[(2 * #E1 - 10) - 8]
+
+Begin! Describe your plans with rich details. Each Plan should be followed by only one #E. Only use the tools listed above.
+
+Task: {{.input}}`
+
+	defaultReWOOSolverTemplate = `Solve the following task or problem. To solve the problem, we have made step-by-step Plan and retrieved corresponding Evidence to each Plan. Use them with caution since long evidence might contain irrelevant information.
+
+{{.plan}}
+
+Now solve the question or task according to provided Evidence above. Respond with the answer directly with no extra words.
+
+Task: {{.input}}
+Response:`
+)
+
+var (
+	reWOOEvidenceRegexp = regexp.MustCompile(`^\s*(#E\d+)\s*=\s*([^\[]+?)\s*\[(.*)\]\s*$`)
+	reWOOVariableRegexp = regexp.MustCompile(`#E\d+`)
+)
+
+// ReWOOOptions represents the configuration options for the ReWOO agent.
+type ReWOOOptions struct {
+	RunOptions
+	PlannerTemplate string
+	SolverTemplate  string
+	OutputKey       string
+	// MaxIterations is the maximum number of rounds of tool calls. Each round executes the planned
+	// tool calls whose evidence is available, so it limits the depth of the variable references in
+	// the plan. A deeper plan fails with ErrPlanTooDeep before any tool is called.
+	MaxIterations int
+}
+
+// ReWOO is a reasoning without observation agent. A planner plans all tool calls up front and
+// references the evidence of previous tool calls with variables (#E1, #E2, ...). The tool calls
+// are executed as soon as the evidence they reference is available, and a solver answers the
+// task with the plan and the evidence in one final model call.
+type ReWOO struct {
+	model    schema.Model
+	planner  schema.Chain
+	solver   schema.Chain
+	toolsMap map[string]schema.Tool
+	opts     ReWOOOptions
+}
+
+// NewReWOO creates a new instance of the ReWOO agent with the given model and tools.
+func NewReWOO(model schema.Model, tools []schema.Tool, optFns ...func(o *ReWOOOptions)) (*Executor, error) {
+	opts := ReWOOOptions{
+		PlannerTemplate: defaultReWOOPlannerTemplate,
+		SolverTemplate:  defaultReWOOSolverTemplate,
+		OutputKey:       "output",
+		MaxIterations:   DefaultMaxIterations,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	planner, err := chain.NewLLM(model, prompt.NewTemplate(opts.PlannerTemplate, func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"toolDescriptions": toolDescriptions(tools),
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	solver, err := chain.NewLLM(model, prompt.NewTemplate(opts.SolverTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	toolsMap := make(map[string]schema.Tool, len(tools))
+	for _, t := range tools {
+		toolsMap[t.Name()] = t
+	}
+
+	agent := &ReWOO{
+		model:    model,
+		planner:  planner,
+		solver:   solver,
+		toolsMap: toolsMap,
+		opts:     opts,
+	}
+
+	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
+		o.MaxIterations = opts.MaxIterations
+		o.AgentChainType = "ReWOO"
+	})
+}
+
+// reWOOStep is a planned tool call of the ReWOO agent.
+type reWOOStep struct {
+	Plan     string
+	Variable string
+	Tool     string
+	Input    string
+}
+
+// Plan executes the agent with the given context, intermediate steps, and inputs. The first call
+// creates the plan. Subsequent calls return the tool calls whose evidence is available and finally
+// the answer of the solver.
+func (a *ReWOO) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
+	var planOutput string
+
+	// The plan is stored in the message log of the actions, so the agent itself stays stateless.
+	restored := len(intermediateSteps) > 0 && len(intermediateSteps[0].Action.MessageLog) > 0
+	if restored {
+		planOutput = intermediateSteps[0].Action.MessageLog[0].Content()
+	} else {
+		output, err := a.call(ctx, a.planner, inputs, rm)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		planOutput = output
+	}
+
+	steps := parseReWOOPlan(planOutput)
+
+	if !restored {
+		if rounds := reWOOPlanRounds(steps); rounds > a.opts.MaxIterations {
+			return nil, nil, fmt.Errorf("%w: the plan needs %d rounds, but max iterations is %d", ErrPlanTooDeep, rounds, a.opts.MaxIterations)
+		}
+	}
+
+	evidence := make(map[string]string, len(intermediateSteps))
+	for _, step := range intermediateSteps {
+		evidence[step.Action.ToolCallID] = step.Observation
+	}
+
+	if actions := a.nextActions(planOutput, steps, evidence); len(actions) > 0 {
+		return actions, nil, nil
+	}
+
+	plan := make([]string, len(steps))
+	for i, step := range steps {
+		plan[i] = fmt.Sprintf("Plan: %s\n%s = %s[%s]\nEvidence: %s", step.Plan, step.Variable, step.Tool, substituteReWOOVariables(step.Input, evidence), evidence[step.Variable])
+	}
+
+	answer, err := a.call(ctx, a.solver, map[string]any{
+		"input": inputs["input"],
+		"plan":  strings.Join(plan, "\n\n"),
+	}, rm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{
+			a.opts.OutputKey: strings.TrimSpace(answer),
+		},
+		Log: answer,
+	}, nil
+}
+
+// Model returns the model of the agent.
+func (a *ReWOO) Model() schema.Model {
+	return a.model
+}
+
+// InputKeys returns the expected input keys for the agent.
+func (a *ReWOO) InputKeys() []string {
+	return []string{"input"}
+}
+
+// OutputKeys returns the output keys that the agent will return.
+func (a *ReWOO) OutputKeys() []string {
+	return []string{a.opts.OutputKey}
+}
+
+// nextActions returns the actions of all planned steps that have not been executed yet and whose
+// referenced evidence is available. References to variables that are not part of the plan are ignored.
+func (a *ReWOO) nextActions(planOutput string, steps []reWOOStep, evidence map[string]string) []*schema.AgentAction {
+	planned := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		planned[step.Variable] = true
+	}
+
+	actions := []*schema.AgentAction{}
+
+	for _, step := range steps {
+		if _, done := evidence[step.Variable]; done {
+			continue
+		}
+
+		ready := true
+
+		for _, variable := range reWOOVariableRegexp.FindAllString(step.Input, -1) {
+			if _, ok := evidence[variable]; planned[variable] && !ok {
+				ready = false
+				break
+			}
+		}
+
+		if !ready {
+			continue
+		}
+
+		input := substituteReWOOVariables(step.Input, evidence)
+
+		toolInput := schema.NewToolInputFromString(input)
+		if t, ok := a.toolsMap[step.Tool]; ok && t.ArgsType().Kind() != reflect.String {
+			toolInput = schema.NewToolInputFromArguments(input)
+		}
+
+		actions = append(actions, &schema.AgentAction{
+			Tool:       step.Tool,
+			ToolInput:  toolInput,
+			Log:        fmt.Sprintf("Plan: %s\n%s = %s[%s]", step.Plan, step.Variable, step.Tool, input),
+			MessageLog: schema.ChatMessages{schema.NewAIChatMessage(planOutput)},
+			ToolCallID: step.Variable,
+		})
+	}
+
+	return actions
+}
+
+// call calls the planner or solver chain and returns its text output.
+func (a *ReWOO) call(ctx context.Context, llmChain schema.Chain, inputs schema.ChainValues, rm schema.CallbackManagerForChainRun) (string, error) {
+	resp, err := golc.Call(ctx, llmChain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = rm.GetInheritableCallbacks()
+		co.ParentRunID = rm.RunID()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	output, ok := resp[llmChain.OutputKeys()[0]].(string)
+	if !ok {
+		return "", ErrInvalidChainReturnType
+	}
+
+	return output, nil
+}
+
+// parseReWOOPlan parses the planned tool calls from the output of the planner.
+func parseReWOOPlan(output string) []reWOOStep {
+	steps := []reWOOStep{}
+	plan := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		if _, description, ok := strings.Cut(line, "Plan:"); ok {
+			plan = strings.TrimSpace(description)
+			continue
+		}
+
+		if matches := reWOOEvidenceRegexp.FindStringSubmatch(line); len(matches) == 4 {
+			steps = append(steps, reWOOStep{
+				Plan:     plan,
+				Variable: matches[1],
+				Tool:     matches[2],
+				Input:    matches[3],
+			})
+		}
+	}
+
+	return steps
+}
+
+// reWOOPlanRounds returns the number of rounds of tool calls needed to execute the planned steps.
+// Each round executes the steps whose referenced evidence is available, like nextActions.
+func reWOOPlanRounds(steps []reWOOStep) int {
+	planned := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		planned[step.Variable] = true
+	}
+
+	available := make(map[string]bool, len(steps))
+	done := make([]bool, len(steps))
+	rounds := 0
+
+	for {
+		ready := []int{}
+
+		for i, step := range steps {
+			if done[i] || available[step.Variable] {
+				continue
+			}
+
+			isReady := true
+
+			for _, variable := range reWOOVariableRegexp.FindAllString(step.Input, -1) {
+				if planned[variable] && !available[variable] {
+					isReady = false
+					break
+				}
+			}
+
+			if isReady {
+				ready = append(ready, i)
+			}
+		}
+
+		if len(ready) == 0 {
+			return rounds
+		}
+
+		for _, i := range ready {
+			done[i] = true
+			available[steps[i].Variable] = true
+		}
+
+		rounds++
+	}
+}
+
+// substituteReWOOVariables replaces the variables in the input with the available evidence.
+func substituteReWOOVariables(input string, evidence map[string]string) string {
+	return reWOOVariableRegexp.ReplaceAllStringFunc(input, func(variable string) string {
+		if e, ok := evidence[variable]; ok {
+			return e
+		}
+
+		return variable
+	})
+}
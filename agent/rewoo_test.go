@@ -0,0 +1,105 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReWOO(t *testing.T) {
+	t.Parallel()
+
+	t.Run("VariableReferences", func(t *testing.T) {
+		t.Parallel()
+
+		mu := sync.Mutex{}
+		calls := []string{}
+
+		newTool := func(name string) schema.Tool {
+			return &mockTool{
+				ToolName:        name,
+				ToolDescription: fmt.Sprintf("Runs %s.", name),
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					mu.Lock()
+					defer mu.Unlock()
+
+					calls = append(calls, fmt.Sprintf("%s[%s]", name, input))
+
+					return strings.ToUpper(input.(string)), nil
+				},
+			}
+		}
+
+		prompts := []string{}
+
+		agent, err := NewReWOO(newSequenceFake(&prompts,
+			"Plan: Search for the first topic.\n#E1 = Search[golc]\nPlan: Search for the second topic.\n#E2 = Search[go]\nPlan: Combine both results.\n#E3 = LLM[#E1 and #E2]",
+			"GOLC AND GO",
+		), []schema.Tool{newTool("Search"), newTool("LLM")})
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{"input": "combine golc and go"})
+		require.NoError(t, err)
+		assert.Equal(t, "GOLC AND GO", output["output"])
+
+		require.Len(t, calls, 3)
+		assert.ElementsMatch(t, []string{"Search[golc]", "Search[go]"}, calls[:2])
+		assert.Equal(t, "LLM[GOLC and GO]", calls[2])
+
+		require.Len(t, prompts, 2)
+		assert.Contains(t, prompts[0], "- Search: Runs Search.\n- LLM: Runs LLM.")
+		assert.Contains(t, prompts[1], "Plan: Combine both results.\n#E3 = LLM[GOLC and GO]\nEvidence: GOLC AND GO")
+		assert.True(t, strings.HasSuffix(prompts[1], "Task: combine golc and go\nResponse:"))
+	})
+
+	t.Run("PlanTooDeep", func(t *testing.T) {
+		t.Parallel()
+
+		prompts := []string{}
+
+		agent, err := NewReWOO(newSequenceFake(&prompts,
+			"#E1 = Search[golc]\n#E2 = Search[#E1]\n#E3 = Search[#E2]",
+		), []schema.Tool{&mockTool{ToolName: "Search"}}, func(o *ReWOOOptions) {
+			o.MaxIterations = 2
+		})
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "search three times"})
+		assert.ErrorIs(t, err, ErrPlanTooDeep)
+	})
+
+	t.Run("NoToolCalls", func(t *testing.T) {
+		t.Parallel()
+
+		prompts := []string{}
+
+		agent, err := NewReWOO(newSequenceFake(&prompts, "No tools are needed.", "42"), []schema.Tool{&mockTool{}})
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{"input": "What is 6 * 7?"})
+		require.NoError(t, err)
+		assert.Equal(t, "42", output["output"])
+		assert.Len(t, prompts, 2)
+	})
+}
+
+func TestParseReWOOPlan(t *testing.T) {
+	steps := parseReWOOPlan("Plan: Find the capital.\n#E1 = Wikipedia[capital of France]\nPlan: Count the letters.\n #E2 = Calculator [len(#E1)] \ninvalid line")
+
+	assert.Equal(t, []reWOOStep{
+		{Plan: "Find the capital.", Variable: "#E1", Tool: "Wikipedia", Input: "capital of France"},
+		{Plan: "Count the letters.", Variable: "#E2", Tool: "Calculator", Input: "len(#E1)"},
+	}, steps)
+}
+
+func TestReWOOPlanRounds(t *testing.T) {
+	steps := parseReWOOPlan("#E1 = Search[a]\n#E2 = Search[b]\n#E3 = LLM[#E1 and #E2]\n#E4 = LLM[#E3 and #E9]")
+	assert.Equal(t, 3, reWOOPlanRounds(steps))
+	assert.Equal(t, 0, reWOOPlanRounds(nil))
+}
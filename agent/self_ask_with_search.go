@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SelfAskWithSearch satisfies the agent interface.
+var _ schema.Agent = (*SelfAskWithSearch)(nil)
+
+const (
+	defaultSelfAskWithSearchTemplate = `Question: Who lived longer, Muhammad Ali or Alan Turing?
+Are follow up questions needed here: Yes.
+Follow up: How old was Muhammad Ali when he died?
+Intermediate answer: Muhammad Ali was 74 years old when he died.
+Follow up: How old was Alan Turing when he died?
+Intermediate answer: Alan Turing was 41 years old when he died.
+So the final answer is: Muhammad Ali
+
+Question: When was the founder of craigslist born?
+Are follow up questions needed here: Yes.
+Follow up: Who was the founder of craigslist?
+Intermediate answer: Craigslist was founded by Craig Newmark.
+Follow up: When was Craig Newmark born?
+Intermediate answer: Craig Newmark was born on December 6, 1952.
+So the final answer is: December 6, 1952
+
+Question: Who was the maternal grandfather of George Washington?
+Are follow up questions needed here: Yes.
+Follow up: Who was the mother of George Washington?
+Intermediate answer: The mother of George Washington was Mary Ball Washington.
+Follow up: Who was the father of Mary Ball Washington?
+Intermediate answer: The father of Mary Ball Washington was Joseph Ball.
+So the final answer is: Joseph Ball
+
+Question: Are both the directors of Jaws and Casino Royale from the same country?
+Are follow up questions needed here: Yes.
+Follow up: Who is the director of Jaws?
+Intermediate answer: The director of Jaws is Steven Spielberg.
+Follow up: Where is Steven Spielberg from?
+Intermediate answer: The United States.
+Follow up: Who is the director of Casino Royale?
+Intermediate answer: The director of Casino Royale is Martin Campbell.
+Follow up: Where is Martin Campbell from?
+Intermediate answer: New Zealand.
+So the final answer is: No
+
+Question: {{.input}}
+Are followup questions needed here:{{.agentScratchpad}}`
+
+	selfAskFollowUp           = "Follow up:"
+	selfAskIntermediateAnswer = "Intermediate answer:"
+	selfAskFinalAnswer        = "So the final answer is:"
+)
+
+// SelfAskWithSearchOptions represents the configuration options for the SelfAskWithSearch agent.
+type SelfAskWithSearchOptions struct {
+	RunOptions
+	Template      string
+	OutputKey     string
+	MaxIterations int
+}
+
+// SelfAskWithSearch is an agent that decomposes a question into follow up questions. Each follow
+// up question is answered by a single search tool, until the model knows the final answer.
+type SelfAskWithSearch struct {
+	model      schema.Model
+	chain      schema.Chain
+	searchTool schema.Tool
+	opts       SelfAskWithSearchOptions
+}
+
+// NewSelfAskWithSearch creates a new instance of the SelfAskWithSearch agent with the given model and search tool.
+func NewSelfAskWithSearch(model schema.Model, searchTool schema.Tool, optFns ...func(o *SelfAskWithSearchOptions)) (*Executor, error) {
+	opts := SelfAskWithSearchOptions{
+		Template:      defaultSelfAskWithSearchTemplate,
+		OutputKey:     "output",
+		MaxIterations: DefaultMaxIterations,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	llmChain, err := chain.NewLLM(model, prompt.NewTemplate(opts.Template))
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &SelfAskWithSearch{
+		model:      model,
+		chain:      llmChain,
+		searchTool: searchTool,
+		opts:       opts,
+	}
+
+	return NewExecutor(agent, []schema.Tool{searchTool}, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
+		o.MaxIterations = opts.MaxIterations
+		o.AgentChainType = "SelfAskWithSearch"
+	})
+}
+
+// Plan executes the agent with the given context, intermediate steps, and inputs.
+// It returns the agent actions, agent finish, or an error, if any.
+func (a *SelfAskWithSearch) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
+	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
+
+	resp, err := golc.Call(ctx, a.chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = rm.GetInheritableCallbacks()
+		co.ParentRunID = rm.RunID()
+		co.Stop = []string{"\n" + selfAskIntermediateAnswer}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, ok := resp[a.chain.OutputKeys()[0]].(string)
+	if !ok {
+		return nil, nil, ErrInvalidChainReturnType
+	}
+
+	return a.parseOutput(output)
+}
+
+// Model returns the model of the agent.
+func (a *SelfAskWithSearch) Model() schema.Model {
+	return a.model
+}
+
+// InputKeys returns the expected input keys for the agent.
+func (a *SelfAskWithSearch) InputKeys() []string {
+	return []string{"input"}
+}
+
+// OutputKeys returns the output keys that the agent will return.
+func (a *SelfAskWithSearch) OutputKeys() []string {
+	return []string{a.opts.OutputKey}
+}
+
+// constructScratchPad constructs the scratchpad with the follow up questions
+// and their intermediate answers.
+func (a *SelfAskWithSearch) constructScratchPad(steps []schema.AgentStep) string {
+	scratchPad := ""
+	for _, step := range steps {
+		scratchPad += step.Action.Log
+		scratchPad += fmt.Sprintf("\n%s %s\n", selfAskIntermediateAnswer, step.Observation)
+	}
+
+	return scratchPad
+}
+
+// parseOutput parses a follow up question or the final answer from the last line of the output.
+func (a *SelfAskWithSearch) parseOutput(output string) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	lastLine := lines[len(lines)-1]
+
+	for _, followUp := range []string{selfAskFollowUp, "Followup:"} {
+		if _, question, ok := strings.Cut(lastLine, followUp); ok {
+			return []*schema.AgentAction{
+				{Tool: a.searchTool.Name(), ToolInput: schema.NewToolInputFromString(strings.TrimSpace(question)), Log: output},
+			}, nil, nil
+		}
+	}
+
+	if _, answer, ok := strings.Cut(lastLine, selfAskFinalAnswer); ok {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.opts.OutputKey: strings.TrimSpace(answer),
+			},
+			Log: output,
+		}, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+}
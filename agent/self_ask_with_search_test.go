@@ -0,0 +1,56 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfAskWithSearch(t *testing.T) {
+	t.Parallel()
+
+	searchTool := &mockTool{
+		ToolName: "Intermediate Answer",
+		ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+			if input.(string) == "Who was the founder of craigslist?" {
+				return "Craig Newmark", nil
+			}
+
+			return "December 6, 1952", nil
+		},
+	}
+
+	t.Run("FollowUpQuestions", func(t *testing.T) {
+		t.Parallel()
+
+		prompts := []string{}
+
+		agent, err := NewSelfAskWithSearch(newSequenceFake(&prompts,
+			" Yes.\nFollow up: Who was the founder of craigslist?",
+			"Follow up: When was Craig Newmark born?",
+			"So the final answer is: December 6, 1952",
+		), searchTool)
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{"input": "When was the founder of craigslist born?"})
+		require.NoError(t, err)
+		assert.Equal(t, "December 6, 1952", output["output"])
+
+		require.Len(t, prompts, 3)
+		assert.True(t, strings.HasSuffix(prompts[2], "Are followup questions needed here: Yes.\nFollow up: Who was the founder of craigslist?\nIntermediate answer: Craig Newmark\nFollow up: When was Craig Newmark born?\nIntermediate answer: December 6, 1952\n"))
+	})
+
+	t.Run("UnableToParseOutput", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewSelfAskWithSearch(newSequenceFake(&[]string{}, "I don't know."), searchTool)
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "When was the founder of craigslist born?"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+}
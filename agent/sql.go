@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"strconv"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/toolkit"
+)
+
+// defaultSQLSystemTemplate defines the default system message template of the SQL agent.
+const defaultSQLSystemTemplate = `You are an agent designed to interact with an SQL database.
+Given an input question, create a syntactically correct {{.dialect}} query to run, then look at the results of the query and return the answer.
+Unless the user specifies a specific number of examples they wish to obtain, always limit your query to at most {{.topK}} results.
+You can order the results by a relevant column to return the most interesting examples in the database.
+Never query for all the columns from a specific table, only ask for the relevant columns given the question.
+
+Always start by listing the tables in the database with list_tables to see what you can query. Then describe the schema of the most relevant tables with describe_tables.
+Only use the columns you have seen in the schema description.
+Double check your query with query_checker before executing it with execute_query.
+If you get an error while executing a query, rewrite the query using the error message and try again.
+
+DO NOT make any DML statements (INSERT, UPDATE, DELETE, DROP etc.) to the database. Only SELECT queries are allowed.`
+
+// SQLOptions represents the configuration options for the SQL agent.
+type SQLOptions struct {
+	*schema.CallbackOptions
+	RunOptions
+	// OutputKey is the key to store the output of the agent in the ChainValues.
+	OutputKey string
+	// SystemTemplate is the template of the system message. It can use the dialect and topK variables.
+	SystemTemplate string
+	// TopK is the number of results the agent should query at most. It is also the row limit of the execute_query tool.
+	TopK          uint
+	MaxIterations int
+}
+
+// NewSQL creates a new ToolCalling agent that answers questions about an SQL database. The agent
+// uses the tools of the SQL toolkit iteratively: it lists and describes the tables, checks its
+// queries and rewrites them if their execution fails. Only read-only queries are executed.
+func NewSQL(model schema.ChatModel, db *sqldb.SQLDB, optFns ...func(o *SQLOptions)) (*Executor, error) {
+	opts := SQLOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		OutputKey:      "output",
+		SystemTemplate: defaultSQLSystemTemplate,
+		TopK:           10,
+		MaxIterations:  15,
+	}
+
+	// Errors of the tools, e.g. invalid queries, are passed to the model to correct its queries.
+	opts.HandleToolErrors = true
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	sqlToolkit, err := toolkit.NewSQL(model, db, func(o *toolkit.SQLOptions) {
+		o.RowLimit = opts.TopK
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	systemMessage := prompt.NewSystemMessageTemplate(opts.SystemTemplate, func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"dialect": db.Dialect(),
+			"topK":    strconv.FormatUint(uint64(opts.TopK), 10),
+		}
+	})
+
+	return NewToolCalling(model, sqlToolkit.Tools(), func(o *ToolCallingOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+		o.RunOptions = opts.RunOptions
+		o.OutputKey = opts.OutputKey
+		o.SystemMessage = systemMessage
+		o.MaxIterations = opts.MaxIterations
+	})
+}
@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQL(t *testing.T) {
+	engine, err := sqldb.NewSQLite3(":memory:")
+	require.NoError(t, err)
+
+	defer engine.Close()
+
+	_, err = engine.Exec(context.Background(), "CREATE TABLE products (id int NOT NULL, name text, price int);")
+	require.NoError(t, err)
+
+	_, err = engine.Exec(context.Background(), "INSERT INTO products (id, name, price) VALUES (1, 'apple', 3), (2, 'pear', 5);")
+	require.NoError(t, err)
+
+	db, err := sqldb.New(engine)
+	require.NoError(t, err)
+
+	// Each turn of the model calls one tool. The observations of the previous turns are checked by the next turn.
+	turns := []struct {
+		observation string
+		toolCall    *schema.ToolCall
+	}{
+		{toolCall: &schema.ToolCall{ID: "call_1", Name: "list_tables", Arguments: `{}`}},
+		{observation: "products", toolCall: &schema.ToolCall{ID: "call_2", Name: "describe_tables", Arguments: `{"__arg1": "products"}`}},
+		{observation: "CREATE TABLE `products`", toolCall: &schema.ToolCall{ID: "call_3", Name: "execute_query", Arguments: `{"__arg1": "SELECT cost FROM products"}`}},
+		{observation: "Error: no such column: cost", toolCall: &schema.ToolCall{ID: "call_4", Name: "execute_query", Arguments: `{"__arg1": "DELETE FROM products"}`}},
+		{observation: "Error: only read-only SELECT queries are allowed", toolCall: &schema.ToolCall{ID: "call_5", Name: "execute_query", Arguments: `{"__arg1": "SELECT name FROM products ORDER BY price DESC LIMIT 1"}`}},
+		{observation: "name\npear\n"},
+	}
+
+	turn := 0
+
+	model := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+		require.Less(t, turn, len(turns))
+
+		if turn == 0 {
+			assert.Contains(t, messages[0].Content(), "sqlite3 query")
+		} else {
+			observation, ok := messages[len(messages)-1].(*schema.ToolChatMessage)
+			require.True(t, ok)
+			assert.Contains(t, observation.Content(), turns[turn].observation)
+		}
+
+		generation := schema.Generation{
+			Text:    "The most expensive product is the pear.",
+			Message: schema.NewAIChatMessage("The most expensive product is the pear."),
+		}
+
+		if tc := turns[turn].toolCall; tc != nil {
+			generation = schema.Generation{
+				Message: schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+					o.ToolCalls = []schema.ToolCall{*tc}
+				}),
+			}
+		}
+
+		turn++
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{generation},
+			LLMOutput:   map[string]any{},
+		}, nil
+	}, func(o *chatmodel.FakeOptions) {
+		o.ChatModelType = "chatmodel.OpenAI"
+	})
+
+	agent, err := NewSQL(model, db)
+	require.NoError(t, err)
+
+	output, err := golc.SimpleCall(context.Background(), agent, "Which product is the most expensive?")
+	require.NoError(t, err)
+	assert.Equal(t, "The most expensive product is the pear.", output)
+	assert.Equal(t, len(turns), turn)
+
+	agent, err = NewSQL(model, db, func(o *SQLOptions) {
+		o.HandleToolErrors = false
+	})
+	require.NoError(t, err)
+	assert.False(t, agent.opts.HandleToolErrors)
+}
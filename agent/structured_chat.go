@@ -0,0 +1,284 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// Compile time check to ensure StructuredChat satisfies the agent interface.
+var _ schema.Agent = (*StructuredChat)(nil)
+
+const (
+	defaultStructuredChatPrefix = `Respond to the human as helpfully and accurately as possible. You have access to the following tools:
+
+{{.toolDescriptions}}`
+
+	defaultStructuredChatInstructions = `Use a json blob to specify a tool by providing an action key (tool name) and an action_input key (tool input).
+
+Valid "action" values: "Final Answer" or {{.toolNames}}
+
+Provide only ONE action per $JSON_BLOB, as shown:
+
+` + "```" + `
+{
+  "action": $TOOL_NAME,
+  "action_input": $INPUT
+}
+` + "```" + `
+
+Follow this format:
+
+Question: input question to answer
+Thought: consider previous and subsequent steps
+Action:
+` + "```" + `
+$JSON_BLOB
+` + "```" + `
+Observation: action result
+... (repeat Thought/Action/Observation N times)
+Thought: I know what to respond
+Action:
+` + "```" + `
+{
+  "action": "Final Answer",
+  "action_input": "Final response to human"
+}
+` + "```"
+
+	defaultStructuredChatSuffix = `Begin! Reminder to ALWAYS respond with a valid json blob of a single action. Use tools if necessary. Respond directly if appropriate. Format is Action:` + "```" + `$JSON_BLOB` + "```" + `then Observation:.
+
+Question: {{.input}}
+Thought: {{.agentScratchpad}}`
+
+	structuredChatFinalAnswerAction = "Final Answer"
+)
+
+// StructuredChatOptions represents the configuration options for the StructuredChat agent.
+type StructuredChatOptions struct {
+	*schema.CallbackOptions
+	RunOptions
+	Prefix        string
+	Instructions  string
+	Suffix        string
+	OutputKey     string
+	MaxIterations int
+}
+
+// StructuredChat is a ReAct agent that expresses its actions as JSON blobs. The action inputs are
+// validated against the argument schemas of the tools, so tools with multiple arguments can be
+// used with any model.
+type StructuredChat struct {
+	model   schema.Model
+	chain   schema.Chain
+	schemas map[string]*jsonschema.Schema
+	opts    StructuredChatOptions
+}
+
+// NewStructuredChat creates a new instance of the StructuredChat agent with the given model and tools.
+// It returns an error if it fails to convert tools to function definitions.
+func NewStructuredChat(model schema.Model, tools []schema.Tool, optFns ...func(o *StructuredChatOptions)) (*Executor, error) {
+	opts := StructuredChatOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Prefix:        defaultStructuredChatPrefix,
+		Instructions:  defaultStructuredChatInstructions,
+		Suffix:        defaultStructuredChatSuffix,
+		OutputKey:     "output",
+		MaxIterations: DefaultMaxIterations,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	schemas := make(map[string]*jsonschema.Schema, len(tools))
+	descriptions := make([]string, len(tools))
+
+	for i, t := range tools {
+		f, err := tool.ToFunction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		schemas[t.Name()] = &jsonschema.Schema{
+			Type:       f.Parameters.Type,
+			Properties: f.Parameters.Properties,
+			Required:   f.Parameters.Required,
+		}
+
+		args, err := json.Marshal(f.Parameters.Properties)
+		if err != nil {
+			return nil, err
+		}
+
+		descriptions[i] = fmt.Sprintf("%s: %s, args: %s", t.Name(), t.Description(), args)
+	}
+
+	prompt := prompt.NewTemplate(strings.Join([]string{opts.Prefix, opts.Instructions, opts.Suffix}, "\n\n"), func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"toolNames":        toolNames(tools),
+			"toolDescriptions": strings.Join(descriptions, "\n"),
+		}
+	})
+
+	llmChain, err := chain.NewLLM(model, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &StructuredChat{
+		model:   model,
+		chain:   llmChain,
+		schemas: schemas,
+		opts:    opts,
+	}
+
+	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.CallbackOptions = opts.CallbackOptions
+		o.RunOptions = opts.RunOptions
+		o.MaxIterations = opts.MaxIterations
+		o.AgentChainType = "StructuredChat"
+	})
+}
+
+// Plan executes the agent with the given context, intermediate steps, and inputs.
+// It returns the agent actions, agent finish, or an error, if any.
+func (a *StructuredChat) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
+	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
+
+	resp, err := golc.Call(ctx, a.chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = rm.GetInheritableCallbacks()
+		co.ParentRunID = rm.RunID()
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output, ok := resp[a.chain.OutputKeys()[0]].(string)
+	if !ok {
+		return nil, nil, ErrInvalidChainReturnType
+	}
+
+	return a.parseOutput(output)
+}
+
+// Model returns the model of the agent.
+func (a *StructuredChat) Model() schema.Model {
+	return a.model
+}
+
+// InputKeys returns the expected input keys for the agent.
+func (a *StructuredChat) InputKeys() []string {
+	chainInputs := a.chain.InputKeys()
+
+	agentInput := make([]string, 0, len(chainInputs))
+
+	for _, v := range chainInputs {
+		if v == "agentScratchpad" {
+			continue
+		}
+
+		agentInput = append(agentInput, v)
+	}
+
+	return agentInput
+}
+
+// OutputKeys returns the output keys that the agent will return.
+func (a *StructuredChat) OutputKeys() []string {
+	return []string{a.opts.OutputKey}
+}
+
+// constructScratchPad constructs the scratchpad that lets the agent
+// continue its thought process.
+func (a *StructuredChat) constructScratchPad(steps []schema.AgentStep) string {
+	scratchPad := ""
+	for _, step := range steps {
+		scratchPad += step.Action.Log
+		scratchPad += fmt.Sprintf("\nObservation: %s\nThought:", step.Observation)
+	}
+
+	return scratchPad
+}
+
+// structuredChatAction is the JSON action blob of the StructuredChat agent.
+type structuredChatAction struct {
+	Action      string `json:"action"`
+	ActionInput any    `json:"action_input"`
+}
+
+// parseOutput parses the JSON action blob from the output of the model. The action input of tools
+// is validated against the argument schema of the tool.
+func (a *StructuredChat) parseOutput(output string) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	text := output
+
+	if matches := jsonCodeBlockRegexp.FindStringSubmatch(output); len(matches) == 2 {
+		text = matches[1]
+	} else if start, end := strings.Index(output, "{"), strings.LastIndex(output, "}"); start >= 0 && end > start {
+		text = output[start : end+1]
+	}
+
+	action := structuredChatAction{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(text)), &action); err != nil || action.Action == "" {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, output)
+	}
+
+	if action.Action == structuredChatFinalAnswerAction {
+		answer, ok := action.ActionInput.(string)
+		if !ok {
+			b, err := json.Marshal(action.ActionInput)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			answer = string(b)
+		}
+
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.opts.OutputKey: answer,
+			},
+			Log: output,
+		}, nil
+	}
+
+	toolSchema, ok := a.schemas[action.Action]
+	if !ok {
+		// Unknown tools are reported back to the model by the executor.
+		return []*schema.AgentAction{
+			{Tool: action.Action, ToolInput: schema.NewToolInputFromString(fmt.Sprint(action.ActionInput)), Log: output},
+		}, nil, nil
+	}
+
+	// Tools with a single string argument may be called with a plain string input.
+	if input, isString := action.ActionInput.(string); isString {
+		if _, hasArg := toolSchema.Properties["__arg1"]; hasArg {
+			action.ActionInput = map[string]any{"__arg1": input}
+		}
+	}
+
+	if err := toolSchema.Validate(action.ActionInput); err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid input for tool %s: %w", ErrUnableToParseOutput, action.Action, err)
+	}
+
+	arguments, err := json.Marshal(action.ActionInput)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*schema.AgentAction{
+		{Tool: action.Action, ToolInput: schema.NewToolInputFromArguments(string(arguments)), Log: output},
+	}, nil, nil
+}
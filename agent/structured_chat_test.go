@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchArgs struct {
+	Query string `json:"query"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+func TestStructuredChat(t *testing.T) {
+	t.Parallel()
+
+	searchTool := &mockTool{
+		ToolName:        "Search",
+		ToolDescription: "Searches the web.",
+		ToolArgsType:    searchArgs{},
+		ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+			args, ok := input.(searchArgs)
+			if !ok {
+				return "", errors.New("unexpected input")
+			}
+
+			return strings.Repeat(args.Query+" ", args.Limit), nil
+		},
+	}
+
+	echoTool := &mockTool{
+		ToolName: "Echo",
+		ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+			return input.(string), nil
+		},
+	}
+
+	t.Run("StructuredTool", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewStructuredChat(newSequenceFake(nil,
+			"Thought: I need to search.\nAction:\n```json\n{\"action\": \"Search\", \"action_input\": {\"query\": \"golc\", \"limit\": 2}}\n```",
+			"Thought: I know what to respond\nAction:\n```\n{\"action\": \"Final Answer\", \"action_input\": \"done\"}\n```",
+		), []schema.Tool{searchTool, echoTool})
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{"input": "search golc"})
+		require.NoError(t, err)
+		assert.Equal(t, "done", output["output"])
+	})
+
+	t.Run("StringTool", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := NewStructuredChat(newSequenceFake(nil, ""), []schema.Tool{searchTool, echoTool})
+		require.NoError(t, err)
+
+		sc, ok := a.agent.(*StructuredChat)
+		require.True(t, ok)
+
+		actions, finish, err := sc.parseOutput(`{"action": "Echo", "action_input": "hello"}`)
+		require.NoError(t, err)
+		assert.Nil(t, finish)
+		require.Len(t, actions, 1)
+		assert.Equal(t, "Echo", actions[0].Tool)
+		assert.True(t, actions[0].ToolInput.Structured())
+
+		input := ""
+		require.NoError(t, actions[0].ToolInput.Unmarshal(&input))
+		assert.Equal(t, "hello", input)
+	})
+
+	t.Run("InvalidToolInput", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewStructuredChat(newSequenceFake(nil,
+			"Action:\n```\n{\"action\": \"Search\", \"action_input\": {\"limit\": 2}}\n```",
+		), []schema.Tool{searchTool})
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "search"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+		assert.ErrorContains(t, err, "$.query is required")
+	})
+
+	t.Run("UnknownTool", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := NewStructuredChat(newSequenceFake(nil, ""), []schema.Tool{searchTool})
+		require.NoError(t, err)
+
+		actions, _, err := a.agent.(*StructuredChat).parseOutput(`{"action": "Unknown", "action_input": "foo"}`)
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		assert.Equal(t, "Unknown", actions[0].Tool)
+	})
+
+	t.Run("InvalidOutput", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewStructuredChat(newSequenceFake(nil, "I don't know"), []schema.Tool{searchTool})
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{"input": "search"})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewStructuredChat(newSequenceFake(nil, ""), []schema.Tool{searchTool})
+		require.NoError(t, err)
+
+		assert.Equal(t, "StructuredChat", agent.Type())
+		assert.ElementsMatch(t, []string{"input"}, agent.InputKeys())
+		assert.ElementsMatch(t, []string{"output"}, agent.OutputKeys())
+	})
+	t.Run("CallbackOptions", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewStructuredChat(newSequenceFake(nil, ""), []schema.Tool{searchTool}, func(o *StructuredChatOptions) {
+			o.Verbose = true
+		})
+		require.NoError(t, err)
+
+		assert.True(t, agent.Verbose())
+	})
+}
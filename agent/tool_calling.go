@@ -0,0 +1,354 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// Compile time check to ensure ToolCalling satisfies the agent interface.
+var _ schema.Agent = (*ToolCalling)(nil)
+
+const defaultToolCallingJSONInstructions = `You have access to the following tools:
+{{.toolDescriptions}}
+
+To use one or more tools, respond only with a JSON object in the following format:
+{"tool_calls": [{"name": "<one of [{{.toolNames}}]>", "arguments": <JSON object matching the arguments of the tool>}]}
+
+When you know the final answer, respond only with a JSON object in the following format:
+{"final_answer": "<the final answer to the original input>"}`
+
+// nativeToolCallingModels contains the types of the chat models with native tool calling support.
+var nativeToolCallingModels = map[string]bool{
+	"chatmodel.OpenAI":      true,
+	"chatmodel.AzureOpenAI": true,
+	"chatmodel.Anthropic":   true,
+	"chatmodel.Bedrock":     true,
+	"chatmodel.Cohere":      true,
+	"chatmodel.GoogleGenAI": true,
+	"chatmodel.Ollama":      true,
+}
+
+// ToolCallingOptions represents the configuration options for the ToolCalling agent.
+type ToolCallingOptions struct {
+	*schema.CallbackOptions
+	RunOptions
+	// OutputKey is the key to store the output of the agent in the ChainValues.
+	OutputKey     string
+	SystemMessage *prompt.SystemMessageTemplate
+	ExtraMessages []prompt.MessageTemplate
+	MaxIterations int
+	// JSONPrompt describes the tools in the prompt and parses the tool calls from the JSON output
+	// of the model instead of using the native tool calling of the model. It defaults to true for
+	// models without native tool calling support.
+	JSONPrompt bool
+	// JSONInstructions are the instructions added to the system message if JSONPrompt is enabled.
+	JSONInstructions string
+}
+
+// ToolCalling is an agent that uses the tool calling of chat models and schema.Tools to perform actions.
+// All tool calls of a model turn are executed in parallel and their results are passed back to the
+// model as tool messages. Models without native tool calling support use a JSON prompted protocol.
+type ToolCalling struct {
+	model     schema.ChatModel
+	functions []schema.FunctionDefinition
+	prompt    prompt.ChatTemplate
+	opts      ToolCallingOptions
+}
+
+// NewToolCalling creates a new instance of the ToolCalling agent with the given model and tools.
+// It returns an error if it fails to convert tools to function definitions.
+func NewToolCalling(model schema.ChatModel, tools []schema.Tool, optFns ...func(o *ToolCallingOptions)) (*Executor, error) {
+	opts := ToolCallingOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		OutputKey:        "output",
+		SystemMessage:    prompt.NewSystemMessageTemplate("You are a helpful AI assistant."),
+		ExtraMessages:    []prompt.MessageTemplate{},
+		MaxIterations:    DefaultMaxIterations,
+		JSONPrompt:       !nativeToolCallingModels[model.Type()],
+		JSONInstructions: defaultToolCallingJSONInstructions,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	functions := make([]schema.FunctionDefinition, len(tools))
+
+	for i, t := range tools {
+		f, err := tool.ToFunction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		functions[i] = *f
+	}
+
+	templates := []prompt.MessageTemplate{opts.SystemMessage}
+
+	if opts.JSONPrompt {
+		descriptions, err := toolCallingDescriptions(functions)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, prompt.NewSystemMessageTemplate(opts.JSONInstructions, func(o *prompt.TemplateOptions) {
+			o.PartialValues = map[string]any{
+				"toolNames":        toolNames(tools),
+				"toolDescriptions": descriptions,
+			}
+		}))
+	}
+
+	templates = append(templates, opts.ExtraMessages...)
+	templates = append(templates, prompt.NewHumanMessageTemplate("{{.input}}"))
+
+	agent := &ToolCalling{
+		model:     model,
+		functions: functions,
+		prompt:    prompt.NewChatTemplateWrapper(prompt.NewChatTemplate(templates), prompt.NewMessagesPlaceholder("agentScratchpad")),
+		opts:      opts,
+	}
+
+	return NewExecutor(agent, tools, func(o *ExecutorOptions) {
+		o.RunOptions = opts.RunOptions
+		o.MaxIterations = opts.MaxIterations
+		o.AgentChainType = "ToolCalling"
+	})
+}
+
+// Plan executes the agent with the given context, intermediate steps, and inputs.
+// It returns one agent action per tool call, agent finish, or an error, if any.
+func (a *ToolCalling) Plan(ctx context.Context, intermediateSteps []schema.AgentStep, inputs schema.ChainValues) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	rm := runManager(ctx)
+
+	inputs["agentScratchpad"] = a.constructScratchPad(intermediateSteps)
+
+	prompt, err := a.prompt.FormatPrompt(inputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := model.ChatModelGenerate(ctx, a.model, prompt.Messages(), func(o *model.Options) {
+		o.Callbacks = rm.GetInheritableCallbacks()
+		o.ParentRunID = rm.RunID()
+
+		if !a.opts.JSONPrompt {
+			o.Functions = a.functions
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msg := result.Generations[0].Message
+
+	aiMsg, ok := msg.(*schema.AIChatMessage)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected chatMessage type. Expected ai, but got %s", msg.Type())
+	}
+
+	if a.opts.JSONPrompt {
+		return a.parseJSONOutput(aiMsg)
+	}
+
+	toolCalls := aiMsg.ToolCalls()
+	if len(toolCalls) == 0 {
+		return nil, a.finish(aiMsg.Content()), nil
+	}
+
+	actions := make([]*schema.AgentAction, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		actions[i] = newToolCallingAction(aiMsg, tc.ID, tc.Name, schema.NewToolInputFromArguments(tc.Arguments))
+	}
+
+	return actions, nil, nil
+}
+
+// Model returns the chat model of the agent.
+func (a *ToolCalling) Model() schema.Model {
+	return a.model
+}
+
+// InputKeys returns the expected input keys for the agent.
+func (a *ToolCalling) InputKeys() []string {
+	return []string{"input"}
+}
+
+// OutputKeys returns the output keys that the agent will return.
+func (a *ToolCalling) OutputKeys() []string {
+	return []string{a.opts.OutputKey}
+}
+
+// finish creates the agent finish for the given answer.
+func (a *ToolCalling) finish(answer string) *schema.AgentFinish {
+	return &schema.AgentFinish{
+		ReturnValues: map[string]any{
+			a.opts.OutputKey: answer,
+		},
+		Log: answer,
+	}
+}
+
+// toolCallingJSONOutput is the output of the model in the JSON prompted protocol.
+type toolCallingJSONOutput struct {
+	ToolCalls []struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	} `json:"tool_calls"`
+	FinalAnswer *string `json:"final_answer"`
+}
+
+// parseJSONOutput parses the output of the model in the JSON prompted protocol. An output
+// without a JSON object is treated as final answer.
+func (a *ToolCalling) parseJSONOutput(aiMsg *schema.AIChatMessage) ([]*schema.AgentAction, *schema.AgentFinish, error) {
+	text := strings.TrimSpace(aiMsg.Content())
+
+	if matches := jsonCodeBlockRegexp.FindStringSubmatch(text); len(matches) == 2 {
+		text = strings.TrimSpace(matches[1])
+	}
+
+	if !strings.HasPrefix(text, "{") {
+		return nil, a.finish(aiMsg.Content()), nil
+	}
+
+	output := toolCallingJSONOutput{}
+	if err := json.Unmarshal([]byte(text), &output); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, aiMsg.Content())
+	}
+
+	if len(output.ToolCalls) == 0 {
+		if output.FinalAnswer == nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnableToParseOutput, aiMsg.Content())
+		}
+
+		return nil, a.finish(*output.FinalAnswer), nil
+	}
+
+	actions := make([]*schema.AgentAction, len(output.ToolCalls))
+
+	for i, tc := range output.ToolCalls {
+		var toolInput *schema.ToolInput
+
+		input := ""
+		if err := json.Unmarshal(tc.Arguments, &input); err == nil {
+			toolInput = schema.NewToolInputFromString(input)
+		} else {
+			toolInput = schema.NewToolInputFromArguments(string(tc.Arguments))
+		}
+
+		actions[i] = newToolCallingAction(aiMsg, fmt.Sprintf("call_%d", i), tc.Name, toolInput)
+	}
+
+	return actions, nil, nil
+}
+
+// constructScratchPad constructs the scratch pad from the given intermediate steps. The model
+// message of a turn is added once, followed by the results of all tool calls of that turn.
+// Turns are told apart by the tool call IDs of the steps, so that steps restored from a
+// serialized state are grouped the same way.
+func (a *ToolCalling) constructScratchPad(steps []schema.AgentStep) schema.ChatMessages {
+	messages := schema.ChatMessages{}
+
+	var (
+		// turnIDs holds the tool call IDs of the model message of the current turn. It is nil if
+		// the message does not list its tool calls, e.g. with the JSON prompt. answeredIDs is nil
+		// until a turn is started.
+		turnIDs     map[string]bool
+		answeredIDs map[string]bool
+	)
+
+	for _, step := range steps {
+		if len(step.Action.MessageLog) == 0 {
+			messages = append(messages, schema.NewAIChatMessage(step.Action.Log))
+			messages = append(messages, schema.NewHumanChatMessage(step.Observation))
+			turnIDs, answeredIDs = nil, nil
+
+			continue
+		}
+
+		id := step.Action.ToolCallID
+		if answeredIDs == nil || id == "" || answeredIDs[id] || (turnIDs != nil && !turnIDs[id]) {
+			messages = append(messages, step.Action.MessageLog...)
+			turnIDs, answeredIDs = toolCallIDs(step.Action.MessageLog), map[string]bool{}
+		}
+
+		answeredIDs[id] = true
+
+		switch {
+		case a.opts.JSONPrompt:
+			messages = append(messages, schema.NewHumanChatMessage(fmt.Sprintf("Result of tool %s (%s): %s", step.Action.Tool, step.Action.ToolCallID, step.Observation)))
+		case step.Action.ToolCallID == "":
+			messages = append(messages, schema.NewFunctionChatMessage(step.Action.Tool, step.Observation))
+		default:
+			messages = append(messages, schema.NewToolChatMessage(step.Action.ToolCallID, step.Action.Tool, step.Observation))
+		}
+	}
+
+	return messages
+}
+
+// toolCallIDs returns the IDs of the tool calls of the AI messages, or nil if there are none.
+func toolCallIDs(messages schema.ChatMessages) map[string]bool {
+	var ids map[string]bool
+
+	for _, m := range messages {
+		aiMsg, ok := m.(*schema.AIChatMessage)
+		if !ok {
+			continue
+		}
+
+		for _, tc := range aiMsg.ToolCalls() {
+			if ids == nil {
+				ids = map[string]bool{}
+			}
+
+			ids[tc.ID] = true
+		}
+	}
+
+	return ids
+}
+
+// newToolCallingAction creates the agent action for a tool call of the given model message.
+func newToolCallingAction(aiMsg *schema.AIChatMessage, toolCallID, name string, toolInput *schema.ToolInput) *schema.AgentAction {
+	msgContent := ""
+	if aiMsg.Content() != "" {
+		msgContent = fmt.Sprintf("responded: %s", aiMsg.Content())
+	}
+
+	return &schema.AgentAction{
+		Tool:       name,
+		ToolInput:  toolInput,
+		Log:        fmt.Sprintf("\nInvoking `%s` with `%s`\n%s\n", name, toolInput, msgContent),
+		MessageLog: schema.ChatMessages{aiMsg},
+		ToolCallID: toolCallID,
+	}
+}
+
+// toolCallingDescriptions returns a formatted string containing the names, descriptions and
+// argument schemas of the given function definitions.
+func toolCallingDescriptions(functions []schema.FunctionDefinition) (string, error) {
+	descriptions := make([]string, len(functions))
+
+	for i, f := range functions {
+		parameters, err := json.Marshal(f.Parameters)
+		if err != nil {
+			return "", err
+		}
+
+		descriptions[i] = fmt.Sprintf("- %s: %s, arguments: %s", f.Name, f.Description, parameters)
+	}
+
+	return strings.Join(descriptions, "\n"), nil
+}
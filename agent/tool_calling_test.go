@@ -0,0 +1,214 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCalling(t *testing.T) {
+	t.Parallel()
+
+	newTools := func(t *testing.T) []schema.Tool {
+		return []schema.Tool{
+			&mockTool{
+				ToolName: "Weather",
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					assert.Equal(t, "Berlin", input.(string))
+					return "sunny", nil
+				},
+			},
+			&mockTool{
+				ToolName: "Time",
+				ToolRunFunc: func(ctx context.Context, input any) (string, error) {
+					assert.Equal(t, "CET", input.(string))
+					return "12:00", nil
+				},
+			},
+		}
+	}
+
+	t.Run("NativeToolCalls", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			var generation schema.Generation
+
+			if len(messages) == 2 {
+				assert.Equal(t, "user Input", messages[1].Content())
+
+				generation = schema.Generation{
+					Message: schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+						o.ToolCalls = []schema.ToolCall{
+							{ID: "call_weather", Name: "Weather", Arguments: `{"__arg1": "Berlin"}`},
+							{ID: "call_time", Name: "Time", Arguments: `{"__arg1": "CET"}`},
+						}
+					}),
+				}
+			} else {
+				require.Len(t, messages, 5)
+				assert.Equal(t, schema.ChatMessageTypeAI, messages[2].Type())
+
+				weather, ok := messages[3].(*schema.ToolChatMessage)
+				require.True(t, ok)
+				assert.Equal(t, "call_weather", weather.ToolCallID())
+				assert.Equal(t, "sunny", weather.Content())
+
+				time, ok := messages[4].(*schema.ToolChatMessage)
+				require.True(t, ok)
+				assert.Equal(t, "call_time", time.ToolCallID())
+				assert.Equal(t, "12:00", time.Content())
+
+				generation = schema.Generation{
+					Text:    "finish text",
+					Message: schema.NewAIChatMessage("finish text"),
+				}
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{generation},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}, func(o *chatmodel.FakeOptions) {
+			o.ChatModelType = "chatmodel.Anthropic"
+		}), newTools(t))
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{
+			"input": "user Input",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "finish text", output[agent.OutputKeys()[0]])
+	})
+
+	t.Run("JSONPrompt", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			var generation schema.Generation
+
+			if len(messages) == 3 {
+				assert.Equal(t, schema.ChatMessageTypeSystem, messages[1].Type())
+				assert.Contains(t, messages[1].Content(), "- Weather: Mock, arguments:")
+				assert.Contains(t, messages[1].Content(), "one of [Weather, Time]")
+
+				generation = schema.Generation{
+					Message: schema.NewAIChatMessage("```json\n" + `{"tool_calls": [{"name": "Weather", "arguments": {"__arg1": "Berlin"}}, {"name": "Time", "arguments": "CET"}]}` + "\n```"),
+				}
+			} else {
+				require.Len(t, messages, 6)
+				assert.Equal(t, "Result of tool Weather (call_0): sunny", messages[4].Content())
+				assert.Equal(t, "Result of tool Time (call_1): 12:00", messages[5].Content())
+
+				generation = schema.Generation{
+					Message: schema.NewAIChatMessage(`{"final_answer": "finish text"}`),
+				}
+			}
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{generation},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}), newTools(t))
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{
+			"input": "user Input",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "finish text", output[agent.OutputKeys()[0]])
+	})
+
+	t.Run("JSONPromptPlainText", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(chatmodel.NewSimpleFake("plain answer"), newTools(t))
+		require.NoError(t, err)
+
+		output, err := agent.Call(context.Background(), schema.ChainValues{
+			"input": "user Input",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "plain answer", output[agent.OutputKeys()[0]])
+	})
+
+	t.Run("JSONPromptInvalidOutput", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(chatmodel.NewSimpleFake(`{"tool_calls": [`), newTools(t))
+		require.NoError(t, err)
+
+		_, err = agent.Call(context.Background(), schema.ChainValues{
+			"input": "user Input",
+		})
+		assert.ErrorIs(t, err, ErrUnableToParseOutput)
+	})
+
+	t.Run("ScratchPadOfRestoredSteps", func(t *testing.T) {
+		t.Parallel()
+
+		agent := &ToolCalling{}
+
+		// restore simulates a JSON round trip of the message log, which loses the message identity.
+		restore := func(aiMsg *schema.AIChatMessage) schema.ChatMessages {
+			data, err := json.Marshal(schema.ChatMessages{aiMsg})
+			require.NoError(t, err)
+
+			messages := schema.ChatMessages{}
+			require.NoError(t, json.Unmarshal(data, &messages))
+
+			return messages
+		}
+
+		newAIMsg := func(ids ...string) *schema.AIChatMessage {
+			return schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				for _, id := range ids {
+					o.ToolCalls = append(o.ToolCalls, schema.ToolCall{ID: id, Name: "Weather"})
+				}
+			})
+		}
+
+		first, second := newAIMsg("call_1", "call_2"), newAIMsg("call_3")
+
+		steps := []schema.AgentStep{
+			{Action: &schema.AgentAction{Tool: "Weather", MessageLog: restore(first), ToolCallID: "call_1"}, Observation: "sunny"},
+			{Action: &schema.AgentAction{Tool: "Weather", MessageLog: restore(first), ToolCallID: "call_2"}, Observation: "rainy"},
+			{Action: &schema.AgentAction{Tool: "Weather", MessageLog: restore(second), ToolCallID: "call_3"}, Observation: "cloudy"},
+		}
+
+		messages := agent.constructScratchPad(steps)
+		require.Len(t, messages, 5)
+		assert.Equal(t, schema.ChatMessageTypeAI, messages[0].Type())
+		assert.Equal(t, schema.ChatMessageTypeTool, messages[1].Type())
+		assert.Equal(t, schema.ChatMessageTypeTool, messages[2].Type())
+		assert.Equal(t, schema.ChatMessageTypeAI, messages[3].Type())
+		assert.Equal(t, "cloudy", messages[4].Content())
+	})
+
+	t.Run("InvalidTool", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewToolCalling(chatmodel.NewSimpleFake("foo"), []schema.Tool{
+			&mockTool{ToolArgsType: struct {
+				Channel chan int `json:"channel"` // chan cannot converted to json
+			}{}},
+		})
+		assert.EqualError(t, err, "unsupported type chan from chan int")
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		t.Parallel()
+
+		agent, err := NewToolCalling(chatmodel.NewSimpleFake("foo"), newTools(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, "ToolCalling", agent.Type())
+		assert.ElementsMatch(t, []string{"input"}, agent.InputKeys())
+		assert.ElementsMatch(t, []string{"output"}, agent.OutputKeys())
+	})
+}
@@ -0,0 +1,180 @@
+package golc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// BatchResult represents the result of a single input of a batch.
+type BatchResult struct {
+	// Index is the index of the input in the batch.
+	Index int
+	// Outputs are the outputs of the chain. It is nil if the call failed.
+	Outputs schema.ChainValues
+	// Err is the error of the last attempt, if any.
+	Err error
+	// Attempts is the number of attempts made for the input.
+	Attempts uint
+}
+
+// BatchCallResultsOptions contains options for BatchCallResults.
+type BatchCallResultsOptions struct {
+	Callbacks      []schema.Callback
+	ParentRunID    string
+	IncludeRunInfo bool
+	Stop           []string
+	MaxConcurrency int
+
+	// MaxAttempts is the maximum number of attempts per input, including the first call.
+	MaxAttempts uint
+
+	// RetryDelay is the delay between two attempts of an input.
+	RetryDelay time.Duration
+
+	// RetryIf decides whether a failed attempt is retried. All errors are retried if nil.
+	// Inputs are never retried after the context is done.
+	RetryIf func(err error) bool
+
+	// Timeout is the maximum duration of a single attempt. Zero means no timeout.
+	Timeout time.Duration
+
+	// OnProgress is called after every finished input with the number of finished inputs,
+	// the total number of inputs and the result of the input. Calls are never concurrent.
+	OnProgress func(finished, total int, result BatchResult)
+}
+
+// BatchCallResults executes multiple calls to the chain concurrently and returns a result for
+// every input in the same order as the inputs. In contrast to BatchCall, a failed input does
+// not abort the batch. If the context is cancelled, the results of the finished inputs are
+// returned together with the context error and all unfinished inputs report the context error.
+func BatchCallResults(ctx context.Context, chain schema.Chain, inputs []schema.ChainValues, optFns ...func(*BatchCallResultsOptions)) ([]BatchResult, error) {
+	opts := BatchCallResultsOptions{
+		MaxConcurrency: 5,
+		MaxAttempts:    1,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.MaxConcurrency < 1 {
+		opts.MaxConcurrency = 1
+	}
+
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+
+	results := make([]BatchResult, len(inputs))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		finished int
+	)
+
+	sem := make(chan struct{}, opts.MaxConcurrency)
+
+	finish := func(result BatchResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		results[result.Index] = result
+		finished++
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(finished, len(inputs), result)
+		}
+	}
+
+	for i, input := range inputs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			finish(BatchResult{Index: i, Err: ctx.Err()})
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, input schema.ChainValues) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			finish(batchCallItem(ctx, chain, i, input, opts))
+		}(i, input)
+	}
+
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+// batchCallItem calls the chain with a single input of a batch and retries failed attempts.
+func batchCallItem(ctx context.Context, chain schema.Chain, index int, input schema.ChainValues, opts BatchCallResultsOptions) BatchResult {
+	result := BatchResult{Index: index}
+
+	for result.Attempts < opts.MaxAttempts {
+		if result.Attempts > 0 && opts.RetryDelay > 0 {
+			timer := time.NewTimer(opts.RetryDelay)
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+
+				result.Err = ctx.Err()
+
+				return result
+			}
+		}
+
+		result.Attempts++
+
+		outputs, err := batchCallAttempt(ctx, chain, input.Clone(), opts)
+		if err == nil {
+			result.Outputs = outputs
+			result.Err = nil
+
+			return result
+		}
+
+		result.Err = err
+
+		if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+			return result
+		}
+
+		if opts.RetryIf != nil && !opts.RetryIf(err) {
+			return result
+		}
+	}
+
+	return result
+}
+
+// batchCallAttempt calls the chain once, limited by the timeout of the options.
+func batchCallAttempt(ctx context.Context, chain schema.Chain, input schema.ChainValues, opts BatchCallResultsOptions) (schema.ChainValues, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	return Call(ctx, chain, input, func(o *CallOptions) {
+		o.Callbacks = opts.Callbacks
+		o.ParentRunID = opts.ParentRunID
+		o.IncludeRunInfo = opts.IncludeRunInfo
+		o.Stop = opts.Stop
+	})
+}
@@ -0,0 +1,115 @@
+package golc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCallResults(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	t.Run("Per item results", func(t *testing.T) {
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				if inputs["fail"] == true {
+					return nil, errFailed
+				}
+
+				return inputs, nil
+			},
+		}
+
+		progress := []int{}
+
+		results, err := BatchCallResults(context.Background(), chain, []schema.ChainValues{
+			{"foo": "bar"}, {"fail": true}, {"foo": "baz"},
+		}, func(o *BatchCallResultsOptions) {
+			o.OnProgress = func(finished, total int, result BatchResult) {
+				require.Equal(t, 3, total)
+				progress = append(progress, finished)
+			}
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		require.Equal(t, schema.ChainValues{"foo": "bar"}, results[0].Outputs)
+		require.NoError(t, results[0].Err)
+		require.ErrorIs(t, results[1].Err, errFailed)
+		require.Nil(t, results[1].Outputs)
+		require.Equal(t, schema.ChainValues{"foo": "baz"}, results[2].Outputs)
+		require.Equal(t, 2, results[2].Index)
+		require.Equal(t, []int{1, 2, 3}, progress)
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		var calls atomic.Int32
+
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				if calls.Add(1) < 3 {
+					return nil, errFailed
+				}
+
+				return inputs, nil
+			},
+		}
+
+		results, err := BatchCallResults(context.Background(), chain, []schema.ChainValues{{"foo": "bar"}}, func(o *BatchCallResultsOptions) {
+			o.MaxAttempts = 3
+			o.RetryDelay = time.Millisecond
+		})
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		require.Equal(t, uint(3), results[0].Attempts)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}
+
+		results, err := BatchCallResults(context.Background(), chain, []schema.ChainValues{{"foo": "bar"}}, func(o *BatchCallResultsOptions) {
+			o.Timeout = 10 * time.Millisecond
+			o.MaxAttempts = 2
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, results[0].Err, context.DeadlineExceeded)
+		require.Equal(t, uint(2), results[0].Attempts)
+	})
+
+	t.Run("Partial results on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		chain := mockChain{
+			CallFunc: func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+				if inputs["cancel"] == true {
+					cancel()
+					return nil, ctx.Err()
+				}
+
+				return inputs, nil
+			},
+		}
+
+		results, err := BatchCallResults(ctx, chain, []schema.ChainValues{
+			{"foo": "bar"}, {"cancel": true}, {"foo": "baz"},
+		}, func(o *BatchCallResultsOptions) {
+			o.MaxConcurrency = 1
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, schema.ChainValues{"foo": "bar"}, results[0].Outputs)
+		require.ErrorIs(t, results[1].Err, context.Canceled)
+		require.ErrorIs(t, results[2].Err, context.Canceled)
+		require.Equal(t, uint(0), results[2].Attempts)
+	})
+}
@@ -0,0 +1,175 @@
+// Package cache provides caches for model results.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// key returns a hash of the prompt and the model key.
+func key(prompt, modelKey string) string {
+	h := sha256.New()
+	h.Write([]byte(modelKey))
+	h.Write([]byte{0})
+	h.Write([]byte(prompt))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// generation is the serializable representation of a schema.Generation.
+type generation struct {
+	Text         string               `json:"text"`
+	Message      map[string]string    `json:"message,omitempty"`
+	FunctionCall *schema.FunctionCall `json:"functionCall,omitempty"`
+	ToolCalls    []schema.ToolCall    `json:"toolCalls,omitempty"`
+	Info         map[string]any       `json:"info,omitempty"`
+}
+
+// modelResult is the serializable representation of a schema.ModelResult.
+type modelResult struct {
+	Generations []generation   `json:"generations"`
+	LLMOutput   map[string]any `json:"llmOutput,omitempty"`
+}
+
+// marshalResult serializes a model result to JSON.
+func marshalResult(result *schema.ModelResult) ([]byte, error) {
+	r := modelResult{
+		Generations: make([]generation, len(result.Generations)),
+		LLMOutput:   result.LLMOutput,
+	}
+
+	for i, g := range result.Generations {
+		r.Generations[i] = generation{
+			Text: g.Text,
+			Info: g.Info,
+		}
+
+		if g.Message != nil {
+			r.Generations[i].Message = schema.ChatMessageToMap(g.Message)
+
+			if aiMsg, ok := g.Message.(*schema.AIChatMessage); ok {
+				r.Generations[i].FunctionCall = aiMsg.Extension().FunctionCall
+				r.Generations[i].ToolCalls = aiMsg.Extension().ToolCalls
+			}
+		}
+	}
+
+	return json.Marshal(r)
+}
+
+// unmarshalResult deserializes a model result from JSON.
+func unmarshalResult(data []byte) (*schema.ModelResult, error) {
+	r := modelResult{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+
+	result := &schema.ModelResult{
+		Generations: make([]schema.Generation, len(r.Generations)),
+		LLMOutput:   r.LLMOutput,
+	}
+
+	for i, g := range r.Generations {
+		result.Generations[i] = schema.Generation{
+			Text: g.Text,
+			Info: g.Info,
+		}
+
+		if g.Message == nil {
+			continue
+		}
+
+		if g.FunctionCall != nil || len(g.ToolCalls) > 0 {
+			result.Generations[i].Message = schema.NewAIChatMessage(g.Message["content"], func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = g.FunctionCall
+				o.ToolCalls = g.ToolCalls
+			})
+
+			continue
+		}
+
+		msg, err := schema.MapToChatMessage(g.Message)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Generations[i].Message = msg
+	}
+
+	return result, nil
+}
+
+// copyResult returns a deep copy of a model result, so that callers can't modify the results held by
+// the in-memory caches. Messages other than AI messages are immutable and therefore shared.
+func copyResult(result *schema.ModelResult) *schema.ModelResult {
+	if result == nil {
+		return nil
+	}
+
+	c := &schema.ModelResult{
+		LLMOutput: copyMap(result.LLMOutput),
+	}
+
+	if result.Generations != nil {
+		c.Generations = make([]schema.Generation, len(result.Generations))
+	}
+
+	for i, g := range result.Generations {
+		c.Generations[i] = schema.Generation{
+			Text:    g.Text,
+			Message: g.Message,
+			Info:    copyMap(g.Info),
+		}
+
+		if aiMsg, ok := g.Message.(*schema.AIChatMessage); ok {
+			ext := aiMsg.Extension()
+
+			c.Generations[i].Message = schema.NewAIChatMessage(aiMsg.Content(), func(o *schema.ChatMessageExtension) {
+				if ext.FunctionCall != nil {
+					functionCall := *ext.FunctionCall
+					o.FunctionCall = &functionCall
+				}
+
+				if ext.ToolCalls != nil {
+					o.ToolCalls = append([]schema.ToolCall{}, ext.ToolCalls...)
+				}
+			})
+		}
+	}
+
+	return c
+}
+
+// copyMap returns a copy of the map. Nested maps and slices are copied as well.
+func copyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]any, len(m))
+	for k, v := range m {
+		c[k] = copyValue(v)
+	}
+
+	return c
+}
+
+// copyValue returns a copy of nested maps and slices and the value itself otherwise.
+func copyValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		return copyMap(t)
+	case []any:
+		c := make([]any, len(t))
+		for i, e := range t {
+			c[i] = copyValue(e)
+		}
+
+		return c
+	default:
+		return v
+	}
+}
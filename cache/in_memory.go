@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure InMemory satisfies the Cache interface.
+var _ schema.Cache = (*InMemory)(nil)
+
+// InMemoryOptions contains options for the in-memory cache.
+type InMemoryOptions struct {
+	// Capacity is the maximum number of cached results. The least recently used results are evicted first.
+	Capacity int
+}
+
+type inMemoryEntry struct {
+	key    string
+	result *schema.ModelResult
+}
+
+// InMemory is a least recently used cache that stores the model results in memory.
+type InMemory struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	opts    InMemoryOptions
+}
+
+// NewInMemory creates a new in-memory cache.
+func NewInMemory(optFns ...func(o *InMemoryOptions)) *InMemory {
+	opts := InMemoryOptions{
+		Capacity: 1000,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &InMemory{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		opts:    opts,
+	}
+}
+
+// Lookup returns a copy of the cached result for the prompt and model key. It returns nil if there is no cached result.
+func (c *InMemory) Lookup(ctx context.Context, prompt, modelKey string) (*schema.ModelResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key(prompt, modelKey)]
+	if !ok {
+		return nil, nil
+	}
+
+	c.lru.MoveToFront(element)
+
+	return copyResult(element.Value.(*inMemoryEntry).result), nil
+}
+
+// Update stores a copy of the result for the prompt and model key.
+func (c *InMemory) Update(ctx context.Context, prompt, modelKey string, result *schema.ModelResult) error {
+	result = copyResult(result)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	k := key(prompt, modelKey)
+
+	if element, ok := c.entries[k]; ok {
+		element.Value.(*inMemoryEntry).result = result
+		c.lru.MoveToFront(element)
+
+		return nil
+	}
+
+	c.entries[k] = c.lru.PushFront(&inMemoryEntry{key: k, result: result})
+
+	for c.opts.Capacity > 0 && c.lru.Len() > c.opts.Capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*inMemoryEntry).key)
+	}
+
+	return nil
+}
+
+// Clear removes all cached results.
+func (c *InMemory) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemory(t *testing.T) {
+	newResult := func(text string) *schema.ModelResult {
+		return &schema.ModelResult{Generations: []schema.Generation{{Text: text}}}
+	}
+
+	t.Run("Lookup and update", func(t *testing.T) {
+		cache := NewInMemory()
+
+		result, err := cache.Lookup(context.Background(), "prompt", "model")
+		require.NoError(t, err)
+		require.Nil(t, result)
+
+		require.NoError(t, cache.Update(context.Background(), "prompt", "model", newResult("foo")))
+
+		result, err = cache.Lookup(context.Background(), "prompt", "model")
+		require.NoError(t, err)
+		require.Equal(t, newResult("foo"), result)
+
+		result, err = cache.Lookup(context.Background(), "prompt", "other model")
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+
+	t.Run("Copy results", func(t *testing.T) {
+		cache := NewInMemory()
+
+		result := &schema.ModelResult{
+			Generations: []schema.Generation{{
+				Text: "foo",
+				Message: schema.NewAIChatMessage("foo", func(o *schema.ChatMessageExtension) {
+					o.ToolCalls = []schema.ToolCall{{ID: "call_0", Name: "weather"}}
+				}),
+				Info: map[string]any{"finish_reason": "stop"},
+			}},
+			LLMOutput: map[string]any{"token_usage": map[string]any{"total_tokens": 10}},
+		}
+
+		require.NoError(t, cache.Update(context.Background(), "prompt", "model", result))
+
+		// Modifying the stored result does not change the cache.
+		result.Generations[0].Text = "bar"
+		result.Generations[0].Info["finish_reason"] = "length"
+
+		cached, err := cache.Lookup(context.Background(), "prompt", "model")
+		require.NoError(t, err)
+		require.Equal(t, "foo", cached.Generations[0].Text)
+		require.Equal(t, "stop", cached.Generations[0].Info["finish_reason"])
+
+		// Modifying a returned result does not change the cache.
+		cached.Generations[0].Message.(*schema.AIChatMessage).ToolCalls()[0].Name = "search"
+		cached.LLMOutput["token_usage"].(map[string]any)["total_tokens"] = 20
+
+		cached, err = cache.Lookup(context.Background(), "prompt", "model")
+		require.NoError(t, err)
+		require.Equal(t, "weather", cached.Generations[0].Message.(*schema.AIChatMessage).ToolCalls()[0].Name)
+		require.Equal(t, 10, cached.LLMOutput["token_usage"].(map[string]any)["total_tokens"])
+	})
+
+	t.Run("Evict least recently used", func(t *testing.T) {
+		cache := NewInMemory(func(o *InMemoryOptions) {
+			o.Capacity = 2
+		})
+
+		require.NoError(t, cache.Update(context.Background(), "a", "model", newResult("a")))
+		require.NoError(t, cache.Update(context.Background(), "b", "model", newResult("b")))
+
+		_, err := cache.Lookup(context.Background(), "a", "model")
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Update(context.Background(), "c", "model", newResult("c")))
+
+		result, err := cache.Lookup(context.Background(), "b", "model")
+		require.NoError(t, err)
+		require.Nil(t, result)
+
+		result, err = cache.Lookup(context.Background(), "a", "model")
+		require.NoError(t, err)
+		require.Equal(t, newResult("a"), result)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		cache := NewInMemory()
+
+		require.NoError(t, cache.Update(context.Background(), "prompt", "model", newResult("foo")))
+		require.NoError(t, cache.Clear(context.Background()))
+
+		result, err := cache.Lookup(context.Background(), "prompt", "model")
+		require.NoError(t, err)
+		require.Nil(t, result)
+	})
+}
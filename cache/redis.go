@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Redis satisfies the Cache interface.
+var _ schema.Cache = (*Redis)(nil)
+
+// RedisClient is the interface of the redis client used by the Redis cache.
+type RedisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+}
+
+// RedisOptions contains options for the Redis cache.
+type RedisOptions struct {
+	// KeyPrefix is the prefix of the redis keys.
+	KeyPrefix string
+	// TTL is the time to live of the cached results. Zero means no expiration.
+	TTL time.Duration
+}
+
+// Redis is a cache that stores the model results in redis.
+type Redis struct {
+	redisClient RedisClient
+	opts        RedisOptions
+}
+
+// NewRedis creates a new Redis cache.
+func NewRedis(redisClient RedisClient, optFns ...func(o *RedisOptions)) *Redis {
+	opts := RedisOptions{
+		KeyPrefix: "model_cache:",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Redis{
+		redisClient: redisClient,
+		opts:        opts,
+	}
+}
+
+// Lookup returns the cached result for the prompt and model key. It returns nil if there is no cached result.
+func (c *Redis) Lookup(ctx context.Context, prompt, modelKey string) (*schema.ModelResult, error) {
+	data, err := c.redisClient.Get(ctx, c.key(prompt, modelKey)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return unmarshalResult(data)
+}
+
+// Update stores the result for the prompt and model key.
+func (c *Redis) Update(ctx context.Context, prompt, modelKey string, result *schema.ModelResult) error {
+	data, err := marshalResult(result)
+	if err != nil {
+		return err
+	}
+
+	return c.redisClient.Set(ctx, c.key(prompt, modelKey), string(data), c.opts.TTL).Err()
+}
+
+func (c *Redis) key(prompt, modelKey string) string {
+	return c.opts.KeyPrefix + key(prompt, modelKey)
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type mockRedisClient struct {
+	values map[string]string
+}
+
+func (c *mockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	cmd := redis.NewStringCmd(ctx)
+
+	if val, ok := c.values[key]; ok {
+		cmd.SetVal(val)
+		return cmd
+	}
+
+	cmd.SetErr(redis.Nil)
+
+	return cmd
+}
+
+func (c *mockRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	c.values[key] = value.(string)
+
+	cmd := redis.NewStatusCmd(ctx)
+	cmd.SetVal("OK")
+
+	return cmd
+}
+
+func TestRedis(t *testing.T) {
+	client := &mockRedisClient{values: map[string]string{}}
+
+	cache := NewRedis(client)
+
+	result, err := cache.Lookup(context.Background(), "prompt", "model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	expected := &schema.ModelResult{
+		Generations: []schema.Generation{{Text: "foo", Message: schema.NewAIChatMessage("foo")}},
+	}
+
+	require.NoError(t, cache.Update(context.Background(), "prompt", "model", expected))
+	require.Len(t, client.values, 1)
+
+	result, err = cache.Lookup(context.Background(), "prompt", "model")
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
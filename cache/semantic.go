@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Semantic satisfies the Cache interface.
+var _ schema.Cache = (*Semantic)(nil)
+
+// SemanticOptions contains options for the semantic cache.
+type SemanticOptions struct {
+	// ScoreThreshold is the minimum cosine similarity between two prompts to return a cached result.
+	ScoreThreshold float32
+}
+
+type semanticEntry struct {
+	modelKey  string
+	embedding []float32
+	result    *schema.ModelResult
+}
+
+// Semantic is a cache that returns the cached result of the most similar prompt,
+// if the similarity of the prompt embeddings reaches the score threshold.
+type Semantic struct {
+	embedder schema.Embedder
+	mu       sync.RWMutex
+	entries  []semanticEntry
+	// missPrompt and missEmbedding memoize the embedding of the last prompt without cached result,
+	// so that the subsequent update does not embed the prompt again.
+	missPrompt    string
+	missEmbedding []float32
+	opts          SemanticOptions
+}
+
+// NewSemantic creates a new semantic cache.
+func NewSemantic(embedder schema.Embedder, optFns ...func(o *SemanticOptions)) *Semantic {
+	opts := SemanticOptions{
+		ScoreThreshold: 0.95,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Semantic{
+		embedder: embedder,
+		opts:     opts,
+	}
+}
+
+// Lookup returns a copy of the cached result of the most similar prompt for the model key.
+// It returns nil if no prompt reaches the score threshold.
+func (c *Semantic) Lookup(ctx context.Context, prompt, modelKey string) (*schema.ModelResult, error) {
+	embedding, err := c.embedder.EmbedText(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		result   *schema.ModelResult
+		maxScore float32
+	)
+
+	for _, entry := range c.entries {
+		if entry.modelKey != modelKey {
+			continue
+		}
+
+		score, err := metric.CosineSimilarity(embedding, entry.embedding)
+		if err != nil {
+			return nil, err
+		}
+
+		if score >= c.opts.ScoreThreshold && (result == nil || score > maxScore) {
+			result = entry.result
+			maxScore = score
+		}
+	}
+
+	if result == nil {
+		c.missPrompt, c.missEmbedding = prompt, embedding
+	}
+
+	return copyResult(result), nil
+}
+
+// Update stores a copy of the result for the prompt and model key.
+// The embedding of the prompt is reused, if the prompt was the last lookup without cached result.
+func (c *Semantic) Update(ctx context.Context, prompt, modelKey string, result *schema.ModelResult) error {
+	embedding, ok := c.takeMissEmbedding(prompt)
+	if !ok {
+		var err error
+
+		embedding, err = c.embedder.EmbedText(ctx, prompt)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = append(c.entries, semanticEntry{
+		modelKey:  modelKey,
+		embedding: embedding,
+		result:    copyResult(result),
+	})
+
+	return nil
+}
+
+// takeMissEmbedding returns and forgets the memoized embedding, if it belongs to the prompt.
+func (c *Semantic) takeMissEmbedding(prompt string) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.missEmbedding == nil || c.missPrompt != prompt {
+		return nil, false
+	}
+
+	embedding := c.missEmbedding
+	c.missPrompt, c.missEmbedding = "", nil
+
+	return embedding, true
+}
+
+// Clear removes all cached results.
+func (c *Semantic) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+	c.missPrompt, c.missEmbedding = "", nil
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile time check to ensure mockEmbedder satisfies the Embedder interface.
+var _ schema.Embedder = (*mockEmbedder)(nil)
+
+type mockEmbedder struct {
+	embeddings map[string][]float32
+	calls      int
+}
+
+func (m *mockEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+
+	for i, text := range texts {
+		embeddings[i] = m.embeddings[text]
+	}
+
+	return embeddings, nil
+}
+
+func (m *mockEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	m.calls++
+	return m.embeddings[text], nil
+}
+
+func TestSemantic(t *testing.T) {
+	cache := NewSemantic(&mockEmbedder{
+		embeddings: map[string][]float32{
+			"What is the capital of France?":  {1, 0},
+			"What's the capital of France?":   {0.99, 0.01},
+			"What is the capital of Germany?": {0, 1},
+		},
+	})
+
+	expected := &schema.ModelResult{Generations: []schema.Generation{{Text: "Paris"}}}
+
+	require.NoError(t, cache.Update(context.Background(), "What is the capital of France?", "model", expected))
+
+	result, err := cache.Lookup(context.Background(), "What's the capital of France?", "model")
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+
+	result, err = cache.Lookup(context.Background(), "What's the capital of France?", "other model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	result, err = cache.Lookup(context.Background(), "What is the capital of Germany?", "model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
+
+func TestSemanticReusesMissEmbedding(t *testing.T) {
+	embedder := &mockEmbedder{
+		embeddings: map[string][]float32{
+			"What is the capital of France?": {1, 0},
+		},
+	}
+
+	cache := NewSemantic(embedder)
+
+	result, err := cache.Lookup(context.Background(), "What is the capital of France?", "model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	expected := &schema.ModelResult{Generations: []schema.Generation{{Text: "Paris"}}}
+
+	require.NoError(t, cache.Update(context.Background(), "What is the capital of France?", "model", expected))
+	require.Equal(t, 1, embedder.calls)
+
+	result, err = cache.Lookup(context.Background(), "What is the capital of France?", "model")
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure SQLite satisfies the Cache interface.
+var _ schema.Cache = (*SQLite)(nil)
+
+// SQLiteOptions contains options for the SQLite cache.
+type SQLiteOptions struct {
+	// TableName is the name of the table storing the cached results.
+	TableName string
+}
+
+// SQLite is a cache that stores the model results in a SQLite database.
+// The caller is responsible for registering a SQLite driver, e.g. github.com/mattn/go-sqlite3.
+type SQLite struct {
+	db   *sql.DB
+	opts SQLiteOptions
+}
+
+// NewSQLite creates a new SQLite cache and creates the cache table if it does not exist.
+func NewSQLite(ctx context.Context, db *sql.DB, optFns ...func(o *SQLiteOptions)) (*SQLite, error) {
+	opts := SQLiteOptions{
+		TableName: "model_cache",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	key TEXT PRIMARY KEY,
+	result TEXT NOT NULL
+);`, opts.TableName)
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return nil, err
+	}
+
+	return &SQLite{
+		db:   db,
+		opts: opts,
+	}, nil
+}
+
+// Lookup returns the cached result for the prompt and model key. It returns nil if there is no cached result.
+func (c *SQLite) Lookup(ctx context.Context, prompt, modelKey string) (*schema.ModelResult, error) {
+	query := fmt.Sprintf("SELECT result FROM %s WHERE key = ?;", c.opts.TableName)
+
+	var data []byte
+	if err := c.db.QueryRowContext(ctx, query, key(prompt, modelKey)).Scan(&data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return unmarshalResult(data)
+}
+
+// Update stores the result for the prompt and model key.
+func (c *SQLite) Update(ctx context.Context, prompt, modelKey string, result *schema.ModelResult) error {
+	data, err := marshalResult(result)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT OR REPLACE INTO %s (key, result) VALUES (?, ?);", c.opts.TableName)
+
+	_, err = c.db.ExecContext(ctx, query, key(prompt, modelKey), string(data))
+
+	return err
+}
+
+// Clear removes all cached results.
+func (c *SQLite) Clear(ctx context.Context) error {
+	_, err := c.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s;", c.opts.TableName))
+	return err
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	cache, err := NewSQLite(context.Background(), db)
+	require.NoError(t, err)
+
+	result, err := cache.Lookup(context.Background(), "prompt", "model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+
+	expected := &schema.ModelResult{
+		Generations: []schema.Generation{{
+			Text: "foo",
+			Message: schema.NewAIChatMessage("foo", func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = &schema.FunctionCall{Name: "bar", Arguments: "{}"}
+			}),
+		}},
+		LLMOutput: map[string]any{"ModelName": "fake"},
+	}
+
+	require.NoError(t, cache.Update(context.Background(), "prompt", "model", expected))
+
+	result, err = cache.Lookup(context.Background(), "prompt", "model")
+	require.NoError(t, err)
+	require.Equal(t, expected, result)
+
+	require.NoError(t, cache.Clear(context.Background()))
+
+	result, err = cache.Lookup(context.Background(), "prompt", "model")
+	require.NoError(t, err)
+	require.Nil(t, result)
+}
@@ -62,7 +62,7 @@ func (cb *OpenAIHandler) AlwaysVerbose() bool {
 }
 
 func (cb *OpenAIHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
-	if input.Result.LLMOutput == nil {
+	if input.Result.LLMOutput == nil || input.Cached {
 		return nil
 	}
 
@@ -21,6 +21,9 @@ type ChatModelOptions struct {
 	// ForceFunctionCall forced the model to call the first function
 	ForceFunctionCall bool
 
+	// ToolChoice controls which functions the model calls. See schema.GenerateOptions.
+	ToolChoice string
+
 	// OutputKey is the key to access the output value containing the ChatModel response summary.
 	OutputKey string
 }
@@ -81,6 +84,7 @@ func (c *ChatModel) Call(ctx context.Context, inputs schema.ChainValues, optFns
 		o.Stop = opts.Stop
 		o.Functions = c.functions
 		o.ForceFunctionCall = c.opts.ForceFunctionCall
+		o.ToolChoice = c.opts.ToolChoice
 	})
 	if err != nil {
 		return nil, err
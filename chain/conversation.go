@@ -107,6 +107,11 @@ func (c *Conversation) Call(ctx context.Context, inputs schema.ChainValues, optF
 	return outputs[0], nil
 }
 
+// Model returns the model associated with the chain.
+func (c *Conversation) Model() schema.Model {
+	return c.model
+}
+
 func (c *Conversation) Prompt() schema.PromptTemplate {
 	return c.opts.Prompt
 }
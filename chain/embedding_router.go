@@ -0,0 +1,171 @@
+package chain
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/metric"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure EmbeddingRouter satisfies the Chain interface.
+var _ schema.Chain = (*EmbeddingRouter)(nil)
+
+// EmbeddingRouterOptions contains options for the EmbeddingRouter chain.
+type EmbeddingRouterOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// Memory is the schema.Memory to be associated with the chain.
+	Memory schema.Memory
+
+	// InputKey is the key to access the input value that is compared with the destination descriptions.
+	InputKey string
+
+	// DefaultChain is the chain used if no destination reaches the score threshold.
+	DefaultChain schema.Chain
+
+	// ScoreThreshold is the minimum cosine similarity between the input and a destination description.
+	ScoreThreshold float32
+}
+
+// EmbeddingRouter is a chain that routes the inputs to the destination chain whose description
+// is most similar to the input. It uses an embedder and does not call a model.
+type EmbeddingRouter struct {
+	embedder     schema.Embedder
+	destinations []RouterDestination
+	chains       map[string]schema.Chain
+	outputKeys   []string
+	mu           sync.Mutex
+	embeddings   [][]float32
+	opts         EmbeddingRouterOptions
+}
+
+// NewEmbeddingRouter creates a new instance of the EmbeddingRouter chain.
+func NewEmbeddingRouter(embedder schema.Embedder, destinations []RouterDestination, optFns ...func(o *EmbeddingRouterOptions)) (*EmbeddingRouter, error) {
+	opts := EmbeddingRouterOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey: "input",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	chains, outputKeys, err := newRouterDestinations(destinations, opts.DefaultChain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmbeddingRouter{
+		embedder:     embedder,
+		destinations: destinations,
+		chains:       chains,
+		outputKeys:   outputKeys,
+		opts:         opts,
+	}, nil
+}
+
+// Call executes the embedding router chain with the given context and inputs.
+// It returns the outputs of the destination chain or an error, if any.
+func (c *EmbeddingRouter) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	input, err := inputs.GetString(c.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := c.destinationEmbeddings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inputEmbedding, err := c.embedder.EmbedText(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		destination string
+		maxScore    float32
+	)
+
+	for i, embedding := range embeddings {
+		score, err := metric.CosineSimilarity(inputEmbedding, embedding)
+		if err != nil {
+			return nil, err
+		}
+
+		if score >= c.opts.ScoreThreshold && (destination == "" || score > maxScore) {
+			destination = c.destinations[i].Name
+			maxScore = score
+		}
+	}
+
+	return routeTo(ctx, c.chains, c.opts.DefaultChain, destination, inputs.Clone(), opts)
+}
+
+// Memory returns the memory associated with the chain.
+func (c *EmbeddingRouter) Memory() schema.Memory {
+	return c.opts.Memory
+}
+
+// Type returns the type of the chain.
+func (c *EmbeddingRouter) Type() string {
+	return "EmbeddingRouter"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *EmbeddingRouter) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *EmbeddingRouter) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *EmbeddingRouter) InputKeys() []string {
+	return []string{c.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *EmbeddingRouter) OutputKeys() []string {
+	return c.outputKeys
+}
+
+// destinationEmbeddings embeds the destination descriptions on first use and caches the embeddings.
+func (c *EmbeddingRouter) destinationEmbeddings(ctx context.Context) ([][]float32, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.embeddings != nil {
+		return c.embeddings, nil
+	}
+
+	descriptions := make([]string, len(c.destinations))
+	for i, d := range c.destinations {
+		descriptions[i] = d.Description
+	}
+
+	embeddings, err := c.embedder.BatchEmbedText(ctx, descriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	c.embeddings = embeddings
+
+	return embeddings, nil
+}
@@ -7,4 +7,6 @@ var (
 	ErrInvalidInputValues   = errors.New("invalid input values")
 	ErrInputValuesWrongType = errors.New("input key is of wrong type")
 	ErrNoOutputParser       = errors.New("no output parser")
+	ErrNoDestinations       = errors.New("no destinations")
+	ErrUnknownDestination   = errors.New("unknown destination")
 )
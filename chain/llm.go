@@ -110,6 +110,11 @@ func (c *LLM) GetNumTokens(ctx context.Context, text string) (uint, error) {
 	return c.model.GetNumTokens(ctx, text)
 }
 
+// Model returns the model associated with the chain.
+func (c *LLM) Model() schema.Model {
+	return c.model
+}
+
 // Prompt returns the prompt.Template associated with the chain.
 func (c *LLM) Prompt() schema.PromptTemplate {
 	return c.prompt
@@ -0,0 +1,311 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Reflection satisfies the Chain interface.
+var _ schema.Chain = (*Reflection)(nil)
+
+const (
+	defaultCriticTemplate = `You are a critic reviewing a response to a task.
+
+Task:
+{{.input}}
+
+Response:
+{{.draft}}
+
+{{.principles}}
+
+{{.formatInstructions}}`
+
+	defaultRevisionTemplate = `Revise the response to the task according to the feedback of the critic.
+
+Task:
+{{.input}}
+
+Response:
+{{.draft}}
+
+Feedback:
+{{.feedback}}
+{{.revisionRequests}}
+Respond only with the revised response.`
+)
+
+// ConstitutionalPrinciple represents a principle a response is critiqued and revised against.
+type ConstitutionalPrinciple struct {
+	// Name is the name of the principle.
+	Name string
+	// CritiqueRequest asks the critic to identify violations of the principle.
+	CritiqueRequest string
+	// RevisionRequest asks the reviser to remove violations of the principle.
+	RevisionRequest string
+}
+
+var (
+	// PrincipleHarmless critiques harmful, unethical, toxic, dangerous or illegal content.
+	PrincipleHarmless = ConstitutionalPrinciple{
+		Name:            "harmless",
+		CritiqueRequest: "Identify specific ways in which the response is harmful, unethical, racist, sexist, toxic, dangerous, or illegal.",
+		RevisionRequest: "Rewrite the response to remove any and all harmful, unethical, racist, sexist, toxic, dangerous, or illegal content.",
+	}
+
+	// PrincipleHonest critiques false, misleading or unsupported claims.
+	PrincipleHonest = ConstitutionalPrinciple{
+		Name:            "honest",
+		CritiqueRequest: "Identify claims in the response that are false, misleading, or not supported by the task.",
+		RevisionRequest: "Rewrite the response to correct or remove any false, misleading, or unsupported claims.",
+	}
+
+	// PrincipleHelpful critiques responses that do not fully address the task.
+	PrincipleHelpful = ConstitutionalPrinciple{
+		Name:            "helpful",
+		CritiqueRequest: "Identify parts of the task that the response does not address, or addresses incompletely.",
+		RevisionRequest: "Rewrite the response to fully address every part of the task.",
+	}
+
+	// PrincipleConcise critiques verbose or repetitive responses.
+	PrincipleConcise = ConstitutionalPrinciple{
+		Name:            "concise",
+		CritiqueRequest: "Identify verbose, repetitive, or irrelevant content in the response.",
+		RevisionRequest: "Rewrite the response to be concise and to the point without losing relevant information.",
+	}
+)
+
+// ReflectionRound represents a critiqued draft of the Reflection chain.
+type ReflectionRound struct {
+	// Draft is the critiqued draft.
+	Draft string
+	// Critique is the verdict of the critic about the draft.
+	Critique outputparser.CritiqueOutput
+}
+
+// ReflectionOptions contains options for the Reflection chain.
+type ReflectionOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// InputKey is the key of the task in the inputs. It defaults to the first input key of the generator.
+	InputKey string
+
+	// OutputKey is the key to store the final draft in the ChainValues.
+	OutputKey string
+
+	// RoundsKey is the key to store all critiqued drafts in the ChainValues.
+	RoundsKey string
+
+	// PassedKey is the key to store whether the critic passed the final draft in the ChainValues.
+	PassedKey string
+
+	// CriticTemplate is the template of the critic prompt. The principles and format instructions
+	// are provided as partial values principles and formatInstructions.
+	CriticTemplate string
+
+	// RevisionTemplate is the template of the revision prompt. The revision requests of the
+	// principles are provided as partial value revisionRequests.
+	RevisionTemplate string
+
+	// OutputParser parses the critic output into an outputparser.CritiqueOutput.
+	OutputParser schema.OutputParser[any]
+
+	// Principles are the principles the drafts are critiqued and revised against.
+	Principles []ConstitutionalPrinciple
+
+	// MaxRounds is the maximum number of critiques. The last draft is returned unrevised
+	// if the critic does not pass it in the last round.
+	MaxRounds int
+}
+
+// Reflection is a chain that runs a generator chain, critiques the generated draft with a critic
+// and revises the draft until the critic passes it or the maximum number of rounds is reached.
+type Reflection struct {
+	generator schema.Chain
+	critic    *LLM
+	reviser   *LLM
+	opts      ReflectionOptions
+}
+
+// NewReflection creates a new instance of the Reflection chain that uses the given model to
+// critique and revise the drafts of the generator.
+func NewReflection(model schema.Model, generator schema.Chain, optFns ...func(o *ReflectionOptions)) (*Reflection, error) {
+	opts := ReflectionOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey:         "input",
+		OutputKey:        "output",
+		RoundsKey:        "rounds",
+		PassedKey:        "passed",
+		CriticTemplate:   defaultCriticTemplate,
+		RevisionTemplate: defaultRevisionTemplate,
+		MaxRounds:        3,
+	}
+
+	if keys := generator.InputKeys(); len(keys) > 0 {
+		opts.InputKey = keys[0]
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.OutputParser == nil {
+		opts.OutputParser = outputparser.NewCritique()
+	}
+
+	critiqueRequests := []string{"Review the response for correctness, completeness and clarity."}
+	revisionRequests := ""
+
+	if len(opts.Principles) > 0 {
+		critiqueRequests = []string{"Review the response according to the following principles:"}
+		revisions := []string{"\nThe revised response must follow these requests:"}
+
+		for _, p := range opts.Principles {
+			critiqueRequests = append(critiqueRequests, fmt.Sprintf("- %s: %s", p.Name, p.CritiqueRequest))
+			revisions = append(revisions, fmt.Sprintf("- %s", p.RevisionRequest))
+		}
+
+		revisionRequests = strings.Join(revisions, "\n") + "\n"
+	}
+
+	critic, err := NewLLM(model, prompt.NewTemplate(opts.CriticTemplate, func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"principles":         strings.Join(critiqueRequests, "\n"),
+			"formatInstructions": opts.OutputParser.GetFormatInstructions(),
+		}
+	}), func(o *LLMOptions) {
+		o.OutputParser = opts.OutputParser
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviser, err := NewLLM(model, prompt.NewTemplate(opts.RevisionTemplate, func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"revisionRequests": revisionRequests,
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reflection{
+		generator: generator,
+		critic:    critic,
+		reviser:   reviser,
+		opts:      opts,
+	}, nil
+}
+
+// Call executes the reflection chain with the given context and inputs.
+// It returns the final draft, all critiqued drafts and whether the critic passed the final draft or an error, if any.
+func (c *Reflection) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	input, err := inputs.GetString(c.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := c.callString(ctx, c.generator, inputs.Clone(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rounds := []ReflectionRound{}
+
+	for {
+		output, err := golc.Call(ctx, c.critic, schema.ChainValues{"input": input, "draft": draft}, func(co *golc.CallOptions) {
+			co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+			co.ParentRunID = opts.CallbackManger.RunID()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		critique, ok := output[c.critic.OutputKeys()[0]].(outputparser.CritiqueOutput)
+		if !ok {
+			return nil, fmt.Errorf("%w: critic output is not an outputparser.CritiqueOutput", ErrInputValuesWrongType)
+		}
+
+		rounds = append(rounds, ReflectionRound{Draft: draft, Critique: critique})
+
+		if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+			Text: fmt.Sprintf("\nDraft:\n%s\nCritique (pass=%t):\n%s", draft, critique.Pass, critique.Feedback),
+		}); cbErr != nil {
+			return nil, cbErr
+		}
+
+		if critique.Pass || len(rounds) >= c.opts.MaxRounds {
+			return schema.ChainValues{
+				c.opts.OutputKey: draft,
+				c.opts.RoundsKey: rounds,
+				c.opts.PassedKey: critique.Pass,
+			}, nil
+		}
+
+		draft, err = c.callString(ctx, c.reviser, schema.ChainValues{"input": input, "draft": draft, "feedback": critique.Feedback}, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// callString calls the given chain and returns its first output as string.
+func (c *Reflection) callString(ctx context.Context, chain schema.Chain, inputs schema.ChainValues, opts schema.CallOptions) (string, error) {
+	output, err := golc.Call(ctx, chain, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return output.GetString(chain.OutputKeys()[0])
+}
+
+// Memory returns the memory associated with the chain.
+func (c *Reflection) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (c *Reflection) Type() string {
+	return "Reflection"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *Reflection) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *Reflection) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *Reflection) InputKeys() []string {
+	return c.generator.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Reflection) OutputKeys() []string {
+	return []string{c.opts.OutputKey, c.opts.RoundsKey, c.opts.PassedKey}
+}
@@ -0,0 +1,90 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReflection(t *testing.T) {
+	// newModel returns a fake model that answers with the outputs in order and records the prompts.
+	newModel := func(prompts *[]string, outputs ...string) *llm.Fake {
+		fake := llm.NewSequenceFake(outputs)
+
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			*prompts = append(*prompts, prompt)
+
+			return fake.Generate(ctx, prompt)
+		})
+	}
+
+	newGenerator := func(t *testing.T) *LLM {
+		generator, err := NewLLM(llm.NewSimpleFake("draft 1"), prompt.NewTemplate("{{.topic}}"))
+		require.NoError(t, err)
+
+		return generator
+	}
+
+	t.Run("Revise until pass", func(t *testing.T) {
+		prompts := []string{}
+
+		reflection, err := NewReflection(newModel(&prompts,
+			`{"verdict": "fail", "feedback": "Add an example."}`,
+			"draft 2",
+			"```json\n{\"verdict\": \"pass\", \"feedback\": \"Good.\"}\n```",
+		), newGenerator(t), func(o *ReflectionOptions) {
+			o.Principles = []ConstitutionalPrinciple{PrincipleHelpful}
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"topic"}, reflection.InputKeys())
+
+		outputs, err := golc.Call(context.Background(), reflection, schema.ChainValues{"topic": "Explain goroutines"})
+		require.NoError(t, err)
+		require.Equal(t, "draft 2", outputs["output"])
+		require.Equal(t, true, outputs["passed"])
+		require.Equal(t, []ReflectionRound{
+			{Draft: "draft 1", Critique: outputparser.CritiqueOutput{Pass: false, Feedback: "Add an example."}},
+			{Draft: "draft 2", Critique: outputparser.CritiqueOutput{Pass: true, Feedback: "Good."}},
+		}, outputs["rounds"])
+
+		require.Len(t, prompts, 3)
+		require.Contains(t, prompts[0], "- helpful: "+PrincipleHelpful.CritiqueRequest)
+		require.Contains(t, prompts[1], "Feedback:\nAdd an example.")
+		require.Contains(t, prompts[1], "- "+PrincipleHelpful.RevisionRequest)
+	})
+
+	t.Run("Max rounds", func(t *testing.T) {
+		reflection, err := NewReflection(llm.NewSequenceFake([]string{
+			`{"verdict": "fail", "feedback": "Too short."}`,
+			"draft 2",
+			`{"verdict": "fail", "feedback": "Still too short."}`,
+		}), newGenerator(t), func(o *ReflectionOptions) {
+			o.MaxRounds = 2
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), reflection, schema.ChainValues{"topic": "Explain goroutines"})
+		require.NoError(t, err)
+		require.Equal(t, "draft 2", outputs["output"])
+		require.Equal(t, false, outputs["passed"])
+
+		rounds, ok := outputs["rounds"].([]ReflectionRound)
+		require.True(t, ok)
+		require.Len(t, rounds, 2)
+		require.Equal(t, "Still too short.", rounds[1].Critique.Feedback)
+	})
+
+	t.Run("Invalid critique", func(t *testing.T) {
+		reflection, err := NewReflection(llm.NewSequenceFake([]string{"looks fine"}), newGenerator(t))
+		require.NoError(t, err)
+
+		_, err = golc.Call(context.Background(), reflection, schema.ChainValues{"topic": "Explain goroutines"})
+		require.ErrorIs(t, err, outputparser.ErrCannotParseOutput)
+	})
+}
@@ -0,0 +1,156 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Retry satisfies the Chain interface.
+var _ schema.Chain = (*Retry)(nil)
+
+// RetryOptions contains options for the Retry chain.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts, including the first call. Zero is treated as one attempt.
+	MaxAttempts uint
+
+	// Delay is the initial delay between two attempts. It is doubled after every attempt.
+	Delay time.Duration
+
+	// MaxDelay is the maximum delay between two attempts.
+	MaxDelay time.Duration
+
+	// MaxJitter is the maximum random jitter added to the delay.
+	MaxJitter time.Duration
+
+	// RetryIf decides whether an error is retried. All errors except cancelled contexts are retried if nil.
+	// model.IsRetryableError can be used to retry only rate limit, server and context length errors.
+	RetryIf func(err error) bool
+
+	// OnRetry is called with the attempt number after every failed attempt with a retryable error.
+	OnRetry func(attempt uint, err error)
+}
+
+// Retry is a chain that retries the call of the wrapped chain with exponential backoff and jitter.
+type Retry struct {
+	chain schema.Chain
+	opts  RetryOptions
+}
+
+// WithRetry creates a new Retry chain wrapping the given chain.
+func WithRetry(chain schema.Chain, optFns ...func(o *RetryOptions)) *Retry {
+	opts := RetryOptions{
+		MaxAttempts: 3,
+		Delay:       500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		MaxJitter:   250 * time.Millisecond,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	// Zero attempts would retry until the call succeeds.
+	opts.MaxAttempts = max(opts.MaxAttempts, 1)
+
+	return &Retry{
+		chain: chain,
+		opts:  opts,
+	}
+}
+
+// Call executes the wrapped chain with the given context and inputs and retries failed calls.
+// It returns the outputs of the chain or the last error, if any.
+func (c *Retry) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	retryOpts := []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(c.opts.MaxAttempts),
+		retry.Delay(c.opts.Delay),
+		retry.MaxDelay(c.opts.MaxDelay),
+		retry.MaxJitter(c.opts.MaxJitter),
+		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			if errors.Is(err, context.Canceled) {
+				return false
+			}
+
+			if c.opts.RetryIf != nil {
+				return c.opts.RetryIf(err)
+			}
+
+			return true
+		}),
+		retry.OnRetry(func(n uint, err error) {
+			if c.opts.OnRetry != nil {
+				c.opts.OnRetry(n+1, err)
+			}
+		}),
+	}
+
+	var outputs schema.ChainValues
+
+	err := retry.Do(
+		func() error {
+			o, err := c.chain.Call(ctx, inputs.Clone(), func(co *schema.CallOptions) {
+				co.CallbackManger = opts.CallbackManger
+				co.Stop = opts.Stop
+			})
+			if err != nil {
+				return err
+			}
+
+			outputs = o
+
+			return nil
+		},
+		retryOpts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// Memory returns the memory associated with the wrapped chain.
+func (c *Retry) Memory() schema.Memory {
+	return c.chain.Memory()
+}
+
+// Type returns the type of the chain.
+func (c *Retry) Type() string {
+	return "Retry"
+}
+
+// Verbose returns the verbosity setting of the wrapped chain.
+func (c *Retry) Verbose() bool {
+	return c.chain.Verbose()
+}
+
+// Callbacks returns the callbacks associated with the wrapped chain.
+func (c *Retry) Callbacks() []schema.Callback {
+	return c.chain.Callbacks()
+}
+
+// InputKeys returns the expected input keys.
+func (c *Retry) InputKeys() []string {
+	return c.chain.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Retry) OutputKeys() []string {
+	return c.chain.OutputKeys()
+}
@@ -0,0 +1,94 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	newChain := func(t *testing.T, errs ...error) (*Transform, *int) {
+		calls := 0
+
+		transform, err := NewTransform([]string{"input"}, []string{"output"}, func(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+			calls++
+			if calls <= len(errs) {
+				return nil, errs[calls-1]
+			}
+
+			return schema.ChainValues{"output": inputs["input"]}, nil
+		})
+		require.NoError(t, err)
+
+		return transform, &calls
+	}
+
+	fastRetry := func(o *RetryOptions) {
+		o.Delay = time.Millisecond
+		o.MaxJitter = time.Millisecond
+	}
+
+	t.Run("Success after retries", func(t *testing.T) {
+		transform, calls := newChain(t, errTemporary, errTemporary)
+
+		attempts := []uint{}
+
+		retryChain := WithRetry(transform, fastRetry, func(o *RetryOptions) {
+			o.OnRetry = func(attempt uint, err error) {
+				attempts = append(attempts, attempt)
+			}
+		})
+
+		output, err := golc.SimpleCall(context.Background(), retryChain, "foo")
+		require.NoError(t, err)
+		require.Equal(t, "foo", output)
+		require.Equal(t, 3, *calls)
+		require.Equal(t, []uint{1, 2}, attempts)
+	})
+
+	t.Run("Max attempts exceeded", func(t *testing.T) {
+		transform, calls := newChain(t, errTemporary, errTemporary, errTemporary)
+
+		retryChain := WithRetry(transform, fastRetry, func(o *RetryOptions) {
+			o.MaxAttempts = 2
+		})
+
+		_, err := golc.SimpleCall(context.Background(), retryChain, "foo")
+		require.ErrorIs(t, err, errTemporary)
+		require.Equal(t, 2, *calls)
+	})
+
+	t.Run("Zero max attempts", func(t *testing.T) {
+		transform, calls := newChain(t, errTemporary, errTemporary)
+
+		retryChain := WithRetry(transform, fastRetry, func(o *RetryOptions) {
+			o.MaxAttempts = 0
+		})
+
+		_, err := golc.SimpleCall(context.Background(), retryChain, "foo")
+		require.ErrorIs(t, err, errTemporary)
+		require.Equal(t, 1, *calls)
+	})
+
+	t.Run("RetryIf", func(t *testing.T) {
+		transform, calls := newChain(t, errPermanent)
+
+		retryChain := WithRetry(transform, fastRetry, func(o *RetryOptions) {
+			o.RetryIf = func(err error) bool {
+				return errors.Is(err, errTemporary)
+			}
+		})
+
+		_, err := golc.SimpleCall(context.Background(), retryChain, "foo")
+		require.ErrorIs(t, err, errPermanent)
+		require.Equal(t, 1, *calls)
+	})
+}
@@ -0,0 +1,248 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+const defaultRouterTemplate = `Given a raw text input to a language model select the destination best suited for the input. You will be given the names of the available destinations and a description of what the destination is best suited for. You may also revise the original input if you think that revising it will ultimately lead to a better response from the language model.
+
+<< FORMATTING >>
+{{.formatInstructions}}
+
+REMEMBER: "destination" MUST be one of the candidate destination names specified below OR it can be "DEFAULT" if the input is not well suited for any of the candidate destinations.
+REMEMBER: "next_inputs" can just be the original input if you don't think any modifications are needed.
+
+<< CANDIDATE DESTINATIONS >>
+{{.destinations}}
+
+<< INPUT >>
+{{.input}}
+
+<< OUTPUT (must include ` + "```json" + ` at the start of the response) >>
+`
+
+// RouterDestination represents a destination chain of a router.
+type RouterDestination struct {
+	// Name is the unique name of the destination.
+	Name string
+	// Description describes the inputs the destination is best suited for.
+	Description string
+	// Chain is the chain the inputs are routed to.
+	Chain schema.Chain
+}
+
+// Compile time check to ensure Router satisfies the Chain interface.
+var _ schema.Chain = (*Router)(nil)
+
+// RouterOptions contains options for the Router chain.
+type RouterOptions struct {
+	// CallbackOptions contains options for the chain callbacks.
+	*schema.CallbackOptions
+
+	// Memory is the schema.Memory to be associated with the chain.
+	Memory schema.Memory
+
+	// RouterTemplate is the template of the routing prompt. The destinations and format instructions
+	// are provided as partial values destinations and formatInstructions.
+	RouterTemplate string
+
+	// OutputParser parses the model output into an outputparser.RouterOutput.
+	OutputParser schema.OutputParser[any]
+
+	// DefaultChain is the chain used if the model selects no or an unknown destination.
+	DefaultChain schema.Chain
+
+	// RewriteInputs determines whether the inputs rewritten by the model are passed to the destination.
+	RewriteInputs bool
+}
+
+// Router is a chain that uses a model to route the inputs to one of several destination chains.
+type Router struct {
+	llmChain     *LLM
+	destinations map[string]schema.Chain
+	outputKeys   []string
+	opts         RouterOptions
+}
+
+// NewRouter creates a new instance of the Router chain.
+func NewRouter(model schema.Model, destinations []RouterDestination, optFns ...func(o *RouterOptions)) (*Router, error) {
+	opts := RouterOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		RouterTemplate: defaultRouterTemplate,
+		RewriteInputs:  true,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.OutputParser == nil {
+		opts.OutputParser = outputparser.NewRouter()
+	}
+
+	destinationsMap, outputKeys, err := newRouterDestinations(destinations, opts.DefaultChain)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := make([]string, len(destinations))
+	for i, d := range destinations {
+		descriptions[i] = fmt.Sprintf("%s: %s", d.Name, d.Description)
+	}
+
+	routerPrompt := prompt.NewTemplate(opts.RouterTemplate, func(o *prompt.TemplateOptions) {
+		o.PartialValues = map[string]any{
+			"destinations":       strings.Join(descriptions, "\n"),
+			"formatInstructions": opts.OutputParser.GetFormatInstructions(),
+		}
+	})
+
+	llmChain, err := NewLLM(model, routerPrompt, func(o *LLMOptions) {
+		o.OutputParser = opts.OutputParser
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Router{
+		llmChain:     llmChain,
+		destinations: destinationsMap,
+		outputKeys:   outputKeys,
+		opts:         opts,
+	}, nil
+}
+
+// Call executes the router chain with the given context and inputs.
+// It returns the outputs of the destination chain or an error, if any.
+func (c *Router) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	output, err := golc.Call(ctx, c.llmChain, inputs.Clone(), func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routerOutput, ok := output[c.llmChain.OutputKeys()[0]].(outputparser.RouterOutput)
+	if !ok {
+		return nil, fmt.Errorf("%w: router output is not an outputparser.RouterOutput", ErrInputValuesWrongType)
+	}
+
+	nextInputs := inputs.Clone()
+
+	if c.opts.RewriteInputs {
+		for k, v := range routerOutput.NextInputs {
+			nextInputs[k] = v
+		}
+	}
+
+	return routeTo(ctx, c.destinations, c.opts.DefaultChain, routerOutput.Destination, nextInputs, opts)
+}
+
+// Memory returns the memory associated with the chain.
+func (c *Router) Memory() schema.Memory {
+	return c.opts.Memory
+}
+
+// Type returns the type of the chain.
+func (c *Router) Type() string {
+	return "Router"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (c *Router) Verbose() bool {
+	return c.opts.CallbackOptions.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (c *Router) Callbacks() []schema.Callback {
+	return c.opts.CallbackOptions.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (c *Router) InputKeys() []string {
+	return c.llmChain.InputKeys()
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (c *Router) OutputKeys() []string {
+	return c.outputKeys
+}
+
+// newRouterDestinations returns the destinations by name and the union of the output keys of all destinations.
+func newRouterDestinations(destinations []RouterDestination, defaultChain schema.Chain) (map[string]schema.Chain, []string, error) {
+	if len(destinations) == 0 {
+		return nil, nil, ErrNoDestinations
+	}
+
+	destinationsMap := make(map[string]schema.Chain, len(destinations))
+	outputKeys := util.NewSet[string]()
+
+	for _, d := range destinations {
+		if _, ok := destinationsMap[d.Name]; ok {
+			return nil, nil, fmt.Errorf("duplicate destination: %s", d.Name)
+		}
+
+		destinationsMap[d.Name] = d.Chain
+
+		for _, k := range d.Chain.OutputKeys() {
+			outputKeys.Put(k)
+		}
+	}
+
+	if defaultChain != nil {
+		for _, k := range defaultChain.OutputKeys() {
+			outputKeys.Put(k)
+		}
+	}
+
+	keys := outputKeys.ToSlice()
+	sort.Strings(keys)
+
+	return destinationsMap, keys, nil
+}
+
+// routeTo executes the destination chain with the given name or the default chain, if the destination is unknown.
+func routeTo(ctx context.Context, destinations map[string]schema.Chain, defaultChain schema.Chain, name string, inputs schema.ChainValues, opts schema.CallOptions) (schema.ChainValues, error) {
+	destination, ok := destinations[name]
+	if !ok {
+		if defaultChain == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownDestination, name)
+		}
+
+		destination = defaultChain
+		name = "DEFAULT"
+	}
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("\nRouting to destination: %s", name),
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	return golc.Call(ctx, destination, inputs, func(co *golc.CallOptions) {
+		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+		co.ParentRunID = opts.CallbackManger.RunID()
+		co.Stop = opts.Stop
+	})
+}
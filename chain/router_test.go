@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	newDestinations := func(t *testing.T) []RouterDestination {
+		physics, err := NewLLM(llm.NewSimpleFake("physics answer"), prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		math, err := NewLLM(llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "math answer: " + prompt}},
+			}, nil
+		}), prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		return []RouterDestination{
+			{Name: "physics", Description: "Good for answering questions about physics", Chain: physics},
+			{Name: "math", Description: "Good for answering math questions", Chain: math},
+		}
+	}
+
+	t.Run("Route to destination", func(t *testing.T) {
+		model := llm.NewSimpleFake("```json\n{\"destination\": \"math\", \"next_inputs\": \"What is 1+1?\"}\n```")
+
+		router, err := NewRouter(model, newDestinations(t))
+		require.NoError(t, err)
+		require.Equal(t, []string{"input"}, router.InputKeys())
+		require.Equal(t, []string{"text"}, router.OutputKeys())
+
+		output, err := golc.SimpleCall(context.Background(), router, "1+1?")
+		require.NoError(t, err)
+		require.Equal(t, "math answer: What is 1+1?", output)
+	})
+
+	t.Run("Route without rewriting inputs", func(t *testing.T) {
+		model := llm.NewSimpleFake(`{"destination": "math", "next_inputs": "What is 1+1?"}`)
+
+		router, err := NewRouter(model, newDestinations(t), func(o *RouterOptions) {
+			o.RewriteInputs = false
+		})
+		require.NoError(t, err)
+
+		output, err := golc.SimpleCall(context.Background(), router, "1+1?")
+		require.NoError(t, err)
+		require.Equal(t, "math answer: 1+1?", output)
+	})
+
+	t.Run("Route to default chain", func(t *testing.T) {
+		defaultChain, err := NewLLM(llm.NewSimpleFake("default answer"), prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		model := llm.NewSimpleFake(`{"destination": "DEFAULT", "next_inputs": "Hello"}`)
+
+		router, err := NewRouter(model, newDestinations(t), func(o *RouterOptions) {
+			o.DefaultChain = defaultChain
+		})
+		require.NoError(t, err)
+
+		output, err := golc.SimpleCall(context.Background(), router, "Hello")
+		require.NoError(t, err)
+		require.Equal(t, "default answer", output)
+	})
+
+	t.Run("Unknown destination without default chain", func(t *testing.T) {
+		model := llm.NewSimpleFake(`{"destination": "history", "next_inputs": "Who was Caesar?"}`)
+
+		router, err := NewRouter(model, newDestinations(t))
+		require.NoError(t, err)
+
+		_, err = golc.SimpleCall(context.Background(), router, "Who was Caesar?")
+		require.ErrorIs(t, err, ErrUnknownDestination)
+	})
+
+	t.Run("No destinations", func(t *testing.T) {
+		_, err := NewRouter(llm.NewSimpleFake(""), nil)
+		require.ErrorIs(t, err, ErrNoDestinations)
+	})
+}
+
+func TestEmbeddingRouter(t *testing.T) {
+	physics, err := NewLLM(llm.NewSimpleFake("physics answer"), prompt.NewTemplate("{{.input}}"))
+	require.NoError(t, err)
+
+	math, err := NewLLM(llm.NewSimpleFake("math answer"), prompt.NewTemplate("{{.input}}"))
+	require.NoError(t, err)
+
+	defaultChain, err := NewLLM(llm.NewSimpleFake("default answer"), prompt.NewTemplate("{{.input}}"))
+	require.NoError(t, err)
+
+	embedder := &mockEmbedder{
+		embeddings: map[string][]float32{
+			"physics":         {1, 0, 0},
+			"math":            {0, 1, 0},
+			"What is light?":  {0.9, 0.1, 0},
+			"Solve x+1=2":     {0.1, 0.9, 0},
+			"Tell me a story": {0, 0, 1},
+		},
+	}
+
+	router, err := NewEmbeddingRouter(embedder, []RouterDestination{
+		{Name: "physics", Description: "physics", Chain: physics},
+		{Name: "math", Description: "math", Chain: math},
+	}, func(o *EmbeddingRouterOptions) {
+		o.DefaultChain = defaultChain
+		o.ScoreThreshold = 0.5
+	})
+	require.NoError(t, err)
+
+	for input, expected := range map[string]string{
+		"What is light?":  "physics answer",
+		"Solve x+1=2":     "math answer",
+		"Tell me a story": "default answer",
+	} {
+		output, err := golc.SimpleCall(context.Background(), router, input)
+		require.NoError(t, err)
+		require.Equal(t, expected, output)
+	}
+}
+
+// Compile time check to ensure mockEmbedder satisfies the Embedder interface.
+var _ schema.Embedder = (*mockEmbedder)(nil)
+
+type mockEmbedder struct {
+	embeddings map[string][]float32
+}
+
+func (m *mockEmbedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+
+	for i, text := range texts {
+		embeddings[i] = m.embeddings[text]
+	}
+
+	return embeddings, nil
+}
+
+func (m *mockEmbedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return m.embeddings[text], nil
+}
@@ -30,6 +30,9 @@ type Sequential struct {
 
 func NewSequential(chains []schema.Chain, inputKeys []string, optFns ...func(o *SequentialOptions)) (*Sequential, error) {
 	opts := SequentialOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
 		ReturnAll: false,
 	}
 
@@ -119,6 +122,11 @@ func (c *Sequential) Call(ctx context.Context, inputs schema.ChainValues, optFns
 	return result, nil
 }
 
+// Chains returns the chains of the sequence.
+func (c *Sequential) Chains() []schema.Chain {
+	return c.chains
+}
+
 // Memory returns the memory associated with the chain.
 func (c *Sequential) Memory() schema.Memory {
 	return c.opts.Memory
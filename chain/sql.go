@@ -188,7 +188,7 @@ func (c *SQL) Call(ctx context.Context, inputs schema.ChainValues, optFns ...fun
 
 	p := sqldb.NewParser(sqlQuery)
 
-	if !p.IsSelect() {
+	if !p.IsReadOnly() {
 		return nil, fmt.Errorf("unsupported sql query: %s", sqlQuery)
 	}
 
@@ -13,6 +13,9 @@ import (
 var (
 	// Verbose controls the verbosity of the chain execution.
 	Verbose = false
+
+	// Cache is the default cache for model results. Caching is disabled if nil.
+	Cache schema.Cache
 )
 
 type CallOptions struct {
@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ChainNodeOptions contains options for a chain node.
+type ChainNodeOptions struct {
+	Callbacks []schema.Callback
+}
+
+// ChainNode returns a node that executes the chain with the current state as inputs and
+// merges the outputs of the chain into a copy of the state.
+func ChainNode(chain schema.Chain, optFns ...func(o *ChainNodeOptions)) NodeFunc[schema.ChainValues] {
+	opts := ChainNodeOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return func(ctx context.Context, state schema.ChainValues) (schema.ChainValues, error) {
+		outputs, err := golc.Call(ctx, chain, state.Clone(), func(o *golc.CallOptions) {
+			o.Callbacks = opts.Callbacks
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		newState := state.Clone()
+		for k, v := range outputs {
+			newState[k] = v
+		}
+
+		return newState, nil
+	}
+}
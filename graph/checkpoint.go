@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Checkpoint represents the state of a graph execution after a step.
+type Checkpoint struct {
+	// ThreadID identifies the execution the checkpoint belongs to.
+	ThreadID string
+	// Step is the number of executed steps.
+	Step int
+	// Node is the name of the last executed node. It is empty for the initial checkpoint.
+	Node string
+	// Next is the name of the node that is executed next.
+	Next string
+	// State is the JSON encoded state.
+	State []byte
+	// CreatedAt is the creation time of the checkpoint.
+	CreatedAt time.Time
+}
+
+// Checkpointer is an interface for storing and loading checkpoints.
+type Checkpointer interface {
+	// Put stores a checkpoint.
+	Put(ctx context.Context, checkpoint *Checkpoint) error
+	// Get returns the latest checkpoint of the thread or ErrCheckpointNotFound.
+	Get(ctx context.Context, threadID string) (*Checkpoint, error)
+	// List returns all checkpoints of the thread in the order they were stored.
+	List(ctx context.Context, threadID string) ([]*Checkpoint, error)
+}
+
+// Compile time check to ensure InMemoryCheckpointer satisfies the Checkpointer interface.
+var _ Checkpointer = (*InMemoryCheckpointer)(nil)
+
+// InMemoryCheckpointer is a Checkpointer that stores the checkpoints in memory.
+type InMemoryCheckpointer struct {
+	mu          sync.RWMutex
+	checkpoints map[string][]*Checkpoint
+}
+
+// NewInMemoryCheckpointer creates a new InMemoryCheckpointer.
+func NewInMemoryCheckpointer() *InMemoryCheckpointer {
+	return &InMemoryCheckpointer{
+		checkpoints: make(map[string][]*Checkpoint),
+	}
+}
+
+// Put stores a checkpoint.
+func (cp *InMemoryCheckpointer) Put(ctx context.Context, checkpoint *Checkpoint) error {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.checkpoints[checkpoint.ThreadID] = append(cp.checkpoints[checkpoint.ThreadID], checkpoint)
+
+	return nil
+}
+
+// Get returns the latest checkpoint of the thread or ErrCheckpointNotFound.
+func (cp *InMemoryCheckpointer) Get(ctx context.Context, threadID string) (*Checkpoint, error) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	checkpoints := cp.checkpoints[threadID]
+	if len(checkpoints) == 0 {
+		return nil, ErrCheckpointNotFound
+	}
+
+	return checkpoints[len(checkpoints)-1], nil
+}
+
+// List returns all checkpoints of the thread in the order they were stored.
+func (cp *InMemoryCheckpointer) List(ctx context.Context, threadID string) ([]*Checkpoint, error) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	checkpoints := make([]*Checkpoint, len(cp.checkpoints[threadID]))
+	copy(checkpoints, cp.checkpoints[threadID])
+
+	return checkpoints, nil
+}
@@ -0,0 +1,189 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// InvokeOptions contains options for executing a compiled graph.
+type InvokeOptions struct {
+	// ThreadID identifies the execution in the checkpointer. It is required if a checkpointer is configured.
+	ThreadID string
+}
+
+// StateSnapshot represents the checkpointed state of a graph execution.
+type StateSnapshot[S any] struct {
+	// State is the state after the last executed step.
+	State S
+	// Next is the name of the node that is executed next. It is End if the execution is finished.
+	Next string
+	// Step is the number of executed steps.
+	Step int
+}
+
+// Compiled is an executable graph.
+type Compiled[S any] struct {
+	graph           *Graph[S]
+	interruptBefore map[string]struct{}
+	opts            CompileOptions
+}
+
+// Invoke executes the graph with the given initial state, starting at the entry point.
+// It returns the final state or an error, if any. If the execution is interrupted before a node,
+// it returns the current state together with ErrInterrupted.
+func (c *Compiled[S]) Invoke(ctx context.Context, state S, optFns ...func(o *InvokeOptions)) (S, error) {
+	opts := InvokeOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if c.opts.Checkpointer != nil {
+		if opts.ThreadID == "" {
+			return state, ErrNoThreadID
+		}
+
+		if err := c.checkpoint(ctx, opts.ThreadID, 0, "", c.graph.entryPoint, state); err != nil {
+			return state, err
+		}
+	}
+
+	return c.run(ctx, opts.ThreadID, state, c.graph.entryPoint, 0, false)
+}
+
+// Resume continues the execution of the thread from its latest checkpoint.
+// Nodes the execution was interrupted before are executed without interrupting again.
+func (c *Compiled[S]) Resume(ctx context.Context, threadID string) (S, error) {
+	snapshot, err := c.GetState(ctx, threadID)
+	if err != nil {
+		var empty S
+		return empty, err
+	}
+
+	if snapshot.Next == End {
+		return snapshot.State, nil
+	}
+
+	return c.run(ctx, threadID, snapshot.State, snapshot.Next, snapshot.Step, true)
+}
+
+// GetState returns the latest checkpointed state of the thread.
+func (c *Compiled[S]) GetState(ctx context.Context, threadID string) (*StateSnapshot[S], error) {
+	if c.opts.Checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+
+	checkpoint, err := c.opts.Checkpointer.Get(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	var state S
+	if err := json.Unmarshal(checkpoint.State, &state); err != nil {
+		return nil, err
+	}
+
+	return &StateSnapshot[S]{
+		State: state,
+		Next:  checkpoint.Next,
+		Step:  checkpoint.Step,
+	}, nil
+}
+
+// UpdateState replaces the state of the latest checkpoint of the thread, e.g. to apply
+// human feedback while the execution is interrupted. The next node remains unchanged.
+func (c *Compiled[S]) UpdateState(ctx context.Context, threadID string, state S) error {
+	if c.opts.Checkpointer == nil {
+		return ErrNoCheckpointer
+	}
+
+	checkpoint, err := c.opts.Checkpointer.Get(ctx, threadID)
+	if err != nil {
+		return err
+	}
+
+	return c.checkpoint(ctx, threadID, checkpoint.Step, checkpoint.Node, checkpoint.Next, state)
+}
+
+func (c *Compiled[S]) run(ctx context.Context, threadID string, state S, next string, step int, resumed bool) (S, error) {
+	for steps := 0; next != End; steps++ {
+		if steps >= c.opts.RecursionLimit {
+			return state, fmt.Errorf("%w: %d", ErrRecursionLimit, c.opts.RecursionLimit)
+		}
+
+		if _, ok := c.interruptBefore[next]; ok && !resumed {
+			return state, fmt.Errorf("%w: before %s", ErrInterrupted, next)
+		}
+
+		resumed = false
+
+		if err := ctx.Err(); err != nil {
+			return state, err
+		}
+
+		node, ok := c.graph.nodes[next]
+		if !ok {
+			return state, fmt.Errorf("%w: %s", ErrUnknownNode, next)
+		}
+
+		newState, err := node(ctx, state)
+		if err != nil {
+			return state, fmt.Errorf("node %s: %w", next, err)
+		}
+
+		state = newState
+		step++
+
+		current := next
+
+		next, err = c.nextNode(ctx, current, state)
+		if err != nil {
+			return state, err
+		}
+
+		if c.opts.Checkpointer != nil {
+			if err := c.checkpoint(ctx, threadID, step, current, next, state); err != nil {
+				return state, err
+			}
+		}
+	}
+
+	return state, nil
+}
+
+func (c *Compiled[S]) nextNode(ctx context.Context, current string, state S) (string, error) {
+	if to, ok := c.graph.edges[current]; ok {
+		return to, nil
+	}
+
+	fn := c.graph.conditionalEdges[current]
+
+	to, err := fn(ctx, state)
+	if err != nil {
+		return "", fmt.Errorf("edge from %s: %w", current, err)
+	}
+
+	if _, ok := c.graph.nodes[to]; !ok && to != End {
+		return "", fmt.Errorf("%w: %s", ErrUnknownNode, to)
+	}
+
+	return to, nil
+}
+
+func (c *Compiled[S]) checkpoint(ctx context.Context, threadID string, step int, node, next string, state S) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return c.opts.Checkpointer.Put(ctx, &Checkpoint{
+		ThreadID:  threadID,
+		Step:      step,
+		Node:      node,
+		Next:      next,
+		State:     b,
+		CreatedAt: time.Now().UTC(),
+	})
+}
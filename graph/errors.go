@@ -0,0 +1,17 @@
+package graph
+
+import "errors"
+
+var (
+	ErrNoEntryPoint       = errors.New("no entry point")
+	ErrUnknownNode        = errors.New("unknown node")
+	ErrDuplicateNode      = errors.New("duplicate node")
+	ErrReservedNode       = errors.New("reserved node name")
+	ErrDuplicateEdge      = errors.New("duplicate edge")
+	ErrMissingEdge        = errors.New("node has no outgoing edge")
+	ErrRecursionLimit     = errors.New("recursion limit reached")
+	ErrInterrupted        = errors.New("graph execution interrupted")
+	ErrNoCheckpointer     = errors.New("no checkpointer")
+	ErrNoThreadID         = errors.New("no thread id")
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+)
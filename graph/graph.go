@@ -0,0 +1,161 @@
+// Package graph provides a stateful orchestration of nodes connected by static and conditional edges.
+// Graphs may contain cycles and their state can be checkpointed after every step, so that
+// interrupted runs can be inspected and resumed.
+package graph
+
+import (
+	"context"
+	"fmt"
+)
+
+// End is the name of the virtual node that terminates the graph execution.
+const End = "__end__"
+
+// DefaultRecursionLimit is the default maximum number of steps of a graph execution.
+const DefaultRecursionLimit = 25
+
+// NodeFunc is a function that transforms the state of the graph.
+type NodeFunc[S any] func(ctx context.Context, state S) (S, error)
+
+// EdgeFunc is a function that returns the name of the next node based on the state of the graph.
+type EdgeFunc[S any] func(ctx context.Context, state S) (string, error)
+
+// Graph is a builder for a stateful graph of nodes operating on a state of type S.
+type Graph[S any] struct {
+	nodes            map[string]NodeFunc[S]
+	edges            map[string]string
+	conditionalEdges map[string]EdgeFunc[S]
+	entryPoint       string
+}
+
+// New creates a new empty graph.
+func New[S any]() *Graph[S] {
+	return &Graph[S]{
+		nodes:            make(map[string]NodeFunc[S]),
+		edges:            make(map[string]string),
+		conditionalEdges: make(map[string]EdgeFunc[S]),
+	}
+}
+
+// AddNode adds a node with the given name to the graph.
+func (g *Graph[S]) AddNode(name string, fn NodeFunc[S]) error {
+	if name == End {
+		return fmt.Errorf("%w: %s", ErrReservedNode, End)
+	}
+
+	if _, ok := g.nodes[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateNode, name)
+	}
+
+	g.nodes[name] = fn
+
+	return nil
+}
+
+// AddEdge adds a static edge from one node to another. Use End as target to terminate the execution.
+func (g *Graph[S]) AddEdge(from, to string) error {
+	if err := g.checkOutgoing(from); err != nil {
+		return err
+	}
+
+	if _, ok := g.nodes[to]; !ok && to != End {
+		return fmt.Errorf("%w: %s", ErrUnknownNode, to)
+	}
+
+	g.edges[from] = to
+
+	return nil
+}
+
+// AddConditionalEdge adds an edge from a node to the node returned by the edge function.
+// The edge function may return End to terminate the execution.
+func (g *Graph[S]) AddConditionalEdge(from string, fn EdgeFunc[S]) error {
+	if err := g.checkOutgoing(from); err != nil {
+		return err
+	}
+
+	g.conditionalEdges[from] = fn
+
+	return nil
+}
+
+// SetEntryPoint sets the node the graph execution starts with.
+func (g *Graph[S]) SetEntryPoint(name string) error {
+	if _, ok := g.nodes[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownNode, name)
+	}
+
+	g.entryPoint = name
+
+	return nil
+}
+
+// CompileOptions contains options for compiling a graph.
+type CompileOptions struct {
+	// Checkpointer stores the state after every step. Checkpointing is disabled if nil.
+	Checkpointer Checkpointer
+	// RecursionLimit is the maximum number of steps of a single execution.
+	RecursionLimit int
+	// InterruptBefore contains the names of the nodes before which the execution is interrupted.
+	// Interrupts require a checkpointer, so that the execution can be resumed.
+	InterruptBefore []string
+}
+
+// Compile validates the graph and returns an executable version of it.
+func (g *Graph[S]) Compile(optFns ...func(o *CompileOptions)) (*Compiled[S], error) {
+	opts := CompileOptions{
+		RecursionLimit: DefaultRecursionLimit,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if g.entryPoint == "" {
+		return nil, ErrNoEntryPoint
+	}
+
+	for name := range g.nodes {
+		_, hasEdge := g.edges[name]
+		_, hasConditionalEdge := g.conditionalEdges[name]
+
+		if !hasEdge && !hasConditionalEdge {
+			return nil, fmt.Errorf("%w: %s", ErrMissingEdge, name)
+		}
+	}
+
+	interruptBefore := make(map[string]struct{}, len(opts.InterruptBefore))
+
+	for _, name := range opts.InterruptBefore {
+		if _, ok := g.nodes[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownNode, name)
+		}
+
+		interruptBefore[name] = struct{}{}
+	}
+
+	if len(interruptBefore) > 0 && opts.Checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+
+	return &Compiled[S]{
+		graph:           g,
+		interruptBefore: interruptBefore,
+		opts:            opts,
+	}, nil
+}
+
+func (g *Graph[S]) checkOutgoing(from string) error {
+	if _, ok := g.nodes[from]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownNode, from)
+	}
+
+	_, hasEdge := g.edges[from]
+	_, hasConditionalEdge := g.conditionalEdges[from]
+
+	if hasEdge || hasConditionalEdge {
+		return fmt.Errorf("%w: %s", ErrDuplicateEdge, from)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type counterState struct {
+	Count int      `json:"count"`
+	Path  []string `json:"path"`
+}
+
+func increment(name string) NodeFunc[counterState] {
+	return func(ctx context.Context, state counterState) (counterState, error) {
+		state.Count++
+		state.Path = append(state.Path, name)
+
+		return state, nil
+	}
+}
+
+func newCounterGraph(t *testing.T, max int) *Graph[counterState] {
+	t.Helper()
+
+	g := New[counterState]()
+	require.NoError(t, g.AddNode("increment", increment("increment")))
+	require.NoError(t, g.AddNode("review", increment("review")))
+	require.NoError(t, g.AddEdge("increment", "review"))
+	require.NoError(t, g.AddConditionalEdge("review", func(ctx context.Context, state counterState) (string, error) {
+		if state.Count >= max {
+			return End, nil
+		}
+
+		return "increment", nil
+	}))
+	require.NoError(t, g.SetEntryPoint("increment"))
+
+	return g
+}
+
+func TestGraph(t *testing.T) {
+	t.Run("Cycle with conditional edge", func(t *testing.T) {
+		compiled, err := newCounterGraph(t, 4).Compile()
+		require.NoError(t, err)
+
+		state, err := compiled.Invoke(context.Background(), counterState{})
+		require.NoError(t, err)
+		require.Equal(t, 4, state.Count)
+		require.Equal(t, []string{"increment", "review", "increment", "review"}, state.Path)
+	})
+
+	t.Run("Recursion limit", func(t *testing.T) {
+		compiled, err := newCounterGraph(t, 100).Compile(func(o *CompileOptions) {
+			o.RecursionLimit = 5
+		})
+		require.NoError(t, err)
+
+		state, err := compiled.Invoke(context.Background(), counterState{})
+		require.ErrorIs(t, err, ErrRecursionLimit)
+		require.Equal(t, 5, state.Count)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		g := New[counterState]()
+		require.NoError(t, g.AddNode("a", increment("a")))
+		require.ErrorIs(t, g.AddNode("a", increment("a")), ErrDuplicateNode)
+		require.ErrorIs(t, g.AddNode(End, increment("end")), ErrReservedNode)
+		require.ErrorIs(t, g.AddEdge("a", "unknown"), ErrUnknownNode)
+		require.ErrorIs(t, g.SetEntryPoint("unknown"), ErrUnknownNode)
+
+		_, err := g.Compile()
+		require.ErrorIs(t, err, ErrNoEntryPoint)
+
+		require.NoError(t, g.SetEntryPoint("a"))
+
+		_, err = g.Compile()
+		require.ErrorIs(t, err, ErrMissingEdge)
+
+		require.NoError(t, g.AddEdge("a", End))
+		require.ErrorIs(t, g.AddEdge("a", End), ErrDuplicateEdge)
+
+		_, err = g.Compile()
+		require.NoError(t, err)
+	})
+
+	t.Run("Unknown node from conditional edge", func(t *testing.T) {
+		g := New[counterState]()
+		require.NoError(t, g.AddNode("a", increment("a")))
+		require.NoError(t, g.AddConditionalEdge("a", func(ctx context.Context, state counterState) (string, error) {
+			return "unknown", nil
+		}))
+		require.NoError(t, g.SetEntryPoint("a"))
+
+		compiled, err := g.Compile()
+		require.NoError(t, err)
+
+		_, err = compiled.Invoke(context.Background(), counterState{})
+		require.ErrorIs(t, err, ErrUnknownNode)
+	})
+
+	t.Run("Chain node", func(t *testing.T) {
+		llmChain, err := chain.NewLLM(llm.NewSimpleFake("Paris"), prompt.NewTemplate("{{.question}}"), func(o *chain.LLMOptions) {
+			o.OutputKey = "answer"
+		})
+		require.NoError(t, err)
+
+		g := New[schema.ChainValues]()
+		require.NoError(t, g.AddNode("answer", ChainNode(llmChain)))
+		require.NoError(t, g.AddEdge("answer", End))
+		require.NoError(t, g.SetEntryPoint("answer"))
+
+		compiled, err := g.Compile()
+		require.NoError(t, err)
+
+		state, err := compiled.Invoke(context.Background(), schema.ChainValues{"question": "Capital of France?"})
+		require.NoError(t, err)
+		require.Equal(t, schema.ChainValues{"question": "Capital of France?", "answer": "Paris"}, state)
+	})
+}
+
+func TestCheckpoints(t *testing.T) {
+	t.Run("Resume after failure", func(t *testing.T) {
+		checkpointer := NewInMemoryCheckpointer()
+
+		fail := true
+
+		g := New[counterState]()
+		require.NoError(t, g.AddNode("a", increment("a")))
+		require.NoError(t, g.AddNode("b", func(ctx context.Context, state counterState) (counterState, error) {
+			if fail {
+				return state, errors.New("temporary failure")
+			}
+
+			return increment("b")(ctx, state)
+		}))
+		require.NoError(t, g.AddEdge("a", "b"))
+		require.NoError(t, g.AddEdge("b", End))
+		require.NoError(t, g.SetEntryPoint("a"))
+
+		compiled, err := g.Compile(func(o *CompileOptions) {
+			o.Checkpointer = checkpointer
+		})
+		require.NoError(t, err)
+
+		_, err = compiled.Invoke(context.Background(), counterState{})
+		require.ErrorIs(t, err, ErrNoThreadID)
+
+		_, err = compiled.Invoke(context.Background(), counterState{}, func(o *InvokeOptions) {
+			o.ThreadID = "thread"
+		})
+		require.EqualError(t, err, "node b: temporary failure")
+
+		snapshot, err := compiled.GetState(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Equal(t, "b", snapshot.Next)
+		require.Equal(t, 1, snapshot.Step)
+		require.Equal(t, []string{"a"}, snapshot.State.Path)
+
+		fail = false
+
+		state, err := compiled.Resume(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, state.Path)
+
+		checkpoints, err := checkpointer.List(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Len(t, checkpoints, 3)
+		require.Equal(t, End, checkpoints[2].Next)
+	})
+
+	t.Run("Interrupt before node", func(t *testing.T) {
+		compiled, err := newCounterGraph(t, 2).Compile(func(o *CompileOptions) {
+			o.Checkpointer = NewInMemoryCheckpointer()
+			o.InterruptBefore = []string{"review"}
+		})
+		require.NoError(t, err)
+
+		state, err := compiled.Invoke(context.Background(), counterState{}, func(o *InvokeOptions) {
+			o.ThreadID = "thread"
+		})
+		require.ErrorIs(t, err, ErrInterrupted)
+		require.Equal(t, []string{"increment"}, state.Path)
+
+		state.Path = append(state.Path, "approved")
+		require.NoError(t, compiled.UpdateState(context.Background(), "thread", state))
+
+		state, err = compiled.Resume(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Equal(t, 2, state.Count)
+		require.Equal(t, []string{"increment", "approved", "review"}, state.Path)
+	})
+
+	t.Run("Interrupt requires checkpointer", func(t *testing.T) {
+		_, err := newCounterGraph(t, 2).Compile(func(o *CompileOptions) {
+			o.InterruptBefore = []string{"review"}
+		})
+		require.ErrorIs(t, err, ErrNoCheckpointer)
+	})
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Compile time check to ensure SQLiteCheckpointer satisfies the Checkpointer interface.
+var _ Checkpointer = (*SQLiteCheckpointer)(nil)
+
+// SQLiteCheckpointerOptions contains options for the SQLite checkpointer.
+type SQLiteCheckpointerOptions struct {
+	// TableName is the name of the table storing the checkpoints.
+	TableName string
+}
+
+// SQLiteCheckpointer is a Checkpointer that stores the checkpoints in a SQLite database.
+// The caller is responsible for registering a SQLite driver, e.g. github.com/mattn/go-sqlite3.
+type SQLiteCheckpointer struct {
+	db   *sql.DB
+	opts SQLiteCheckpointerOptions
+}
+
+// NewSQLiteCheckpointer creates a new SQLiteCheckpointer and creates the checkpoint table if it does not exist.
+func NewSQLiteCheckpointer(ctx context.Context, db *sql.DB, optFns ...func(o *SQLiteCheckpointerOptions)) (*SQLiteCheckpointer, error) {
+	opts := SQLiteCheckpointerOptions{
+		TableName: "checkpoints",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	thread_id TEXT NOT NULL,
+	step INTEGER NOT NULL,
+	node TEXT NOT NULL,
+	next TEXT NOT NULL,
+	state BLOB NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);`, opts.TableName)
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return nil, err
+	}
+
+	return &SQLiteCheckpointer{
+		db:   db,
+		opts: opts,
+	}, nil
+}
+
+// Put stores a checkpoint.
+func (cp *SQLiteCheckpointer) Put(ctx context.Context, checkpoint *Checkpoint) error {
+	query := fmt.Sprintf("INSERT INTO %s (thread_id, step, node, next, state, created_at) VALUES (?, ?, ?, ?, ?, ?);", cp.opts.TableName)
+
+	_, err := cp.db.ExecContext(ctx, query, checkpoint.ThreadID, checkpoint.Step, checkpoint.Node, checkpoint.Next, checkpoint.State, checkpoint.CreatedAt)
+
+	return err
+}
+
+// Get returns the latest checkpoint of the thread or ErrCheckpointNotFound.
+func (cp *SQLiteCheckpointer) Get(ctx context.Context, threadID string) (*Checkpoint, error) {
+	query := fmt.Sprintf("SELECT thread_id, step, node, next, state, created_at FROM %s WHERE thread_id = ? ORDER BY id DESC LIMIT 1;", cp.opts.TableName)
+
+	checkpoint, err := scanCheckpoint(cp.db.QueryRowContext(ctx, query, threadID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCheckpointNotFound
+		}
+
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// List returns all checkpoints of the thread in the order they were stored.
+func (cp *SQLiteCheckpointer) List(ctx context.Context, threadID string) ([]*Checkpoint, error) {
+	query := fmt.Sprintf("SELECT thread_id, step, node, next, state, created_at FROM %s WHERE thread_id = ? ORDER BY id ASC;", cp.opts.TableName)
+
+	rows, err := cp.db.QueryContext(ctx, query, threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	checkpoints := []*Checkpoint{}
+
+	for rows.Next() {
+		checkpoint, err := scanCheckpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return checkpoints, nil
+}
+
+func scanCheckpoint(row interface{ Scan(dest ...any) error }) (*Checkpoint, error) {
+	var checkpoint Checkpoint
+
+	if err := row.Scan(&checkpoint.ThreadID, &checkpoint.Step, &checkpoint.Node, &checkpoint.Next, &checkpoint.State, &checkpoint.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
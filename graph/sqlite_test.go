@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteCheckpointer(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	checkpointer, err := NewSQLiteCheckpointer(context.Background(), db)
+	require.NoError(t, err)
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := checkpointer.Get(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrCheckpointNotFound)
+	})
+
+	t.Run("Put and get", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, checkpointer.Put(context.Background(), &Checkpoint{
+				ThreadID:  "thread",
+				Step:      i,
+				Node:      "node",
+				Next:      "next",
+				State:     []byte(`{"count":1}`),
+				CreatedAt: time.Now().UTC(),
+			}))
+		}
+
+		checkpoint, err := checkpointer.Get(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Equal(t, 2, checkpoint.Step)
+		require.Equal(t, []byte(`{"count":1}`), checkpoint.State)
+
+		checkpoints, err := checkpointer.List(context.Background(), "thread")
+		require.NoError(t, err)
+		require.Len(t, checkpoints, 3)
+		require.Equal(t, 0, checkpoints[0].Step)
+	})
+
+	t.Run("Graph", func(t *testing.T) {
+		compiled, err := newCounterGraph(t, 2).Compile(func(o *CompileOptions) {
+			o.Checkpointer = checkpointer
+		})
+		require.NoError(t, err)
+
+		_, err = compiled.Invoke(context.Background(), counterState{}, func(o *InvokeOptions) {
+			o.ThreadID = "graph"
+		})
+		require.NoError(t, err)
+
+		snapshot, err := compiled.GetState(context.Background(), "graph")
+		require.NoError(t, err)
+		require.Equal(t, End, snapshot.Next)
+		require.Equal(t, 2, snapshot.State.Count)
+	})
+}
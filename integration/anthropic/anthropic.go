@@ -29,6 +29,9 @@ type Options struct {
 	// The version of the Anthropic API to use.
 	Version string
 
+	// The version of the Anthropic API to use for the messages API.
+	MessagesVersion string
+
 	// The SDK identifier used in the API requests.
 	SDK string
 
@@ -45,10 +48,11 @@ type Client struct {
 // New creates a new instance of the Anthropic API client with the given API key and optional configuration options.
 func New(apiKey string, optFns ...func(o *Options)) *Client {
 	opts := Options{
-		APIUrl:     "https://api.anthropic.com",
-		Version:    "2023-01-01",
-		SDK:        "golc-anthrophic-sdk",
-		HTTPClient: http.DefaultClient,
+		APIUrl:          "https://api.anthropic.com",
+		Version:         "2023-01-01",
+		MessagesVersion: "2023-06-01",
+		SDK:             "golc-anthrophic-sdk",
+		HTTPClient:      http.DefaultClient,
 	}
 
 	for _, fn := range optFns {
@@ -98,6 +98,74 @@ func TestClient(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, response)
 	})
+
+	t.Run("CreateMessage", func(t *testing.T) {
+		mockClient := &mockHTTPClient{
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "https://api.anthropic.com/v1/messages", req.URL.String())
+				assert.Equal(t, "2023-06-01", req.Header.Get("Anthropic-Version"))
+
+				body, bErr := io.ReadAll(req.Body)
+				assert.NoError(t, bErr)
+
+				defer req.Body.Close()
+
+				assert.JSONEq(t, `{
+					"model":"claude-3-haiku-20240307",
+					"max_tokens":256,
+					"messages":[{"role":"user","content":[{"type":"text","text":"Weather in Berlin?"}]}],
+					"tools":[{"name":"weather","input_schema":{"type":"object"}}],
+					"tool_choice":{"type":"any"}
+				}`, string(body))
+
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body: io.NopCloser(bytes.NewBufferString(`{
+						"id":"msg_1",
+						"role":"assistant",
+						"content":[{"type":"tool_use","id":"toolu_1","name":"weather","input":{"city":"Berlin"}}],
+						"stop_reason":"tool_use"
+					}`)),
+				}, nil
+			},
+		}
+
+		client := New("api-key", func(o *Options) {
+			o.HTTPClient = mockClient
+		})
+
+		response, err := client.CreateMessage(context.Background(), &MessageRequest{
+			Model:      "claude-3-haiku-20240307",
+			MaxTokens:  256,
+			Messages:   []Message{{Role: "user", Content: []ContentBlock{{Type: ContentTypeText, Text: "Weather in Berlin?"}}}},
+			Tools:      []Tool{{Name: "weather", InputSchema: map[string]any{"type": "object"}}},
+			ToolChoice: &ToolChoice{Type: "any"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "tool_use", response.StopReason)
+		assert.Equal(t, ContentTypeToolUse, response.Content[0].Type)
+		assert.Equal(t, "toolu_1", response.Content[0].ID)
+		assert.JSONEq(t, `{"city":"Berlin"}`, string(response.Content[0].Input))
+	})
+
+	t.Run("CreateMessage_APIError", func(t *testing.T) {
+		mockClient := &mockHTTPClient{
+			doFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusBadRequest,
+					Body:       io.NopCloser(bytes.NewBufferString(`{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens required"}}`)),
+				}, nil
+			},
+		}
+
+		client := New("api-key", func(o *Options) {
+			o.HTTPClient = mockClient
+		})
+
+		response, err := client.CreateMessage(context.Background(), &MessageRequest{})
+		assert.EqualError(t, err, "anthropic API error: invalid_request_error: max_tokens required")
+		assert.Nil(t, response)
+	})
 }
 
 // mockHTTPClient is a mock implementation of the HTTPClient interface.
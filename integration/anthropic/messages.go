@@ -0,0 +1,163 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Content block types of the messages API.
+const (
+	ContentTypeText       = "text"
+	ContentTypeToolUse    = "tool_use"
+	ContentTypeToolResult = "tool_result"
+)
+
+// ContentBlock represents a content block of a message.
+type ContentBlock struct {
+	// The type of the content block.
+	Type string `json:"type"`
+	// The text of a text block.
+	Text string `json:"text,omitempty"`
+	// The ID of a tool use block.
+	ID string `json:"id,omitempty"`
+	// The tool name of a tool use block.
+	Name string `json:"name,omitempty"`
+	// The tool input of a tool use block as JSON object.
+	Input json.RawMessage `json:"input,omitempty"`
+	// The ID of the tool use a tool result block responds to.
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	// The content of a tool result block.
+	Content string `json:"content,omitempty"`
+}
+
+// Message represents a message in the conversation.
+type Message struct {
+	// The role of the message, either "user" or "assistant".
+	Role string `json:"role"`
+	// The content blocks of the message.
+	Content []ContentBlock `json:"content"`
+}
+
+// Tool represents a tool the model may use.
+type Tool struct {
+	// The name of the tool.
+	Name string `json:"name"`
+	// The description of the tool.
+	Description string `json:"description,omitempty"`
+	// The JSON schema of the tool input.
+	InputSchema any `json:"input_schema"`
+}
+
+// ToolChoice controls how the model uses the tools.
+type ToolChoice struct {
+	// The type of the tool choice, either "auto", "any" or "tool".
+	Type string `json:"type"`
+	// The name of the tool to use, if the type is "tool".
+	Name string `json:"name,omitempty"`
+}
+
+// MessageRequest represents a request to the Anthropic messages API.
+type MessageRequest struct {
+	// The model to use.
+	Model string `json:"model"`
+	// The messages of the conversation.
+	Messages []Message `json:"messages"`
+	// The system prompt.
+	System string `json:"system,omitempty"`
+	// The maximum number of tokens to generate.
+	MaxTokens int `json:"max_tokens"`
+	// List of strings to stop generation at.
+	Stop []string `json:"stop_sequences,omitempty"`
+	// The temperature for randomness in sampling.
+	Temperature float32 `json:"temperature,omitempty"`
+	// The number of highest probability tokens to use in sampling.
+	TopK int `json:"top_k,omitempty"`
+	// The cumulative probability for nucleus sampling.
+	TopP float32 `json:"top_p,omitempty"`
+	// The tools the model may use.
+	Tools []Tool `json:"tools,omitempty"`
+	// How the model uses the tools.
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+}
+
+// Usage represents the token usage of a request.
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// MessageResponse represents the response from the Anthropic messages API.
+type MessageResponse struct {
+	// The ID of the message.
+	ID string `json:"id"`
+	// The role of the message, always "assistant".
+	Role string `json:"role"`
+	// The generated content blocks.
+	Content []ContentBlock `json:"content"`
+	// The model that generated the message.
+	Model string `json:"model"`
+	// The reason for stopping generation.
+	StopReason string `json:"stop_reason"`
+	// The stop sequence that caused generation to stop.
+	StopSequence string `json:"stop_sequence"`
+	// The token usage of the request.
+	Usage Usage `json:"usage"`
+}
+
+// errorResponse represents an error response from the Anthropic API.
+type errorResponse struct {
+	Error struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateMessage sends a request to the Anthropic messages API and returns the response.
+func (c *Client) CreateMessage(ctx context.Context, request *MessageRequest) (*MessageResponse, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/messages", c.opts.APIUrl), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Anthropic-SDK", c.opts.SDK)
+	req.Header.Set("Anthropic-Version", c.opts.MessagesVersion)
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	resp, err := c.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResp := errorResponse{}
+		if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Message == "" {
+			return nil, fmt.Errorf("anthropic API error: status code %d", resp.StatusCode)
+		}
+
+		return nil, fmt.Errorf("anthropic API error: %s: %s", errResp.Error.Type, errResp.Error.Message)
+	}
+
+	var response MessageResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -175,8 +175,15 @@ func GenerateFromField(f reflect.StructField, mode Mode, definedRefs map[string]
 		return name, false, nil, err
 	}
 
+	schemaTags := parseSchemaTag(f.Tag.Get("jsonschema"))
+
 	for _, tag := range []string{"description", "doc", "format", "enum", "default", "example", "minimum", "exclusiveMinimum", "maximum", "exclusiveMaximum", "multipleOf", "minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems", "minProperties", "maxProperties", "nullable", "readOnly", "writeOnly", "deprecated"} {
-		if tagValue, ok := f.Tag.Lookup(tag); ok {
+		tagValue, ok := f.Tag.Lookup(tag)
+		if !ok {
+			tagValue, ok = schemaTags[tag]
+		}
+
+		if ok {
 			switch tag {
 			case "description", "doc":
 				s.Description = tagValue
@@ -325,9 +332,63 @@ func GenerateFromField(f reflect.StructField, mode Mode, definedRefs map[string]
 		}
 	}
 
+	if _, ok := schemaTags["required"]; ok {
+		optional = false
+	}
+
+	if _, ok := schemaTags["optional"]; ok {
+		optional = true
+	}
+
 	return name, optional, s, nil
 }
 
+// parseSchemaTag parses a jsonschema struct tag like `jsonschema:"required,description=The name,enum=a,enum=b"`.
+// Commas in values must be escaped with a backslash, written as \\, in the struct tag. Repeated
+// keys are joined with a comma, flags without value like required and optional map to an empty string.
+func parseSchemaTag(tag string) map[string]string {
+	values := map[string]string{}
+
+	if tag == "" {
+		return values
+	}
+
+	parts := []string{}
+	current := strings.Builder{}
+
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			current.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(tag[i])
+		}
+	}
+
+	parts = append(parts, current.String())
+
+	for _, part := range parts {
+		key, value, _ := strings.Cut(part, "=")
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		if existing, ok := values[key]; ok && key == "enum" {
+			value = existing + "," + value
+		}
+
+		values[key] = value
+	}
+
+	return values
+}
+
 // handleEnumTag handles the "enum" tag for a struct field.
 func handleEnumTag(f reflect.StructField, s *Schema, tagValue string) error {
 	s.Enum = []interface{}{}
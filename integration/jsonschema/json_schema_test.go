@@ -64,6 +64,23 @@ func TestGenerate(t *testing.T) {
 		assert.Contains(t, schema.Properties["nested"].Properties, "nested_field")
 	})
 
+	t.Run("Generate schema for struct with jsonschema tags", func(t *testing.T) {
+		type MyStruct struct {
+			Query string `json:"query,omitempty" jsonschema:"required,description=The query\\, in plain text"`
+			Order string `json:"order" jsonschema:"optional,enum=asc,enum=desc"`
+			Limit int    `json:"limit" jsonschema:"minimum=1" description:"The maximum number of results"`
+		}
+
+		schema, err := Generate(reflect.TypeOf(MyStruct{}))
+		assert.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"query", "limit"}, schema.Required)
+		assert.Equal(t, "The query, in plain text", schema.Properties["query"].Description)
+		assert.Equal(t, []interface{}{"asc", "desc"}, schema.Properties["order"].Enum)
+		assert.Equal(t, 1.0, *schema.Properties["limit"].Minimum)
+		assert.Equal(t, "The maximum number of results", schema.Properties["limit"].Description)
+	})
+
 	t.Run("Generate schema for struct with array field", func(t *testing.T) {
 		type MyStruct struct {
 			ArrayField []string `json:"array_field"`
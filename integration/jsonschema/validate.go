@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// ErrValidation represents an error indicating that a value does not match the schema.
+var ErrValidation = errors.New("value does not match schema")
+
+// Validate checks that the given value, as decoded by json.Unmarshal into an any, matches the schema.
+// It validates types, required and enum values of objects, their properties and array items.
+// Other validation rules and references are not checked.
+func (s *Schema) Validate(value any) error {
+	return s.validate("$", value)
+}
+
+// ValidateJSON decodes the given JSON document and checks that it matches the schema.
+func (s *Schema) ValidateJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	return s.Validate(value)
+}
+
+func (s *Schema) validate(path string, value any) error {
+	if value == nil {
+		if s.Nullable || s.Type == "" || s.Type == "null" {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s must be of type %s, got null", ErrValidation, path, s.Type)
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, value) {
+		return fmt.Errorf("%w: %s must be one of %v", ErrValidation, path, s.Enum)
+	}
+
+	switch s.Type {
+	case TypeString:
+		if _, ok := value.(string); !ok {
+			return typeError(path, s.Type, value)
+		}
+	case TypeNumber:
+		if _, ok := value.(float64); !ok {
+			return typeError(path, s.Type, value)
+		}
+	case TypeInteger:
+		if f, ok := value.(float64); !ok || f != math.Trunc(f) {
+			return typeError(path, s.Type, value)
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return typeError(path, s.Type, value)
+		}
+	case TypeArray:
+		items, ok := value.([]any)
+		if !ok {
+			return typeError(path, s.Type, value)
+		}
+
+		if s.Items != nil {
+			for i, item := range items {
+				if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case TypeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return typeError(path, s.Type, value)
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return fmt.Errorf("%w: %s.%s is required", ErrValidation, path, name)
+			}
+		}
+
+		for name, v := range object {
+			if property, ok := s.Properties[name]; ok {
+				if err := property.validate(fmt.Sprintf("%s.%s", path, name), v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// typeError returns a validation error for a value of an unexpected type.
+func typeError(path, typ string, value any) error {
+	return fmt.Errorf("%w: %s must be of type %s, got %T", ErrValidation, path, typ, value)
+}
+
+// containsValue checks if the given values contain the value.
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) || fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	type Item struct {
+		Name string `json:"name"`
+	}
+
+	type MyStruct struct {
+		Query string   `json:"query"`
+		Limit int      `json:"limit,omitempty"`
+		Order string   `json:"order,omitempty" enum:"asc,desc"`
+		Items []Item   `json:"items,omitempty"`
+		Tags  []string `json:"tags,omitempty"`
+	}
+
+	schema, err := Generate(reflect.TypeOf(MyStruct{}))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{name: "valid", json: `{"query": "foo", "limit": 5, "order": "asc", "items": [{"name": "bar"}], "tags": ["a"]}`},
+		{name: "optional fields", json: `{"query": "foo"}`},
+		{name: "missing required", json: `{"limit": 5}`, wantErr: "$.query is required"},
+		{name: "wrong type", json: `{"query": 1}`, wantErr: "$.query must be of type string, got float64"},
+		{name: "no integer", json: `{"query": "foo", "limit": 1.5}`, wantErr: "$.limit must be of type integer"},
+		{name: "invalid enum", json: `{"query": "foo", "order": "up"}`, wantErr: "$.order must be one of [asc desc]"},
+		{name: "invalid item", json: `{"query": "foo", "items": [{"name": true}]}`, wantErr: "$.items[0].name must be of type string"},
+		{name: "no object", json: `"foo"`, wantErr: "$ must be of type object, got string"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := schema.ValidateJSON([]byte(tc.json))
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, ErrValidation)
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
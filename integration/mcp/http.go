@@ -0,0 +1,203 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Compile time check to ensure HTTPTransport satisfies the Transport interface.
+var _ Transport = (*HTTPTransport)(nil)
+
+// sessionIDHeader is the header of the session id assigned by the server.
+const sessionIDHeader = "Mcp-Session-Id"
+
+// HTTPClient is an interface for making HTTP requests.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPTransportOptions contains options for the streamable HTTP transport.
+type HTTPTransportOptions struct {
+	// HTTPClient is the client used to send the requests.
+	HTTPClient HTTPClient
+	// Headers are added to every request, e.g. for authorization.
+	Headers map[string]string
+}
+
+// HTTPTransport is a transport that sends JSON-RPC messages to the endpoint of an MCP server
+// using the streamable HTTP transport. Responses are either JSON documents or server-sent event streams.
+type HTTPTransport struct {
+	endpoint  string
+	mu        sync.RWMutex
+	sessionID string
+	opts      HTTPTransportOptions
+}
+
+// NewHTTPTransport creates a new streamable HTTP transport for the given MCP endpoint.
+func NewHTTPTransport(endpoint string, optFns ...func(o *HTTPTransportOptions)) *HTTPTransport {
+	opts := HTTPTransportOptions{
+		HTTPClient: http.DefaultClient,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &HTTPTransport{
+		endpoint: endpoint,
+		opts:     opts,
+	}
+}
+
+// Send posts the message to the endpoint. For requests, it returns the response of the server.
+func (t *HTTPTransport) Send(ctx context.Context, msg *Message) (*Message, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := t.newRequest(ctx, http.MethodPost, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+
+	res, err := t.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		resBody, _ := io.ReadAll(res.Body)
+		return nil, fmt.Errorf("mcp request failed with status code %d: %s", res.StatusCode, resBody)
+	}
+
+	if sessionID := res.Header.Get(sessionIDHeader); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+
+	if len(msg.ID) == 0 {
+		return nil, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return readEventStream(res.Body, msg.ID)
+	}
+
+	resp := &Message{}
+	if err := json.NewDecoder(res.Body).Decode(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Close terminates the session on the server, if the server assigned a session id.
+func (t *HTTPTransport) Close() error {
+	t.mu.RLock()
+	sessionID := t.sessionID
+	t.mu.RUnlock()
+
+	if sessionID == "" {
+		return nil
+	}
+
+	httpReq, err := t.newRequest(context.Background(), http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := t.opts.HTTPClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	return res.Body.Close()
+}
+
+// newRequest creates a request to the endpoint with the configured headers and the session id.
+func (t *HTTPTransport) newRequest(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, t.endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range t.opts.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	t.mu.RLock()
+	if t.sessionID != "" {
+		httpReq.Header.Set(sessionIDHeader, t.sessionID)
+	}
+	t.mu.RUnlock()
+
+	return httpReq, nil
+}
+
+// readEventStream reads server-sent events until the response with the given id is received.
+func readEventStream(r io.Reader, id json.RawMessage) (*Message, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	data := []string{}
+
+	// dispatch returns the response of the current event, if it has the given id.
+	dispatch := func() *Message {
+		defer func() { data = data[:0] }()
+
+		msg := &Message{}
+		if err := json.Unmarshal([]byte(strings.Join(data, "\n")), msg); err != nil {
+			return nil
+		}
+
+		if msg.IsRequest() || msg.IsNotification() || string(msg.ID) != string(id) {
+			return nil
+		}
+
+		return msg
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+			continue
+		}
+
+		// An empty line dispatches the event.
+		if line == "" && len(data) > 0 {
+			if msg := dispatch(); msg != nil {
+				return msg, nil
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 {
+		if msg := dispatch(); msg != nil {
+			return msg, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: event stream ended without response", ErrTransportClosed)
+}
@@ -0,0 +1,318 @@
+// Package mcp provides a client for the Model Context Protocol (MCP). The client communicates with
+// MCP servers over JSON-RPC 2.0, either with a server process over stdio or with a remote server
+// over streamable HTTP.
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LatestProtocolVersion is the latest MCP protocol version supported by the client.
+const LatestProtocolVersion = "2025-03-26"
+
+// JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+var (
+	ErrTransportClosed = errors.New("mcp transport closed")
+	ErrNotInitialized  = errors.New("mcp client not initialized")
+	ErrToolExecution   = errors.New("mcp tool execution failed")
+)
+
+// Message is a JSON-RPC 2.0 message. A message with method and id is a request, a message with
+// method and without id is a notification, and a message with result or error is a response.
+type Message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// IsRequest reports whether the message is a request.
+func (m *Message) IsRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// IsNotification reports whether the message is a notification.
+func (m *Message) IsNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+// RPCError is a JSON-RPC 2.0 error.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error returns the error message.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("mcp error %d: %s", e.Code, e.Message)
+}
+
+// Implementation describes the name and version of an MCP client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// InitializeResult is the result of the initialization of a session.
+type InitializeResult struct {
+	ProtocolVersion string         `json:"protocolVersion"`
+	Capabilities    map[string]any `json:"capabilities"`
+	ServerInfo      Implementation `json:"serverInfo"`
+	Instructions    string         `json:"instructions,omitempty"`
+}
+
+// Tool describes a tool exposed by an MCP server.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// ListToolsResult is the result of listing the tools of a server.
+type ListToolsResult struct {
+	Tools      []Tool `json:"tools"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ResourceTemplate describes a parameterized resource of an MCP server using a URI template.
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ReadResourceResult is the result of reading a resource.
+type ReadResourceResult struct {
+	Contents []ResourceContent `json:"contents"`
+}
+
+// Content is a content item of a tool result.
+type Content struct {
+	Type     string           `json:"type"`
+	Text     string           `json:"text,omitempty"`
+	Data     string           `json:"data,omitempty"`
+	MimeType string           `json:"mimeType,omitempty"`
+	Resource *ResourceContent `json:"resource,omitempty"`
+}
+
+// ResourceContent is the content of an embedded resource.
+type ResourceContent struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Blob     string `json:"blob,omitempty"`
+}
+
+// CallToolResult is the result of a tool call.
+type CallToolResult struct {
+	Content []Content `json:"content"`
+	IsError bool      `json:"isError,omitempty"`
+}
+
+// Text returns the text of all content items. Binary content is represented by its type.
+func (r *CallToolResult) Text() string {
+	parts := make([]string, 0, len(r.Content))
+
+	for _, c := range r.Content {
+		switch {
+		case c.Type == "text":
+			parts = append(parts, c.Text)
+		case c.Type == "resource" && c.Resource != nil && c.Resource.Text != "":
+			parts = append(parts, c.Resource.Text)
+		case c.Type == "resource" && c.Resource != nil:
+			parts = append(parts, fmt.Sprintf("[resource: %s]", c.Resource.URI))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s: %s]", c.Type, c.MimeType))
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// Transport sends JSON-RPC messages to an MCP server.
+type Transport interface {
+	// Send sends the message to the server. For requests, it returns the response of the server.
+	// For notifications, it returns nil.
+	Send(ctx context.Context, msg *Message) (*Message, error)
+	// Close closes the transport.
+	Close() error
+}
+
+// Options contains options for the MCP client.
+type Options struct {
+	// ClientInfo is the name and version of the client sent to the server.
+	ClientInfo Implementation
+	// ProtocolVersion is the protocol version requested by the client.
+	ProtocolVersion string
+}
+
+// Client is an MCP client.
+type Client struct {
+	transport   Transport
+	nextID      atomic.Int64
+	mu          sync.Mutex
+	initialized *InitializeResult
+	opts        Options
+}
+
+// New creates a new MCP client using the given transport. The session must be initialized with
+// Initialize before other requests are sent.
+func New(transport Transport, optFns ...func(o *Options)) *Client {
+	opts := Options{
+		ClientInfo: Implementation{
+			Name:    "golc",
+			Version: "1.0.0",
+		},
+		ProtocolVersion: LatestProtocolVersion,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Client{
+		transport: transport,
+		opts:      opts,
+	}
+}
+
+// Initialize initializes the session with the server. Subsequent calls return the result of the first call.
+func (c *Client) Initialize(ctx context.Context) (*InitializeResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.initialized != nil {
+		return c.initialized, nil
+	}
+
+	result := InitializeResult{}
+	if err := c.request(ctx, "initialize", map[string]any{
+		"protocolVersion": c.opts.ProtocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      c.opts.ClientInfo,
+	}, &result); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.transport.Send(ctx, &Message{
+		JSONRPC: "2.0",
+		Method:  "notifications/initialized",
+	}); err != nil {
+		return nil, err
+	}
+
+	c.initialized = &result
+
+	return c.initialized, nil
+}
+
+// ListTools returns all tools of the server.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	if err := c.checkInitialized(); err != nil {
+		return nil, err
+	}
+
+	tools := []Tool{}
+	cursor := ""
+
+	for {
+		params := map[string]any{}
+		if cursor != "" {
+			params["cursor"] = cursor
+		}
+
+		result := ListToolsResult{}
+
+		if err := c.request(ctx, "tools/list", params, &result); err != nil {
+			return nil, err
+		}
+
+		tools = append(tools, result.Tools...)
+
+		if result.NextCursor == "" {
+			return tools, nil
+		}
+
+		cursor = result.NextCursor
+	}
+}
+
+// CallTool calls the tool with the given name and JSON arguments.
+func (c *Client) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*CallToolResult, error) {
+	if err := c.checkInitialized(); err != nil {
+		return nil, err
+	}
+
+	if len(arguments) == 0 {
+		arguments = json.RawMessage("{}")
+	}
+
+	result := CallToolResult{}
+	if err := c.request(ctx, "tools/call", map[string]any{
+		"name":      name,
+		"arguments": arguments,
+	}, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Close closes the transport of the client.
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+// checkInitialized returns ErrNotInitialized if the session has not been initialized.
+func (c *Client) checkInitialized() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.initialized == nil {
+		return ErrNotInitialized
+	}
+
+	return nil
+}
+
+// request sends a request with the given method and params and unmarshals the result.
+func (c *Client) request(ctx context.Context, method string, params any, result any) error {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.transport.Send(ctx, &Message{
+		JSONRPC: "2.0",
+		ID:      json.RawMessage(fmt.Sprintf("%d", c.nextID.Add(1))),
+		Method:  method,
+		Params:  rawParams,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	return json.Unmarshal(resp.Result, result)
+}
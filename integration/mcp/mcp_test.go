@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	methods := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			assert.Equal(t, "session-1", r.Header.Get(sessionIDHeader))
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		msg := Message{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+
+		methods = append(methods, msg.Method)
+
+		if msg.Method != "initialize" {
+			assert.Equal(t, "session-1", r.Header.Get(sessionIDHeader))
+		}
+
+		switch msg.Method {
+		case "initialize":
+			w.Header().Set(sessionIDHeader, "session-1")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"protocolVersion":"2025-03-26","capabilities":{},"serverInfo":{"name":"stub","version":"0.1.0"}}}`, msg.ID)
+		case "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+		case "tools/list":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"tools":[{"name":"echo","inputSchema":{"type":"object"}}]}}`, msg.ID)
+		case "tools/call":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":%s,\"result\":{\"content\":[{\"type\":\"text\",\"text\":\"hello\"},{\"type\":\"image\",\"mimeType\":\"image/png\"}]}}\n\n", msg.ID)
+		default:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32601,"message":"method not found"}}`, msg.ID)
+		}
+	}))
+	defer server.Close()
+
+	client := New(NewHTTPTransport(server.URL))
+
+	t.Run("Not initialized", func(t *testing.T) {
+		_, err := client.ListTools(context.Background())
+		require.ErrorIs(t, err, ErrNotInitialized)
+	})
+
+	t.Run("Initialize", func(t *testing.T) {
+		result, err := client.Initialize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "stub", result.ServerInfo.Name)
+	})
+
+	t.Run("List tools", func(t *testing.T) {
+		tools, err := client.ListTools(context.Background())
+		require.NoError(t, err)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "echo", tools[0].Name)
+	})
+
+	t.Run("Call tool", func(t *testing.T) {
+		result, err := client.CallTool(context.Background(), "echo", nil)
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, "hello\n[image: image/png]", result.Text())
+	})
+
+	require.NoError(t, client.Close())
+	assert.Equal(t, []string{"initialize", "notifications/initialized", "tools/list", "tools/call"}, methods)
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// Compile time check to ensure StdioTransport satisfies the Transport interface.
+var _ Transport = (*StdioTransport)(nil)
+
+// StdioTransportOptions contains options for the stdio transport.
+type StdioTransportOptions struct {
+	// Env is the environment of the server process. The environment of the current process is used if nil.
+	Env []string
+	// Dir is the working directory of the server process.
+	Dir string
+	// Stderr receives the log output of the server process. It is discarded if nil.
+	Stderr io.Writer
+	// CloseTimeout is the time to wait for the server process to exit after closing its stdin,
+	// before it is killed.
+	CloseTimeout time.Duration
+}
+
+// StdioTransport is a transport that starts an MCP server as child process and exchanges
+// newline delimited JSON-RPC messages over its stdin and stdout.
+type StdioTransport struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	writeMu   sync.Mutex
+	pendingMu sync.Mutex
+	pending   map[string]chan *Message
+	done      chan struct{}
+	closeOnce sync.Once
+	opts      StdioTransportOptions
+}
+
+// NewStdioTransport starts the given command as MCP server process.
+func NewStdioTransport(command string, args []string, optFns ...func(o *StdioTransportOptions)) (*StdioTransport, error) {
+	opts := StdioTransportOptions{
+		CloseTimeout: 5 * time.Second,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	cmd := exec.Command(command, args...)
+	cmd.Env = opts.Env
+	cmd.Dir = opts.Dir
+	cmd.Stderr = opts.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	t := &StdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[string]chan *Message),
+		done:    make(chan struct{}),
+		opts:    opts,
+	}
+
+	go t.readLoop(stdout)
+
+	return t, nil
+}
+
+// Send sends the message to the server process. For requests, it waits for the response of the server.
+func (t *StdioTransport) Send(ctx context.Context, msg *Message) (*Message, error) {
+	var ch chan *Message
+
+	if len(msg.ID) > 0 {
+		ch = make(chan *Message, 1)
+
+		t.pendingMu.Lock()
+		t.pending[string(msg.ID)] = ch
+		t.pendingMu.Unlock()
+
+		defer func() {
+			t.pendingMu.Lock()
+			delete(t.pending, string(msg.ID))
+			t.pendingMu.Unlock()
+		}()
+	}
+
+	if err := t.write(msg); err != nil {
+		return nil, err
+	}
+
+	if ch == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp := <-ch:
+		return resp, nil
+	case <-t.done:
+		return nil, ErrTransportClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close closes the stdin of the server process and waits for the process to exit. The process is
+// killed if it does not exit within the close timeout.
+func (t *StdioTransport) Close() error {
+	var err error
+
+	t.closeOnce.Do(func() {
+		_ = t.stdin.Close()
+
+		select {
+		case <-t.done:
+		case <-time.After(t.opts.CloseTimeout):
+			_ = t.cmd.Process.Kill()
+			<-t.done
+		}
+
+		err = t.cmd.Wait()
+	})
+
+	return err
+}
+
+// write writes the message as a single line to the stdin of the server process.
+func (t *StdioTransport) write(msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	t.writeMu.Lock()
+	defer t.writeMu.Unlock()
+
+	select {
+	case <-t.done:
+		return ErrTransportClosed
+	default:
+	}
+
+	_, err = t.stdin.Write(append(data, '\n'))
+
+	return err
+}
+
+// readLoop reads the messages of the server process until its stdout is closed.
+func (t *StdioTransport) readLoop(stdout io.Reader) {
+	defer close(t.done)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		msg := &Message{}
+		if err := json.Unmarshal(scanner.Bytes(), msg); err != nil {
+			// Ignore lines that are not JSON-RPC messages.
+			continue
+		}
+
+		switch {
+		case msg.IsRequest():
+			go t.handleRequest(msg)
+		case msg.IsNotification():
+			// Notifications of the server are not supported.
+		default:
+			t.pendingMu.Lock()
+			ch, ok := t.pending[string(msg.ID)]
+			t.pendingMu.Unlock()
+
+			if ok {
+				ch <- msg
+			}
+		}
+	}
+}
+
+// handleRequest answers requests of the server. Only ping requests are supported.
+func (t *StdioTransport) handleRequest(msg *Message) {
+	resp := &Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+	}
+
+	if msg.Method == "ping" {
+		resp.Result = json.RawMessage("{}")
+	} else {
+		resp.Error = &RPCError{Code: CodeMethodNotFound, Message: "method not found"}
+	}
+
+	_ = t.write(resp)
+}
@@ -90,10 +90,31 @@ type GenerationStream struct {
 	*stream.Stream[GenerationResponse]
 }
 
+type ToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type ToolCall struct {
+	Function ToolCallFunction `json:"function"`
+}
+
+type ToolFunction struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters"`
+}
+
+type Tool struct {
+	Type     string       `json:"type"` // always "function"
+	Function ToolFunction `json:"function"`
+}
+
 type Message struct {
-	Role    string      `json:"role"` // one of ["system", "user", "assistant"]
-	Content string      `json:"content"`
-	Images  []ImageData `json:"images,omitempty"`
+	Role      string      `json:"role"` // one of ["system", "user", "assistant", "tool"]
+	Content   string      `json:"content"`
+	Images    []ImageData `json:"images,omitempty"`
+	ToolCalls []ToolCall  `json:"tool_calls,omitempty"`
 }
 
 type ChatRequest struct {
@@ -101,6 +122,7 @@ type ChatRequest struct {
 	Messages []Message `json:"messages"`
 	Stream   *bool     `json:"stream,omitempty"`
 	Format   string    `json:"format"`
+	Tools    []Tool    `json:"tools,omitempty"`
 
 	Options Options `json:"options"`
 }
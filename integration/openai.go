@@ -18,13 +18,27 @@ func ToOpenAIChatCompletionMessages(messages schema.ChatMessages) ([]openai.Chat
 			return nil, err
 		}
 
-		if functionMessage, ok := message.(*schema.FunctionChatMessage); ok {
+		switch m := message.(type) {
+		case *schema.FunctionChatMessage:
 			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
 				Role:    role,
-				Content: functionMessage.Content(),
-				Name:    functionMessage.Name(),
+				Content: m.Content(),
+				Name:    m.Name(),
 			})
-		} else {
+		case *schema.ToolChatMessage:
+			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
+				Role:       role,
+				Content:    m.Content(),
+				Name:       m.Name(),
+				ToolCallID: m.ToolCallID(),
+			})
+		case *schema.AIChatMessage:
+			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
+				Role:      role,
+				Content:   m.Content(),
+				ToolCalls: ToOpenAIToolCalls(m.Extension().ToolCalls),
+			})
+		default:
 			openAIMessages = append(openAIMessages, openai.ChatCompletionMessage{
 				Role:    role,
 				Content: message.Content(),
@@ -35,6 +49,43 @@ func ToOpenAIChatCompletionMessages(messages schema.ChatMessages) ([]openai.Chat
 	return openAIMessages, nil
 }
 
+// ToOpenAIToolCalls converts a slice of schema.ToolCall to a slice of openai.ToolCall.
+func ToOpenAIToolCalls(toolCalls []schema.ToolCall) []openai.ToolCall {
+	if len(toolCalls) == 0 {
+		return nil
+	}
+
+	openAIToolCalls := make([]openai.ToolCall, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		openAIToolCalls[i] = openai.ToolCall{
+			ID:   tc.ID,
+			Type: openai.ToolTypeFunction,
+			Function: openai.FunctionCall{
+				Name:      tc.Name,
+				Arguments: tc.Arguments,
+			},
+		}
+	}
+
+	return openAIToolCalls
+}
+
+// ToOpenAIToolChoice converts a tool choice of the schema.GenerateOptions to the OpenAI tool choice.
+// It returns nil for an empty tool choice.
+func ToOpenAIToolChoice(toolChoice string) any {
+	switch toolChoice {
+	case "":
+		return nil
+	case schema.ToolChoiceAuto, schema.ToolChoiceNone, schema.ToolChoiceRequired:
+		return toolChoice
+	default:
+		return openai.ToolChoice{Type: openai.ToolTypeFunction, Function: openai.ToolFunction{
+			Name: toolChoice,
+		}}
+	}
+}
+
 // messageTypeToOpenAIRole converts a schema.ChatMessageType to the corresponding OpenAI role string.
 func messageTypeToOpenAIRole(mType schema.ChatMessageType) (string, error) {
 	switch mType { // nolint exhaustive
@@ -46,6 +97,8 @@ func messageTypeToOpenAIRole(mType schema.ChatMessageType) (string, error) {
 		return "user", nil
 	case schema.ChatMessageTypeFunction:
 		return "function", nil
+	case schema.ChatMessageTypeTool:
+		return "tool", nil
 	default:
 		return "", fmt.Errorf("unknown message type: %s", mType)
 	}
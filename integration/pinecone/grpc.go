@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/tls"
 
-	"github.com/google/uuid"
 	pc "github.com/pinecone-io/go-pinecone/pinecone_grpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -52,7 +51,7 @@ func (p *GRPCClient) Upsert(ctx context.Context, req *UpsertRequest) (*UpsertRes
 		pineconeVectors = append(
 			pineconeVectors,
 			&pc.Vector{
-				Id:       uuid.New().String(),
+				Id:       req.Vectors[i].ID,
 				Values:   req.Vectors[i].Values,
 				Metadata: metadataStruct,
 			},
@@ -93,7 +92,7 @@ func (p *GRPCClient) Fetch(ctx context.Context, req *FetchRequest) (*FetchRespon
 	}
 
 	return &FetchResponse{
-		Vectors:   nil,
+		Vectors:   vectors,
 		Namespace: pcRes.Namespace,
 	}, nil
 }
@@ -136,6 +135,20 @@ func (p *GRPCClient) Query(ctx context.Context, req *QueryRequest) (*QueryRespon
 	}, nil
 }
 
+func (p *GRPCClient) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, "api-key", p.apiKey)
+
+	if _, err := p.client.Delete(ctx, &pc.DeleteRequest{
+		Ids:       req.IDs,
+		DeleteAll: req.DeleteAll,
+		Namespace: req.Namespace,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &DeleteResponse{}, nil
+}
+
 func (p *GRPCClient) Close() error {
 	return p.conn.Close()
 }
@@ -111,6 +111,32 @@ func (p *RestClient) Query(ctx context.Context, req *QueryRequest) (*QueryRespon
 	return &queryResponse, nil
 }
 
+func (p *RestClient) Delete(ctx context.Context, req *DeleteRequest) (*DeleteResponse, error) {
+	reqURL := fmt.Sprintf("https://%s/vectors/delete", p.target)
+
+	res, err := p.doRequest(ctx, http.MethodPost, reqURL, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != 200 {
+		errorResponse := ErrorResponse{}
+		if err := json.Unmarshal(body, &errorResponse); err != nil {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("pinecone error: %s", errorResponse.Message)
+	}
+
+	return &DeleteResponse{}, nil
+}
+
 func (p *RestClient) Close() error {
 	return nil
 }
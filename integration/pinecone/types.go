@@ -31,6 +31,17 @@ type FetchResponse struct {
 	Namespace string             `json:"namespace"`
 }
 
+// DeleteRequest represents the parameters for a delete vectors request.
+// See https://docs.pinecone.io/reference/delete_post for more informations.
+type DeleteRequest struct {
+	IDs       []string `json:"ids,omitempty"`
+	DeleteAll bool     `json:"deleteAll,omitempty"`
+	Namespace string   `json:"namespace"`
+}
+
+// DeleteResponse represents the response from a delete vectors request.
+type DeleteResponse struct{}
+
 // QueryRequest represents the parameters for a query request.
 // See https://docs.pinecone.io/reference/query for more information.
 type QueryRequest struct {
@@ -1,7 +1,7 @@
 package sqldb
 
-// Compile time check to ensure CockroachDB satisfies the Engine interface.
-var _ Engine = (*CockroachDB)(nil)
+// Compile time check to ensure CockroachDB satisfies the TxEngine interface.
+var _ TxEngine = (*CockroachDB)(nil)
 
 // CockroachDBOptions holds options for the CockroachDB database engine.
 type CockroachDBOptions struct {
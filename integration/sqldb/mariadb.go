@@ -1,7 +1,7 @@
 package sqldb
 
-// Compile time check to ensure MariaDB satisfies the Engine interface.
-var _ Engine = (*MariaDB)(nil)
+// Compile time check to ensure MariaDB satisfies the TxEngine interface.
+var _ TxEngine = (*MariaDB)(nil)
 
 // MariaDBOptions holds options for the MariaDB database engine.
 type MariaDBOptions struct {
@@ -8,8 +8,8 @@ import (
 	"ariga.io/atlas/sql/mysql"
 )
 
-// Compile time check to ensure MySQL satisfies the Engine interface.
-var _ Engine = (*MySQL)(nil)
+// Compile time check to ensure MySQL satisfies the TxEngine interface.
+var _ TxEngine = (*MySQL)(nil)
 
 // MySQLOptions holds options for the MySQL database engine.
 type MySQLOptions struct {
@@ -70,6 +70,11 @@ func (e *MySQL) QueryRow(ctx context.Context, query string, args ...any) *sql.Ro
 	return e.db.QueryRowContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *MySQL) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // SampleRowsQuery returns the query to retrieve a sample of rows from the specified table (table) with a limit of (k) rows.
 func (e *MySQL) SampleRowsQuery(table string, k uint) string {
 	return fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, k)
@@ -6,6 +6,25 @@ import (
 	"unicode"
 )
 
+var (
+	// literalRegexp matches quoted string literals and identifiers, quotes are escaped by doubling them.
+	literalRegexp = regexp.MustCompile("'(?:[^']|'')*'|\"(?:[^\"]|\"\")*\"|`(?:[^`]|``)*`")
+	// wordRegexp matches the words of a lowered query.
+	wordRegexp = regexp.MustCompile(`[a-z_]+`)
+	// lockingClauseRegexp matches the locking clauses of a SELECT statement, e.g. FOR SHARE.
+	lockingClauseRegexp = regexp.MustCompile(`\b(for\s+(key\s+)?share|lock\s+in\s+share\s+mode)\b`)
+)
+
+// writeKeywords are keywords of statements and clauses that modify the database, e.g. in a data
+// modifying common table expression or in SELECT INTO.
+var writeKeywords = map[string]bool{
+	"insert": true,
+	"update": true,
+	"delete": true,
+	"merge":  true,
+	"into":   true,
+}
+
 // CleanQuery cleans sql query from double white space, comments and leading/trailing spaces.
 func CleanQuery(query string) string {
 	// remove comments
@@ -35,7 +54,41 @@ func NewParser(query string) *Parser {
 }
 
 func (p *Parser) IsSelect() bool {
-	return p.lowered[:strings.IndexRune(p.lowered, ' ')] == "select"
+	firstSyntax, _, _ := strings.Cut(p.lowered, " ")
+
+	return firstSyntax == "select"
+}
+
+// IsReadOnly reports whether the query is a single SELECT statement, optionally with common table
+// expressions and a trailing semicolon. The check is best-effort: string literals and quoted
+// identifiers are ignored, and queries containing keywords that modify the database are rejected,
+// e.g. SELECT INTO or SELECT FOR UPDATE.
+func (p *Parser) IsReadOnly() bool {
+	stripped := strings.ToLower(literalRegexp.ReplaceAllString(p.query, "''"))
+	stripped = strings.TrimSuffix(strings.TrimSpace(stripped), ";")
+
+	if strings.Contains(stripped, ";") {
+		return false
+	}
+
+	words := wordRegexp.FindAllString(stripped, -1)
+	if len(words) == 0 || (words[0] != "select" && words[0] != "with") {
+		return false
+	}
+
+	hasSelect := false
+
+	for _, word := range words {
+		if writeKeywords[word] {
+			return false
+		}
+
+		if word == "select" {
+			hasSelect = true
+		}
+	}
+
+	return hasSelect && !lockingClauseRegexp.MatchString(stripped)
 }
 
 func (p *Parser) TableNames() []string {
@@ -18,6 +18,8 @@ func TestParser(t *testing.T) {
 			{`UPDATE Customers
 			SET ContactName = 'Alfred Schmidt', City= 'Frankfurt'
 			WHERE CustomerID = 1;`, false},
+			{"VACUUM", false},
+			{"", false},
 		}
 
 		for _, tt := range testcase {
@@ -25,6 +27,33 @@ func TestParser(t *testing.T) {
 			require.Equal(t, tt.isSelect, p.IsSelect())
 		}
 	})
+	t.Run("TestIsReadOnly", func(t *testing.T) {
+		testcase := []struct {
+			query      string
+			isReadOnly bool
+		}{
+			{"SELECT * FROM table", true},
+			{"select id from table;", true},
+			{"WITH named AS (SELECT id FROM table WHERE name = 'a') SELECT COUNT(*) FROM named", true},
+			{"SELECT * FROM table WHERE name = 'a;b' OR name = 'it''s; into'", true},
+			{`SELECT "update" FROM table`, true},
+			{"SELECT id FROM table; DROP TABLE table", false},
+			{"SELECT * INTO backup FROM table", false},
+			{"SELECT id FROM table FOR UPDATE", false},
+			{"SELECT id FROM table FOR SHARE", false},
+			{"SELECT id FROM table LOCK IN SHARE MODE", false},
+			{"WITH deleted AS (DELETE FROM table RETURNING id) SELECT id FROM deleted", false},
+			{"WITH named AS (VALUES (1)) TABLE named", false},
+			{"DELETE FROM table", false},
+			{"VACUUM", false},
+			{"", false},
+		}
+
+		for _, tt := range testcase {
+			p := NewParser(tt.query)
+			require.Equal(t, tt.isReadOnly, p.IsReadOnly(), tt.query)
+		}
+	})
 	t.Run("TestTableNames", func(t *testing.T) {
 		testcase := []struct {
 			query      string
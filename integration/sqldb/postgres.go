@@ -8,8 +8,8 @@ import (
 	"ariga.io/atlas/sql/postgres"
 )
 
-// Compile time check to ensure Postgres satisfies the Engine interface.
-var _ Engine = (*Postgres)(nil)
+// Compile time check to ensure Postgres satisfies the TxEngine interface.
+var _ TxEngine = (*Postgres)(nil)
 
 // PostgresOptions holds options for the Postgres database engine.
 type PostgresOptions struct {
@@ -70,6 +70,11 @@ func (e *Postgres) QueryRow(ctx context.Context, query string, args ...any) *sql
 	return e.db.QueryRowContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *Postgres) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // SampleRowsQuery returns the query to retrieve a sample of rows from the specified table (table) with a limit of (k) rows.
 func (e *Postgres) SampleRowsQuery(table string, k uint) string {
 	return fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, k)
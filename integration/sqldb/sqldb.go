@@ -4,13 +4,18 @@ package sqldb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"ariga.io/atlas/sql/migrate"
 	"ariga.io/atlas/sql/schema"
 )
 
+// ErrTableNotFound is returned if a requested table does not exist or is not usable.
+var ErrTableNotFound = errors.New("table not found")
+
 // Engine defines the interface for an SQL database engine.
 type Engine interface {
 	// Dialect returns the dialect of the SQL database engine.
@@ -36,6 +41,14 @@ type Engine interface {
 	Close() error
 }
 
+// TxEngine defines the interface for an SQL database engine that supports transactions.
+type TxEngine interface {
+	Engine
+
+	// BeginTx starts a transaction with the provided options (opts).
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
 // SQLDBOptions holds options for the SQLDB.
 type SQLDBOptions struct {
 	Schema                string
@@ -71,19 +84,42 @@ func (db *SQLDB) Dialect() string {
 	return db.engine.Dialect()
 }
 
-// TableInfo retrieves information about the tables in the database.
-func (db *SQLDB) TableInfo(ctx context.Context) (string, error) {
-	createStmts, err := db.engine.Inspect(ctx, db.opts.Schema, &schema.InspectOptions{
-		Tables:  db.opts.Tables,
-		Exclude: db.opts.Exclude,
-	})
+// TableNames returns the sorted names of the usable tables in the database.
+func (db *SQLDB) TableNames(ctx context.Context) ([]string, error) {
+	createStmts, err := db.inspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedKeys(createStmts), nil
+}
+
+// TableInfo retrieves information about the tables in the database. If table names are given,
+// only these tables are described.
+func (db *SQLDB) TableInfo(ctx context.Context, tables ...string) (string, error) {
+	createStmts, err := db.inspect(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	names := sortedKeys(createStmts)
+
+	if len(tables) > 0 {
+		names = make([]string, 0, len(tables))
+
+		for _, t := range tables {
+			name, ok := lookupTable(createStmts, t)
+			if !ok {
+				return "", fmt.Errorf("%w: %s", ErrTableNotFound, t)
+			}
+
+			names = append(names, name)
+		}
+	}
+
 	info := ""
-	for k, v := range createStmts {
-		info += fmt.Sprintf("%s\n\n", v)
+	for _, k := range names {
+		info += fmt.Sprintf("%s\n\n", createStmts[k])
 
 		if db.opts.SampleRowsinTableInfo > 0 {
 			sampleRows, err := db.sampleRows(ctx, k, db.opts.SampleRowsinTableInfo)
@@ -102,6 +138,8 @@ func (db *SQLDB) TableInfo(ctx context.Context) (string, error) {
 type QueryResult struct {
 	Columns []string
 	Rows    [][]string
+	// Truncated is true if rows were omitted because of a row limit.
+	Truncated bool
 }
 
 // String returns the string representation of the QueryResult.
@@ -121,20 +159,66 @@ func (db *SQLDB) Query(ctx context.Context, query string, args ...any) (*QueryRe
 		return nil, err
 	}
 
-	if rErr := rows.Err(); rErr != nil {
-		return nil, rErr
+	return scanRows(rows, 0)
+}
+
+// QueryReadOnly executes an SQL query in a read-only transaction and returns at most maxRows
+// rows of the result, or all rows if maxRows is zero. Further rows are not read from the database.
+// The transaction is always rolled back.
+//
+// Whether writes are rejected in a read-only transaction depends on the database and its
+// driver, e.g. Postgres and MySQL reject them, while the sqlite3 driver ignores the option.
+// Engines that do not implement TxEngine execute the query without a transaction.
+func (db *SQLDB) QueryReadOnly(ctx context.Context, query string, maxRows uint, args ...any) (*QueryResult, error) {
+	txEngine, ok := db.engine.(TxEngine)
+	if !ok {
+		rows, err := db.engine.Query(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+
+		return scanRows(rows, maxRows)
+	}
+
+	tx, err := txEngine.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
 	}
 
+	defer func() {
+		_ = tx.Rollback() // the transaction only reads, so there is nothing to commit
+	}()
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRows(rows, maxRows)
+}
+
+// scanRows reads at most maxRows rows, or all rows if maxRows is zero, and closes the rows.
+func scanRows(rows *sql.Rows, maxRows uint) (*QueryResult, error) {
 	defer rows.Close()
 
+	if rErr := rows.Err(); rErr != nil {
+		return nil, rErr
+	}
+
 	cols, err := rows.Columns()
 	if err != nil {
 		return nil, err
 	}
 
 	results := make([][]string, 0)
+	truncated := false
 
 	for rows.Next() {
+		if maxRows > 0 && uint(len(results)) == maxRows {
+			truncated = true
+			break
+		}
+
 		row := make([]string, len(cols))
 		rowNullable := make([]sql.NullString, len(cols))
 		rowPtrs := make([]any, len(cols))
@@ -157,9 +241,14 @@ func (db *SQLDB) Query(ctx context.Context, query string, args ...any) (*QueryRe
 		results = append(results, row)
 	}
 
+	if rErr := rows.Err(); rErr != nil {
+		return nil, rErr
+	}
+
 	return &QueryResult{
-		Columns: cols,
-		Rows:    results,
+		Columns:   cols,
+		Rows:      results,
+		Truncated: truncated,
 	}, nil
 }
 
@@ -179,11 +268,48 @@ func (db *SQLDB) sampleRows(ctx context.Context, table string, k uint) (string,
 	return ret, nil
 }
 
+// inspect returns the CREATE TABLE statements of the usable tables by table name.
+func (db *SQLDB) inspect(ctx context.Context) (map[string]string, error) {
+	return db.engine.Inspect(ctx, db.opts.Schema, &schema.InspectOptions{
+		Tables:  db.opts.Tables,
+		Exclude: db.opts.Exclude,
+	})
+}
+
 // Close closes the database connection.
 func (db *SQLDB) Close() error {
 	return db.engine.Close()
 }
 
+// lookupTable returns the name of the given table, ignoring the case of the name.
+func lookupTable(createStmts map[string]string, table string) (string, bool) {
+	table = strings.TrimSpace(table)
+
+	if _, ok := createStmts[table]; ok {
+		return table, true
+	}
+
+	for name := range createStmts {
+		if strings.EqualFold(name, table) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// sortedKeys returns the sorted keys of the map.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 // atlas represents the atlas migration driver.
 type atlas struct {
 	driver migrate.Driver
@@ -41,6 +41,21 @@ func TestSQLDB(t *testing.T) {
 		require.Equal(t, "CREATE TABLE `example` (`id` int NOT NULL, `foo` text NULL)\n\n/*\n2 rows from example table:\nid\tfoo\n0\tbar\n1\tbar\n*/ \n\n", info)
 	})
 
+	t.Run("TestTableInfo Tables", func(t *testing.T) {
+		info, err := sqldb.TableInfo(context.Background(), "EXAMPLE")
+		require.NoError(t, err)
+		require.Contains(t, info, "CREATE TABLE `example`")
+
+		_, err = sqldb.TableInfo(context.Background(), "unknown")
+		require.ErrorIs(t, err, ErrTableNotFound)
+	})
+
+	t.Run("TestTableNames", func(t *testing.T) {
+		names, err := sqldb.TableNames(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"example"}, names)
+	})
+
 	t.Run("TestDialect", func(t *testing.T) {
 		require.Equal(t, "sqlite3", sqldb.Dialect())
 	})
@@ -51,6 +66,18 @@ func TestSQLDB(t *testing.T) {
 		require.Equal(t, "COUNT(*)\n5\n", result.String())
 	})
 
+	t.Run("TestQueryReadOnly", func(t *testing.T) {
+		result, err := sqldb.QueryReadOnly(context.Background(), "SELECT id FROM example ORDER BY id", 2)
+		require.NoError(t, err)
+		require.Equal(t, "id\n0\n1\n", result.String())
+		require.True(t, result.Truncated)
+
+		result, err = sqldb.QueryReadOnly(context.Background(), "SELECT id FROM example ORDER BY id", 5)
+		require.NoError(t, err)
+		require.Len(t, result.Rows, 5)
+		require.False(t, result.Truncated)
+	})
+
 	t.Run("TestQuery Null", func(t *testing.T) {
 		result, err := sqldb.Query(context.Background(), "SELECT * FROM example where id = 4711")
 		require.NoError(t, err)
@@ -8,8 +8,8 @@ import (
 	"ariga.io/atlas/sql/sqlite"
 )
 
-// Compile time check to ensure SQLite3 satisfies the Engine interface.
-var _ Engine = (*SQLite3)(nil)
+// Compile time check to ensure SQLite3 satisfies the TxEngine interface.
+var _ TxEngine = (*SQLite3)(nil)
 
 // SQLite3Options holds options for the SQLite3 database engine.
 type SQLite3Options struct {
@@ -70,6 +70,11 @@ func (e *SQLite3) QueryRow(ctx context.Context, query string, args ...any) *sql.
 	return e.db.QueryRowContext(ctx, query, args...)
 }
 
+// BeginTx starts a transaction with the provided options (opts).
+func (e *SQLite3) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	return e.db.BeginTx(ctx, opts)
+}
+
 // SampleRowsQuery returns the query to retrieve a sample of rows from the specified table (table) with a limit of (k) rows.
 func (e *SQLite3) SampleRowsQuery(table string, k uint) string {
 	return fmt.Sprintf("SELECT * FROM %s LIMIT %d;", table, k)
@@ -0,0 +1,9 @@
+package mcpserver
+
+import "errors"
+
+var (
+	ErrDuplicateTool   = errors.New("duplicate tool name")
+	ErrUnknownTool     = errors.New("unknown tool")
+	ErrUnknownResource = errors.New("unknown resource")
+)
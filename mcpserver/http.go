@@ -0,0 +1,54 @@
+package mcpserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hupe1980/golc/integration/mcp"
+)
+
+// Compile time check to ensure Server satisfies the http.Handler interface.
+var _ http.Handler = (*Server)(nil)
+
+// ServeHTTP serves the MCP server over the streamable HTTP transport. The server is stateless and
+// answers every request with a JSON document. A request is cancelled when the client closes the connection.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	msg := &mcp.Message{}
+	if err := json.NewDecoder(r.Body).Decode(msg); err != nil {
+		writeJSON(w, http.StatusBadRequest, &mcp.Message{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &mcp.RPCError{Code: mcp.CodeParseError, Message: err.Error()},
+		})
+
+		return
+	}
+
+	if !msg.IsRequest() {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	resp := s.Handle(r.Context(), msg)
+
+	if r.Context().Err() != nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeJSON writes the message as JSON document with the given status code.
+func writeJSON(w http.ResponseWriter, statusCode int, msg *mcp.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(w).Encode(msg)
+}
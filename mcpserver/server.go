@@ -0,0 +1,304 @@
+// Package mcpserver provides a Model Context Protocol (MCP) server that exposes golc tools and
+// retrievers to MCP clients over stdio or streamable HTTP.
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/retriever"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// retrieverScheme is the URI scheme of the retriever resources.
+const retrieverScheme = "retriever"
+
+// supportedProtocolVersions are the MCP protocol versions supported by the server.
+var supportedProtocolVersions = []string{mcp.LatestProtocolVersion, "2024-11-05"}
+
+// Retriever describes a retriever exposed by the server. A retriever is exposed as search tool
+// and as resource template with URIs of the form retriever://<name>/<query>.
+type Retriever struct {
+	Name        string
+	Description string
+	Retriever   schema.Retriever
+}
+
+// Options contains options for the MCP server.
+type Options struct {
+	// ServerInfo is the name and version of the server sent to the clients.
+	ServerInfo mcp.Implementation
+	// Instructions describe how to use the server and are sent to the clients.
+	Instructions string
+	// Retrievers are exposed as search tools and resource templates.
+	Retrievers []Retriever
+	// Callbacks are passed to the tools and retrievers.
+	Callbacks []schema.Callback
+}
+
+// Server is an MCP server that exposes tools and retrievers.
+type Server struct {
+	tools      map[string]schema.Tool
+	toolDefs   []mcp.Tool
+	retrievers map[string]Retriever
+	opts       Options
+}
+
+// New creates a new MCP server for the given tools. The input schemas of the tools are derived from their function definitions.
+func New(tools []schema.Tool, optFns ...func(o *Options)) (*Server, error) {
+	opts := Options{
+		ServerInfo: mcp.Implementation{
+			Name:    "golc",
+			Version: "1.0.0",
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	s := &Server{
+		tools:      make(map[string]schema.Tool),
+		retrievers: make(map[string]Retriever),
+		opts:       opts,
+	}
+
+	for _, r := range opts.Retrievers {
+		description := r.Description
+		if description == "" {
+			description = fmt.Sprintf("Searches %s for documents relevant to the query.", r.Name)
+		}
+
+		tools = append(tools, tool.NewRetriever(r.Retriever, r.Name, description))
+		s.retrievers[r.Name] = r
+	}
+
+	for _, t := range tools {
+		if _, ok := s.tools[t.Name()]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTool, t.Name())
+		}
+
+		function, err := tool.ToFunction(t)
+		if err != nil {
+			return nil, err
+		}
+
+		inputSchema, err := json.Marshal(function.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		s.tools[t.Name()] = t
+		s.toolDefs = append(s.toolDefs, mcp.Tool{
+			Name:        function.Name,
+			Description: function.Description,
+			InputSchema: inputSchema,
+		})
+	}
+
+	return s, nil
+}
+
+// Handle handles a JSON-RPC message of a client and returns the response. It returns nil for
+// notifications and responses of the client.
+func (s *Server) Handle(ctx context.Context, msg *mcp.Message) *mcp.Message {
+	if !msg.IsRequest() {
+		return nil
+	}
+
+	resp := &mcp.Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+	}
+
+	result, err := s.dispatch(ctx, msg)
+	if err != nil {
+		if rpcErr, ok := err.(*mcp.RPCError); ok {
+			resp.Error = rpcErr
+		} else {
+			resp.Error = &mcp.RPCError{Code: mcp.CodeInternalError, Message: err.Error()}
+		}
+
+		return resp
+	}
+
+	rawResult, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &mcp.RPCError{Code: mcp.CodeInternalError, Message: err.Error()}
+		return resp
+	}
+
+	resp.Result = rawResult
+
+	return resp
+}
+
+// dispatch calls the handler of the method of the request.
+func (s *Server) dispatch(ctx context.Context, msg *mcp.Message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(msg.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return mcp.ListToolsResult{Tools: s.toolDefs}, nil
+	case "tools/call":
+		return s.callTool(ctx, msg.Params)
+	case "resources/list":
+		return map[string]any{"resources": []any{}}, nil
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": s.resourceTemplates()}, nil
+	case "resources/read":
+		return s.readResource(ctx, msg.Params)
+	default:
+		return nil, &mcp.RPCError{Code: mcp.CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", msg.Method)}
+	}
+}
+
+// initialize negotiates the protocol version and returns the capabilities of the server.
+func (s *Server) initialize(rawParams json.RawMessage) (*mcp.InitializeResult, error) {
+	params := struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}{}
+
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	protocolVersion := mcp.LatestProtocolVersion
+
+	for _, v := range supportedProtocolVersions {
+		if v == params.ProtocolVersion {
+			protocolVersion = v
+		}
+	}
+
+	capabilities := map[string]any{
+		"tools": map[string]any{},
+	}
+
+	if len(s.retrievers) > 0 {
+		capabilities["resources"] = map[string]any{}
+	}
+
+	return &mcp.InitializeResult{
+		ProtocolVersion: protocolVersion,
+		Capabilities:    capabilities,
+		ServerInfo:      s.opts.ServerInfo,
+		Instructions:    s.opts.Instructions,
+	}, nil
+}
+
+// callTool runs the requested tool. Errors of the tool are returned as tool result, so that the
+// model calling the tool can see them.
+func (s *Server) callTool(ctx context.Context, rawParams json.RawMessage) (*mcp.CallToolResult, error) {
+	params := struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}{}
+
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	t, ok := s.tools[params.Name]
+	if !ok {
+		return nil, &mcp.RPCError{Code: mcp.CodeInvalidParams, Message: fmt.Sprintf("%s: %s", ErrUnknownTool, params.Name)}
+	}
+
+	arguments := string(params.Arguments)
+	if arguments == "" || arguments == "null" {
+		arguments = "{}"
+	}
+
+	output, err := tool.Run(ctx, t, schema.NewToolInputFromArguments(arguments), func(o *tool.Options) {
+		o.Callbacks = s.opts.Callbacks
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{{Type: "text", Text: err.Error()}},
+			IsError: true,
+		}, nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{{Type: "text", Text: output}},
+	}, nil
+}
+
+// resourceTemplates returns a resource template for every retriever.
+func (s *Server) resourceTemplates() []mcp.ResourceTemplate {
+	templates := make([]mcp.ResourceTemplate, 0, len(s.opts.Retrievers))
+
+	for _, r := range s.opts.Retrievers {
+		templates = append(templates, mcp.ResourceTemplate{
+			URITemplate: fmt.Sprintf("%s://%s/{query}", retrieverScheme, r.Name),
+			Name:        r.Name,
+			Description: r.Description,
+			MimeType:    "text/plain",
+		})
+	}
+
+	return templates
+}
+
+// readResource returns the documents of the retriever for the query of the resource URI.
+func (s *Server) readResource(ctx context.Context, rawParams json.RawMessage) (*mcp.ReadResourceResult, error) {
+	params := struct {
+		URI string `json:"uri"`
+	}{}
+
+	if err := unmarshalParams(rawParams, &params); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(params.URI)
+	if err != nil || u.Scheme != retrieverScheme {
+		return nil, &mcp.RPCError{Code: mcp.CodeInvalidParams, Message: fmt.Sprintf("%s: %s", ErrUnknownResource, params.URI)}
+	}
+
+	r, ok := s.retrievers[u.Host]
+	if !ok {
+		return nil, &mcp.RPCError{Code: mcp.CodeInvalidParams, Message: fmt.Sprintf("%s: %s", ErrUnknownResource, params.URI)}
+	}
+
+	docs, err := retriever.Run(ctx, r.Retriever, strings.TrimPrefix(u.Path, "/"), func(o *retriever.Options) {
+		o.Callbacks = s.opts.Callbacks
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]mcp.ResourceContent, len(docs))
+	for i, doc := range docs {
+		contents[i] = mcp.ResourceContent{
+			URI:      fmt.Sprintf("%s#%d", params.URI, i),
+			MimeType: "text/plain",
+			Text:     doc.PageContent,
+		}
+	}
+
+	return &mcp.ReadResourceResult{Contents: contents}, nil
+}
+
+// unmarshalParams unmarshals the params of a request and returns an invalid params error on failure.
+func unmarshalParams(rawParams json.RawMessage, params any) error {
+	if len(rawParams) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(rawParams, params); err != nil {
+		return &mcp.RPCError{Code: mcp.CodeInvalidParams, Message: err.Error()}
+	}
+
+	return nil
+}
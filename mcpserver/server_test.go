@@ -0,0 +1,253 @@
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type echoArgs struct {
+	Text string `json:"text" description:"The text to echo"`
+}
+
+func TestServer(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	client := mcp.New(mcp.NewHTTPTransport(httpServer.URL))
+
+	result, err := client.Initialize(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "golc", result.ServerInfo.Name)
+	assert.Contains(t, result.Capabilities, "resources")
+
+	t.Run("List tools", func(t *testing.T) {
+		tools, err := client.ListTools(context.Background())
+		require.NoError(t, err)
+		require.Len(t, tools, 3)
+		assert.Equal(t, "Echo", tools[0].Name)
+		assert.JSONEq(t, `{"type":"object","properties":{"text":{"type":"string","description":"The text to echo"}},"required":["text"]}`, string(tools[0].InputSchema))
+		assert.Equal(t, "Docs", tools[2].Name)
+	})
+
+	t.Run("Call tool", func(t *testing.T) {
+		result, err := client.CallTool(context.Background(), "Echo", json.RawMessage(`{"text":"hello"}`))
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+		assert.Equal(t, "hello", result.Text())
+	})
+
+	t.Run("Call retriever", func(t *testing.T) {
+		result, err := client.CallTool(context.Background(), "Docs", json.RawMessage(`{"__arg1":"golc"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Document about golc", result.Text())
+	})
+
+	t.Run("Tool error", func(t *testing.T) {
+		result, err := client.CallTool(context.Background(), "Fail", nil)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Equal(t, "tool failed", result.Text())
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		result, err := client.CallTool(context.Background(), "Echo", json.RawMessage(`{}`))
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("Unknown tool", func(t *testing.T) {
+		_, err := client.CallTool(context.Background(), "Unknown", nil)
+
+		rpcErr := &mcp.RPCError{}
+		require.ErrorAs(t, err, &rpcErr)
+		assert.Equal(t, mcp.CodeInvalidParams, rpcErr.Code)
+	})
+
+	t.Run("Read resource", func(t *testing.T) {
+		resp := server.Handle(context.Background(), &mcp.Message{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("1"),
+			Method:  "resources/read",
+			Params:  json.RawMessage(`{"uri":"retriever://Docs/golc"}`),
+		})
+		require.Nil(t, resp.Error)
+
+		result := mcp.ReadResourceResult{}
+		require.NoError(t, json.Unmarshal(resp.Result, &result))
+		require.Len(t, result.Contents, 1)
+		assert.Equal(t, "retriever://Docs/golc#0", result.Contents[0].URI)
+		assert.Equal(t, "Document about golc", result.Contents[0].Text)
+	})
+
+	t.Run("Duplicate tool", func(t *testing.T) {
+		echo, err := tool.NewFunc("Docs", "Duplicate", func(ctx context.Context, args echoArgs) (string, error) {
+			return args.Text, nil
+		})
+		require.NoError(t, err)
+
+		_, err = New([]schema.Tool{echo}, func(o *Options) {
+			o.Retrievers = []Retriever{{Name: "Docs", Retriever: &mockRetriever{}}}
+		})
+		require.ErrorIs(t, err, ErrDuplicateTool)
+	})
+}
+
+func TestServe(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+
+	server := newTestServer(t, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.Serve(context.Background(), inR, outW)
+		outW.Close()
+	}()
+
+	responses := bufio.NewScanner(outR)
+
+	send := func(msg string) {
+		_, err := io.WriteString(inW, msg+"\n")
+		require.NoError(t, err)
+	}
+
+	send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"Block"}}`)
+	<-started
+
+	send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1}}`)
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "request was not cancelled")
+	}
+
+	send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	send(`not json`)
+
+	// The cancelled request is not answered, so the responses belong to the ping and the invalid message.
+	// The ping is handled concurrently, so the responses can arrive in any order.
+	resps := map[string]mcp.Message{}
+
+	for i := 0; i < 2; i++ {
+		require.True(t, responses.Scan())
+
+		resp := mcp.Message{}
+		require.NoError(t, json.Unmarshal(responses.Bytes(), &resp))
+
+		resps[string(resp.ID)] = resp
+	}
+
+	require.Contains(t, resps, "2")
+	assert.JSONEq(t, `{}`, string(resps["2"].Result))
+
+	require.Contains(t, resps, "null")
+	assert.Equal(t, mcp.CodeParseError, resps["null"].Error.Code)
+
+	require.NoError(t, inW.Close())
+	require.NoError(t, <-done)
+}
+
+func TestServeContextCancelled(t *testing.T) {
+	server := newTestServer(t, nil)
+
+	// The reader is never closed, so the read blocks until the context is cancelled.
+	inR, inW := io.Pipe()
+	defer inW.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.Serve(ctx, inR, io.Discard)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "serve did not return after the context was cancelled")
+	}
+}
+
+// newTestServer creates a server with an echo tool, a failing tool, a blocking tool and a retriever.
+func newTestServer(t *testing.T, block func(ctx context.Context)) *Server {
+	t.Helper()
+
+	echo, err := tool.NewFunc("Echo", "Echoes the text.", func(ctx context.Context, args echoArgs) (string, error) {
+		return args.Text, nil
+	})
+	require.NoError(t, err)
+
+	fail, err := tool.NewFunc("Fail", "Always fails.", func(ctx context.Context, args struct{}) (string, error) {
+		return "", errors.New("tool failed")
+	})
+	require.NoError(t, err)
+
+	tools := []schema.Tool{echo, fail}
+
+	if block != nil {
+		blocking, err := tool.NewFunc("Block", "Blocks until cancelled.", func(ctx context.Context, args struct{}) (string, error) {
+			block(ctx)
+			return "", ctx.Err()
+		})
+		require.NoError(t, err)
+
+		tools = []schema.Tool{blocking}
+	}
+
+	server, err := New(tools, func(o *Options) {
+		o.Retrievers = []Retriever{{
+			Name:        "Docs",
+			Description: "Searches the documentation.",
+			Retriever: &mockRetriever{
+				docs: []schema.Document{{PageContent: "Document about golc"}},
+			},
+		}}
+	})
+	require.NoError(t, err)
+
+	return server
+}
+
+// mockRetriever is a retriever that returns fixed documents.
+type mockRetriever struct {
+	docs []schema.Document
+}
+
+func (r *mockRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	return r.docs, nil
+}
+
+func (r *mockRetriever) Verbose() bool {
+	return false
+}
+
+func (r *mockRetriever) Callbacks() []schema.Callback {
+	return nil
+}
@@ -0,0 +1,131 @@
+package mcpserver
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hupe1980/golc/integration/mcp"
+)
+
+// ServeStdio serves the MCP server over the stdin and stdout of the current process.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+// Serve reads newline delimited JSON-RPC messages from r and writes the responses to w until r is
+// closed or the context is cancelled. Requests are handled concurrently and can be cancelled by the
+// client with a notifications/cancelled notification. No response is sent for cancelled requests.
+// If the context is cancelled, Serve returns without waiting for the blocked read of r.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	var (
+		wg       sync.WaitGroup
+		writeMu  sync.Mutex
+		cancelMu sync.Mutex
+	)
+
+	cancels := make(map[string]context.CancelFunc)
+	encoder := json.NewEncoder(w)
+
+	write := func(msg *mcp.Message) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+
+		_ = encoder.Encode(msg)
+	}
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+
+	defer close(done)
+
+	// The lines are read in a goroutine, so that a cancelled context is not blocked by the read.
+	go func() {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+		for scanner.Scan() {
+			select {
+			case lines <- append([]byte{}, scanner.Bytes()...):
+			case <-done:
+				return
+			}
+		}
+
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		var line []byte
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case err := <-readErr:
+			wg.Wait()
+			return err
+		case line = <-lines:
+		}
+
+		msg := &mcp.Message{}
+		if err := json.Unmarshal(line, msg); err != nil {
+			write(&mcp.Message{
+				JSONRPC: "2.0",
+				ID:      json.RawMessage("null"),
+				Error:   &mcp.RPCError{Code: mcp.CodeParseError, Message: err.Error()},
+			})
+
+			continue
+		}
+
+		if msg.Method == "notifications/cancelled" {
+			params := struct {
+				RequestID json.RawMessage `json:"requestId"`
+			}{}
+
+			if err := json.Unmarshal(msg.Params, &params); err == nil {
+				cancelMu.Lock()
+				if cancel, ok := cancels[string(params.RequestID)]; ok {
+					cancel()
+				}
+				cancelMu.Unlock()
+			}
+
+			continue
+		}
+
+		if !msg.IsRequest() {
+			continue
+		}
+
+		reqCtx, cancel := context.WithCancel(ctx)
+
+		cancelMu.Lock()
+		cancels[string(msg.ID)] = cancel
+		cancelMu.Unlock()
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer cancel()
+
+			resp := s.Handle(reqCtx, msg)
+
+			cancelMu.Lock()
+			delete(cancels, string(msg.ID))
+			cancelMu.Unlock()
+
+			if reqCtx.Err() != nil {
+				return
+			}
+
+			write(resp)
+		}()
+	}
+}
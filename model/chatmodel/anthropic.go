@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -26,6 +27,12 @@ type AnthropicClient interface {
 	CreateCompletion(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error)
 }
 
+// AnthropicMessagesClient is the interface for an Anthropic client that supports the messages API.
+type AnthropicMessagesClient interface {
+	AnthropicClient
+	CreateMessage(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error)
+}
+
 // AnthropicOptions contains options for configuring the Anthropic chat model.
 type AnthropicOptions struct {
 	*schema.CallbackOptions `map:"-"`
@@ -45,6 +52,10 @@ type AnthropicOptions struct {
 
 	// TopP parameter specifies the cumulative probability threshold for generating tokens.
 	TopP float32 `map:"top_p,omitempty"`
+
+	// MessagesAPI uses the messages API instead of the legacy completions API. It is required
+	// for tool calling and needs a client that implements AnthropicMessagesClient.
+	MessagesAPI bool `map:"messages_api,omitempty"`
 }
 
 // Anthropic is a chat model based on the Anthropic API.
@@ -67,7 +78,6 @@ func NewAnthropicFromClient(client AnthropicClient, optFns ...func(o *AnthropicO
 		CallbackOptions: &schema.CallbackOptions{
 			Verbose: golc.Verbose,
 		},
-		ModelName:   "claude-v1",
 		Temperature: 0.5,
 		MaxTokens:   256,
 	}
@@ -76,6 +86,18 @@ func NewAnthropicFromClient(client AnthropicClient, optFns ...func(o *AnthropicO
 		fn(&opts)
 	}
 
+	if opts.ModelName == "" {
+		// The legacy models are not available in the messages API.
+		opts.ModelName = "claude-v1"
+		if opts.MessagesAPI {
+			opts.ModelName = "claude-3-haiku-20240307"
+		}
+	}
+
+	if _, ok := client.(AnthropicMessagesClient); opts.MessagesAPI && !ok {
+		return nil, fmt.Errorf("the messages API requires a client that implements AnthropicMessagesClient")
+	}
+
 	if opts.Tokenizer == nil {
 		var tErr error
 
@@ -102,6 +124,14 @@ func (cm *Anthropic) Generate(ctx context.Context, messages schema.ChatMessages,
 		fn(&opts)
 	}
 
+	if cm.opts.MessagesAPI {
+		return cm.generateMessage(ctx, messages, opts)
+	}
+
+	if toolChoice := opts.ResolveToolChoice(); toolChoice != "" && toolChoice != schema.ToolChoiceNone {
+		return nil, fmt.Errorf("tool calling requires the messages API of anthropic, enable the MessagesAPI option")
+	}
+
 	prompt, err := convertMessagesToAnthropicPrompt(messages)
 	if err != nil {
 		return nil, err
@@ -126,6 +156,85 @@ func (cm *Anthropic) Generate(ctx context.Context, messages schema.ChatMessages,
 	}, nil
 }
 
+// generateMessage generates text and tool calls with the messages API.
+func (cm *Anthropic) generateMessage(ctx context.Context, messages schema.ChatMessages, opts schema.GenerateOptions) (*schema.ModelResult, error) {
+	system, anthropicMessages, err := toAnthropicMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &anthropic.MessageRequest{
+		Model:       cm.opts.ModelName,
+		Messages:    anthropicMessages,
+		System:      system,
+		Temperature: cm.opts.Temperature,
+		MaxTokens:   cm.opts.MaxTokens,
+		TopK:        cm.opts.TopK,
+		TopP:        cm.opts.TopP,
+		Stop:        opts.Stop,
+	}
+
+	if toolChoice := opts.ResolveToolChoice(); toolChoice != "" && toolChoice != schema.ToolChoiceNone {
+		request.Tools = util.Map(opts.Functions, func(fd schema.FunctionDefinition, _ int) anthropic.Tool {
+			return anthropic.Tool{
+				Name:        fd.Name,
+				Description: fd.Description,
+				InputSchema: fd.Parameters,
+			}
+		})
+
+		switch toolChoice {
+		case schema.ToolChoiceAuto:
+			request.ToolChoice = &anthropic.ToolChoice{Type: "auto"}
+		case schema.ToolChoiceRequired:
+			request.ToolChoice = &anthropic.ToolChoice{Type: "any"}
+		default:
+			request.ToolChoice = &anthropic.ToolChoice{Type: "tool", Name: toolChoice}
+		}
+	}
+
+	res, err := cm.client.(AnthropicMessagesClient).CreateMessage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []string{}
+	toolCalls := []schema.ToolCall{}
+
+	for _, block := range res.Content {
+		switch block.Type {
+		case anthropic.ContentTypeText:
+			texts = append(texts, block.Text)
+		case anthropic.ContentTypeToolUse:
+			toolCalls = append(toolCalls, schema.ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(block.Input),
+			})
+		}
+	}
+
+	text := strings.Join(texts, "")
+
+	return &schema.ModelResult{
+		Generations: []schema.Generation{{
+			Text:    text,
+			Message: newAIChatMessageWithToolCalls(text, toolCalls),
+			Info: map[string]any{
+				"StopReason": res.StopReason,
+			},
+		}},
+		LLMOutput: map[string]any{
+			"ModelName": cm.opts.ModelName,
+			"TokenUsage": map[string]int{
+				"PromptTokens":     res.Usage.InputTokens,
+				"CompletionTokens": res.Usage.OutputTokens,
+				"TotalTokens":      res.Usage.InputTokens + res.Usage.OutputTokens,
+			},
+		},
+	}, nil
+}
+
 // Type returns the type of the model.
 func (cm *Anthropic) Type() string {
 	return "chatmodel.Anthropic"
@@ -146,6 +255,67 @@ func (cm *Anthropic) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
 
+// toAnthropicMessages converts the chat messages to the system prompt and the messages of the
+// Anthropic messages API. Consecutive messages of the same role are merged, because the API
+// requires alternating roles. Tool results are sent as user messages.
+func toAnthropicMessages(messages schema.ChatMessages) (string, []anthropic.Message, error) {
+	systemPrompts := []string{}
+	anthropicMessages := []anthropic.Message{}
+
+	appendBlocks := func(role string, blocks ...anthropic.ContentBlock) {
+		if len(blocks) == 0 {
+			return
+		}
+
+		if n := len(anthropicMessages); n > 0 && anthropicMessages[n-1].Role == role {
+			anthropicMessages[n-1].Content = append(anthropicMessages[n-1].Content, blocks...)
+			return
+		}
+
+		anthropicMessages = append(anthropicMessages, anthropic.Message{Role: role, Content: blocks})
+	}
+
+	for _, message := range messages {
+		switch m := message.(type) {
+		case *schema.SystemChatMessage:
+			systemPrompts = append(systemPrompts, m.Content())
+		case *schema.HumanChatMessage:
+			appendBlocks("user", anthropic.ContentBlock{Type: anthropic.ContentTypeText, Text: m.Content()})
+		case *schema.AIChatMessage:
+			blocks := []anthropic.ContentBlock{}
+			if m.Content() != "" {
+				blocks = append(blocks, anthropic.ContentBlock{Type: anthropic.ContentTypeText, Text: m.Content()})
+			}
+
+			for _, tc := range m.ToolCalls() {
+				input := json.RawMessage(tc.Arguments)
+				if tc.Arguments == "" {
+					input = json.RawMessage("{}")
+				}
+
+				blocks = append(blocks, anthropic.ContentBlock{
+					Type:  anthropic.ContentTypeToolUse,
+					ID:    tc.ID,
+					Name:  tc.Name,
+					Input: input,
+				})
+			}
+
+			appendBlocks("assistant", blocks...)
+		case *schema.ToolChatMessage:
+			appendBlocks("user", anthropic.ContentBlock{
+				Type:      anthropic.ContentTypeToolResult,
+				ToolUseID: m.ToolCallID(),
+				Content:   m.Content(),
+			})
+		default:
+			return "", nil, fmt.Errorf("unsupported message type: %s", message.Type())
+		}
+	}
+
+	return strings.Join(systemPrompts, "\n\n"), anthropicMessages, nil
+}
+
 func convertMessagesToAnthropicPrompt(messages schema.ChatMessages) (string, error) {
 	if len(messages) > 0 {
 		msg := messages[len(messages)-1]
@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -42,7 +43,52 @@ func TestAnthropic(t *testing.T) {
 			assert.Equal(t, "Hello, how can I help you?", result.Generations[0].Text, "Generated text does not match")
 		})
 
-		// Test case 2: Anthropic API error
+		// Test case 2: Tool calls
+		t.Run("Tool calls", func(t *testing.T) {
+			messagesModel, err := NewAnthropicFromClient(client, func(o *AnthropicOptions) {
+				o.MessagesAPI = true
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "claude-3-haiku-20240307", messagesModel.InvocationParams()["model_name"])
+
+			client.createMessageFn = func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+				assert.Equal(t, &anthropic.ToolChoice{Type: "tool", Name: "weather"}, request.ToolChoice)
+				assert.Len(t, request.Tools, 1)
+
+				return &anthropic.MessageResponse{
+					Content: []anthropic.ContentBlock{
+						{Type: anthropic.ContentTypeToolUse, ID: "toolu_1", Name: "weather", Input: json.RawMessage(`{"city":"Berlin"}`)},
+						{Type: anthropic.ContentTypeToolUse, ID: "toolu_2", Name: "weather", Input: json.RawMessage(`{"city":"Paris"}`)},
+					},
+					StopReason: "tool_use",
+				}, nil
+			}
+
+			result, err := messagesModel.Generate(context.Background(), schema.ChatMessages{
+				schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+			}, func(o *schema.GenerateOptions) {
+				o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+				o.ToolChoice = "weather"
+			})
+			assert.NoError(t, err)
+
+			aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+			assert.True(t, ok)
+			assert.Equal(t, []schema.ToolCall{
+				{ID: "toolu_1", Name: "weather", Arguments: `{"city":"Berlin"}`},
+				{ID: "toolu_2", Name: "weather", Arguments: `{"city":"Paris"}`},
+			}, aiMsg.ToolCalls())
+
+			// The completions API does not support tool calling.
+			_, err = anthropicModel.Generate(context.Background(), schema.ChatMessages{
+				schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+			}, func(o *schema.GenerateOptions) {
+				o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+			})
+			assert.ErrorContains(t, err, "messages API")
+		})
+
+		// Test case 3: Anthropic API error
 		t.Run("Anthropic API error", func(t *testing.T) {
 			// Mock the CreateCompletion method to return an error response.
 			client.createCompletionFn = func(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error) {
@@ -87,12 +133,69 @@ func TestAnthropic(t *testing.T) {
 // mockAnthropicClient is a mock implementation of the AnthropicClient interface for testing.
 type mockAnthropicClient struct {
 	createCompletionFn func(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error)
+	createMessageFn    func(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error)
 }
 
 func (m *mockAnthropicClient) CreateCompletion(ctx context.Context, request *anthropic.CompletionRequest) (*anthropic.CompletionResponse, error) {
 	return m.createCompletionFn(ctx, request)
 }
 
+func (m *mockAnthropicClient) CreateMessage(ctx context.Context, request *anthropic.MessageRequest) (*anthropic.MessageResponse, error) {
+	return m.createMessageFn(ctx, request)
+}
+
+func TestToAnthropicMessages(t *testing.T) {
+	t.Run("Empty input messages", func(t *testing.T) {
+		system, messages, err := toAnthropicMessages(schema.ChatMessages{})
+		assert.NoError(t, err)
+		assert.Equal(t, "", system)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("System messages", func(t *testing.T) {
+		system, messages, err := toAnthropicMessages(schema.ChatMessages{
+			schema.NewSystemChatMessage("System message"),
+			schema.NewHumanChatMessage("Human message"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "System message", system)
+		assert.Equal(t, []anthropic.Message{
+			{Role: "user", Content: []anthropic.ContentBlock{{Type: anthropic.ContentTypeText, Text: "Human message"}}},
+		}, messages)
+	})
+
+	t.Run("Tool calls and results", func(t *testing.T) {
+		_, messages, err := toAnthropicMessages(schema.ChatMessages{
+			schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{
+					{ID: "toolu_1", Name: "weather", Arguments: `{"city":"Berlin"}`},
+					{ID: "toolu_2", Name: "weather", Arguments: `{"city":"Paris"}`},
+				}
+			}),
+			schema.NewToolChatMessage("toolu_1", "weather", "sunny"),
+			schema.NewToolChatMessage("toolu_2", "weather", "rainy"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []anthropic.Message{
+			{Role: "user", Content: []anthropic.ContentBlock{{Type: anthropic.ContentTypeText, Text: "Weather in Berlin and Paris?"}}},
+			{Role: "assistant", Content: []anthropic.ContentBlock{
+				{Type: anthropic.ContentTypeToolUse, ID: "toolu_1", Name: "weather", Input: json.RawMessage(`{"city":"Berlin"}`)},
+				{Type: anthropic.ContentTypeToolUse, ID: "toolu_2", Name: "weather", Input: json.RawMessage(`{"city":"Paris"}`)},
+			}},
+			{Role: "user", Content: []anthropic.ContentBlock{
+				{Type: anthropic.ContentTypeToolResult, ToolUseID: "toolu_1", Content: "sunny"},
+				{Type: anthropic.ContentTypeToolResult, ToolUseID: "toolu_2", Content: "rainy"},
+			}},
+		}, messages)
+	})
+
+	t.Run("Unsupported message", func(t *testing.T) {
+		_, _, err := toAnthropicMessages(schema.ChatMessages{schema.NewGenericChatMessage("Generic", "role")})
+		assert.Error(t, err)
+	})
+}
+
 func TestConvertMessagesToAnthropicPrompt(t *testing.T) {
 	t.Run("Empty input messages", func(t *testing.T) {
 		emptyMessages := schema.ChatMessages{}
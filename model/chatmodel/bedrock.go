@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -216,33 +217,77 @@ func NewBedrock(client BedrockRuntimeClient, modelID string, optFns ...func(o *B
 	}, nil
 }
 
+// PrepareInput converts the chat messages, model params and functions to the input of the Converse API.
 func (cm *Bedrock) PrepareInput(msgs schema.ChatMessages, params map[string]any, functions []schema.FunctionDefinition) (*bedrockruntime.ConverseInput, error) {
+	return cm.PrepareInputWithToolChoice(msgs, params, functions, "")
+}
+
+// PrepareInputWithToolChoice converts the chat messages, model params, functions and tool choice to the
+// input of the Converse API. An empty tool choice leaves the choice to the model.
+func (cm *Bedrock) PrepareInputWithToolChoice(msgs schema.ChatMessages, params map[string]any, functions []schema.FunctionDefinition, toolChoice string) (*bedrockruntime.ConverseInput, error) {
 	messages := make([]bedrockruntimeTypes.Message, 0, len(msgs))
 	system := make([]bedrockruntimeTypes.SystemContentBlock, 0)
 
+	// appendBlocks merges consecutive blocks of the same role, because the Converse API
+	// requires alternating roles.
+	appendBlocks := func(role bedrockruntimeTypes.ConversationRole, blocks ...bedrockruntimeTypes.ContentBlock) {
+		if n := len(messages); n > 0 && messages[n-1].Role == role {
+			messages[n-1].Content = append(messages[n-1].Content, blocks...)
+			return
+		}
+
+		messages = append(messages, bedrockruntimeTypes.Message{
+			Role:    role,
+			Content: blocks,
+		})
+	}
+
 	for _, msg := range msgs {
-		switch msg.Type() {
-		case schema.ChatMessageTypeSystem:
+		switch m := msg.(type) {
+		case *schema.SystemChatMessage:
 			system = append(system, &bedrockruntimeTypes.SystemContentBlockMemberText{
-				Value: msg.Content(),
+				Value: m.Content(),
 			})
-		case schema.ChatMessageTypeAI:
-			messages = append(messages, bedrockruntimeTypes.Message{
-				Role: bedrockruntimeTypes.ConversationRoleAssistant,
-				Content: []bedrockruntimeTypes.ContentBlock{
-					&bedrockruntimeTypes.ContentBlockMemberText{
-						Value: msg.Content(),
+		case *schema.AIChatMessage:
+			blocks := []bedrockruntimeTypes.ContentBlock{}
+			if m.Content() != "" || len(m.ToolCalls()) == 0 {
+				blocks = append(blocks, &bedrockruntimeTypes.ContentBlockMemberText{
+					Value: m.Content(),
+				})
+			}
+
+			for _, tc := range m.ToolCalls() {
+				input := map[string]any{}
+				if tc.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Arguments), &input); err != nil {
+						return nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.Name, err)
+					}
+				}
+
+				blocks = append(blocks, &bedrockruntimeTypes.ContentBlockMemberToolUse{
+					Value: bedrockruntimeTypes.ToolUseBlock{
+						ToolUseId: aws.String(tc.ID),
+						Name:      aws.String(tc.Name),
+						Input:     bedrockruntimeDocument.NewLazyDocument(input),
+					},
+				})
+			}
+
+			appendBlocks(bedrockruntimeTypes.ConversationRoleAssistant, blocks...)
+		case *schema.ToolChatMessage:
+			appendBlocks(bedrockruntimeTypes.ConversationRoleUser, &bedrockruntimeTypes.ContentBlockMemberToolResult{
+				Value: bedrockruntimeTypes.ToolResultBlock{
+					ToolUseId: aws.String(m.ToolCallID()),
+					Content: []bedrockruntimeTypes.ToolResultContentBlock{
+						&bedrockruntimeTypes.ToolResultContentBlockMemberText{
+							Value: m.Content(),
+						},
 					},
 				},
 			})
 		default:
-			messages = append(messages, bedrockruntimeTypes.Message{
-				Role: bedrockruntimeTypes.ConversationRoleUser,
-				Content: []bedrockruntimeTypes.ContentBlock{
-					&bedrockruntimeTypes.ContentBlockMemberText{
-						Value: msg.Content(),
-					},
-				},
+			appendBlocks(bedrockruntimeTypes.ConversationRoleUser, &bedrockruntimeTypes.ContentBlockMemberText{
+				Value: msg.Content(),
 			})
 		}
 	}
@@ -252,9 +297,10 @@ func (cm *Bedrock) PrepareInput(msgs schema.ChatMessages, params map[string]any,
 	if len(params) > 0 {
 		additionalModelRequestFields = bedrockruntimeDocument.NewLazyDocument(params)
 	}
-	var ToolConfig *bedrockruntimeTypes.ToolConfiguration
 
-	if len(functions) > 0 {
+	var toolConfig *bedrockruntimeTypes.ToolConfiguration
+
+	if len(functions) > 0 && toolChoice != schema.ToolChoiceNone {
 		tools := make([]bedrockruntimeTypes.Tool, 0, len(functions))
 
 		for _, function := range functions {
@@ -273,9 +319,23 @@ func (cm *Bedrock) PrepareInput(msgs schema.ChatMessages, params map[string]any,
 			})
 		}
 
-		ToolConfig = &bedrockruntimeTypes.ToolConfiguration{
+		toolConfig = &bedrockruntimeTypes.ToolConfiguration{
 			Tools: tools,
 		}
+
+		switch toolChoice {
+		case "":
+		case schema.ToolChoiceAuto:
+			toolConfig.ToolChoice = &bedrockruntimeTypes.ToolChoiceMemberAuto{}
+		case schema.ToolChoiceRequired:
+			toolConfig.ToolChoice = &bedrockruntimeTypes.ToolChoiceMemberAny{}
+		default:
+			toolConfig.ToolChoice = &bedrockruntimeTypes.ToolChoiceMemberTool{
+				Value: bedrockruntimeTypes.SpecificToolChoice{
+					Name: aws.String(toolChoice),
+				},
+			}
+		}
 	}
 
 	return &bedrockruntime.ConverseInput{
@@ -289,7 +349,7 @@ func (cm *Bedrock) PrepareInput(msgs schema.ChatMessages, params map[string]any,
 		},
 		System:                       system,
 		AdditionalModelRequestFields: additionalModelRequestFields,
-		ToolConfig:                   ToolConfig,
+		ToolConfig:                   toolConfig,
 	}, nil
 }
 
@@ -305,7 +365,7 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 
 	params := util.CopyMap(cm.opts.ModelParams)
 
-	input, err := cm.PrepareInput(messages, params, opts.Functions)
+	input, err := cm.PrepareInputWithToolChoice(messages, params, opts.Functions, opts.ResolveToolChoice())
 	if err != nil {
 		return nil, err
 	}
@@ -313,11 +373,13 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 	var completion string
 
 	llmOutput := make(map[string]any)
-	
+
 	var finishReason bedrockruntimeTypes.StopReason
-	
+
+	toolCalls := []schema.ToolCall{}
+
 	functionCalls := []bedrockruntimeTypes.ToolUseBlock{}
-	
+
 	if cm.opts.Stream {
 		input := &bedrockruntime.ConverseStreamInput{
 			Messages:                     input.Messages,
@@ -342,11 +404,9 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 
 		tokens := []string{}
 
-		var (
-			toolBlock      bedrockruntimeTypes.ToolUseBlock
-			toolInput      string
-			toolBlockIndex *int32
-		)
+		// toolCallIndexes maps content block indexes to the index of the tool call, tool
+		// inputs arrive in several deltas.
+		toolCallIndexes := map[int32]int{}
 
 		for event := range stream.Events() {
 			switch v := event.(type) {
@@ -355,9 +415,12 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 				if !ok {
 					continue
 				}
-				toolBlockIndex = v.Value.ContentBlockIndex
-				toolBlock.Name = toolUse.Value.Name
-				toolBlock.ToolUseId = toolUse.Value.ToolUseId
+
+				toolCallIndexes[aws.ToInt32(v.Value.ContentBlockIndex)] = len(toolCalls)
+				toolCalls = append(toolCalls, schema.ToolCall{
+					ID:   aws.ToString(toolUse.Value.ToolUseId),
+					Name: aws.ToString(toolUse.Value.Name),
+				})
 			case *bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockDelta:
 				delta := v.Value.Delta
 				switch token := delta.(type) {
@@ -367,14 +430,12 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 					}); err != nil {
 						return nil, err
 					}
+
 					tokens = append(tokens, token.Value)
 				case *bedrockruntimeTypes.ContentBlockDeltaMemberToolUse:
-					toolInput += *token.Value.Input
-				}
-			case *bedrockruntimeTypes.ConverseStreamOutputMemberContentBlockStop:
-				if toolBlockIndex == v.Value.ContentBlockIndex {
-					toolBlock.Input = bedrockruntimeDocument.NewLazyDocument(toolInput)
-					functionCalls = append(functionCalls, toolBlock)
+					if i, ok := toolCallIndexes[aws.ToInt32(v.Value.ContentBlockIndex)]; ok {
+						toolCalls[i].Arguments += aws.ToString(token.Value.Input)
+					}
 				}
 			case *bedrockruntimeTypes.ConverseStreamOutputMemberMessageStop:
 				finishReason = v.Value.StopReason
@@ -406,6 +467,14 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 		}
 
 		completion = strings.Join(tokens, "")
+
+		for _, tc := range toolCalls {
+			functionCalls = append(functionCalls, bedrockruntimeTypes.ToolUseBlock{
+				ToolUseId: aws.String(tc.ID),
+				Name:      aws.String(tc.Name),
+				Input:     bedrockruntimeDocument.NewLazyDocument(tc.Arguments),
+			})
+		}
 	} else {
 		res, err := cm.client.Converse(ctx, input)
 		if err != nil {
@@ -424,6 +493,21 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 			case *bedrockruntimeTypes.ContentBlockMemberText:
 				output += v.Value
 			case *bedrockruntimeTypes.ContentBlockMemberToolUse:
+				toolCall := schema.ToolCall{
+					ID:   aws.ToString(v.Value.ToolUseId),
+					Name: aws.ToString(v.Value.Name),
+				}
+
+				if v.Value.Input != nil {
+					arguments, err := v.Value.Input.MarshalSmithyDocument()
+					if err != nil {
+						return nil, err
+					}
+
+					toolCall.Arguments = string(arguments)
+				}
+
+				toolCalls = append(toolCalls, toolCall)
 				functionCalls = append(functionCalls, v.Value)
 			default:
 				return nil, fmt.Errorf("unexpected content type returned from bedrock: %T", block)
@@ -439,22 +523,12 @@ func (cm *Bedrock) Generate(ctx context.Context, messages schema.ChatMessages, o
 			llmOutput["tokens"] = *res.Usage.TotalTokens
 		}
 	}
+
 	return &schema.ModelResult{
 		Generations: []schema.Generation{
 			{
-				Text: completion,
-				Message: schema.NewAIChatMessage(completion, func(o *schema.ChatMessageExtension) {
-					for _, functionCall := range functionCalls {
-						inputBytes, err := functionCall.Input.MarshalSmithyDocument()
-						if err != nil {
-							return
-						}
-						o.FunctionCall = &schema.FunctionCall{
-							Name:      *functionCall.Name,
-							Arguments: string(inputBytes),
-						}
-					}
-				}),
+				Text:    completion,
+				Message: newAIChatMessageWithToolCalls(completion, toolCalls),
 				Info: map[string]any{
 					"FinishReason":  string(finishReason),
 					"FunctionCalls": functionCalls,
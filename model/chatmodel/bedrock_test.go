@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	bedrockruntimeDocument "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
 	bedrockruntimeTypes "github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +51,59 @@ func TestBedrock(t *testing.T) {
 				assert.Equal(t, "Hello, how can I help you?", result.Generations[0].Text, "Generated text does not match")
 			})
 
+			t.Run("Tool calls", func(t *testing.T) {
+				client.createConverseFn = func(ctx context.Context, params *bedrockruntime.ConverseInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error) {
+					assert.Equal(t, &bedrockruntimeTypes.ToolChoiceMemberAny{}, params.ToolConfig.ToolChoice)
+					assert.Len(t, params.Messages, 3)
+					assert.Equal(t, bedrockruntimeTypes.ConversationRoleUser, params.Messages[2].Role)
+					assert.Len(t, params.Messages[2].Content, 2)
+					assert.IsType(t, &bedrockruntimeTypes.ContentBlockMemberToolResult{}, params.Messages[2].Content[0])
+
+					return &bedrockruntime.ConverseOutput{
+						Output: &bedrockruntimeTypes.ConverseOutputMemberMessage{
+							Value: bedrockruntimeTypes.Message{
+								Content: []bedrockruntimeTypes.ContentBlock{
+									&bedrockruntimeTypes.ContentBlockMemberToolUse{
+										Value: bedrockruntimeTypes.ToolUseBlock{
+											ToolUseId: aws.String("tooluse_3"),
+											Name:      aws.String("weather"),
+											Input:     bedrockruntimeDocument.NewLazyDocument(map[string]any{"city": "Rome"}),
+										},
+									},
+								},
+							},
+						},
+						StopReason: bedrockruntimeTypes.StopReasonToolUse,
+					}, nil
+				}
+
+				chatMessages := []schema.ChatMessage{
+					schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+					schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+						o.ToolCalls = []schema.ToolCall{
+							{ID: "tooluse_1", Name: "weather", Arguments: `{"city":"Berlin"}`},
+							{ID: "tooluse_2", Name: "weather", Arguments: `{"city":"Paris"}`},
+						}
+					}),
+					schema.NewToolChatMessage("tooluse_1", "weather", "sunny"),
+					schema.NewToolChatMessage("tooluse_2", "weather", "rainy"),
+				}
+
+				result, err := bedrockModel.Generate(context.Background(), chatMessages, func(o *schema.GenerateOptions) {
+					o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+					o.ToolChoice = schema.ToolChoiceRequired
+				})
+				assert.NoError(t, err)
+
+				aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+				assert.True(t, ok)
+				assert.Equal(t, []schema.ToolCall{{ID: "tooluse_3", Name: "weather", Arguments: `{"city":"Rome"}`}}, aiMsg.ToolCalls())
+
+				functionCalls, ok := result.Generations[0].Info["FunctionCalls"].([]bedrockruntimeTypes.ToolUseBlock)
+				assert.True(t, ok)
+				assert.Len(t, functionCalls, 1)
+			})
+
 			t.Run("Bedrock API error", func(t *testing.T) {
 				client.createConverseFn = func(ctx context.Context, params *bedrockruntime.ConverseInput, optFns ...func(*bedrockruntime.Options)) (*bedrockruntime.ConverseOutput, error) {
 					return nil, fmt.Errorf("bedrock api error")
@@ -9,3 +9,19 @@ func newChatGeneraton(text string, extFns ...func(o *schema.ChatMessageExtension
 		Message: schema.NewAIChatMessage(text, extFns...),
 	}
 }
+
+// newAIChatMessageWithToolCalls creates an AI chat message with the given tool calls. The first
+// tool call is also set as legacy function call.
+func newAIChatMessageWithToolCalls(content string, toolCalls []schema.ToolCall) *schema.AIChatMessage {
+	if len(toolCalls) == 0 {
+		return schema.NewAIChatMessage(content)
+	}
+
+	return schema.NewAIChatMessage(content, func(o *schema.ChatMessageExtension) {
+		o.ToolCalls = toolCalls
+		o.FunctionCall = &schema.FunctionCall{
+			Name:      toolCalls[0].Name,
+			Arguments: toolCalls[0].Arguments,
+		}
+	})
+}
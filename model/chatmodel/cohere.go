@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -113,41 +114,37 @@ func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, op
 		return nil, fmt.Errorf("at least one message must be passed")
 	}
 
-	chatMessages := make([]*cohere.Message, len(messages)-1)
-
-	for i, m := range messages[:len(messages)-1] {
-		var message *cohere.Message
+	message, chatHistory, toolResults, err := toCohereMessages(messages)
+	if err != nil {
+		return nil, err
+	}
 
-		switch m.Type() {
-		case schema.ChatMessageTypeAI:
-			message = &cohere.Message{
-				Role: "CHATBOT",
-				Chatbot: &cohere.ChatMessage{
-					Message: m.Content(),
-				},
-			}
-		case schema.ChatMessageTypeHuman:
-			message = &cohere.Message{
-				Role: "USER",
-				Chatbot: &cohere.ChatMessage{
-					Message: m.Content(),
-				},
-			}
-		default:
-			return nil, fmt.Errorf("unsupported chat message type: %s", m.Type())
-		}
+	// Cohere does not support forcing tool calls, tools are offered unless disabled.
+	toolChoice := opts.ResolveToolChoice()
+	if toolChoice != "" && toolChoice != schema.ToolChoiceAuto && toolChoice != schema.ToolChoiceNone {
+		return nil, fmt.Errorf("cohere does not support tool choice %q, only %q and %q are supported", toolChoice, schema.ToolChoiceAuto, schema.ToolChoiceNone)
+	}
 
-		chatMessages[i] = message
+	var tools []*cohere.Tool
+	if toolChoice != "" && toolChoice != schema.ToolChoiceNone {
+		tools = util.Map(opts.Functions, func(fd schema.FunctionDefinition, _ int) *cohere.Tool {
+			return toCohereTool(fd)
+		})
 	}
 
-	var text string
+	var (
+		text      string
+		toolCalls []*cohere.ToolCall
+	)
 
 	if cm.opts.Stream {
 		stream, err := cm.client.ChatStream(ctx, &cohere.ChatStreamRequest{
 			Model:       util.AddrOrNil(cm.opts.Model),
-			Message:     messages[len(messages)-1].Content(),
-			ChatHistory: chatMessages,
+			Message:     message,
+			ChatHistory: chatHistory,
 			Temperature: util.AddrOrNil(cm.opts.Temperature),
+			Tools:       tools,
+			ToolResults: toolResults,
 		})
 		if err != nil {
 			return nil, err
@@ -171,7 +168,8 @@ func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, op
 					return nil, err
 				}
 
-				if res.EventType == "text-generation" {
+				switch res.EventType {
+				case "text-generation":
 					if err := opts.CallbackManger.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{
 						Token: res.TextGeneration.Text,
 					}); err != nil {
@@ -179,6 +177,8 @@ func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, op
 					}
 
 					tokens = append(tokens, res.TextGeneration.Text)
+				case "tool-calls-generation":
+					toolCalls = append(toolCalls, res.ToolCallsGeneration.ToolCalls...)
 				}
 			}
 		}
@@ -187,20 +187,31 @@ func (cm *Cohere) Generate(ctx context.Context, messages schema.ChatMessages, op
 	} else {
 		res, err := cm.generateWithRetry(ctx, &cohere.ChatRequest{
 			Model:       util.AddrOrNil(cm.opts.Model),
-			Message:     messages[len(messages)-1].Content(),
-			ChatHistory: chatMessages,
+			Message:     message,
+			ChatHistory: chatHistory,
 			Temperature: util.AddrOrNil(cm.opts.Temperature),
+			Tools:       tools,
+			ToolResults: toolResults,
 		})
 		if err != nil {
 			return nil, err
 		}
 
 		text = res.Text
+		toolCalls = res.ToolCalls
+	}
+
+	schemaToolCalls, err := fromCohereToolCalls(toolCalls)
+	if err != nil {
+		return nil, err
 	}
 
 	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(text)},
-		LLMOutput:   map[string]any{},
+		Generations: []schema.Generation{{
+			Text:    text,
+			Message: newAIChatMessageWithToolCalls(text, schemaToolCalls),
+		}},
+		LLMOutput: map[string]any{},
 	}, nil
 }
 
@@ -261,3 +272,178 @@ func (cm *Cohere) Callbacks() []schema.Callback {
 func (cm *Cohere) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
+
+// toCohereMessages converts the chat messages to the message, the chat history and the tool results
+// of a Cohere chat request. Trailing tool messages are sent as tool results with an empty message.
+func toCohereMessages(messages schema.ChatMessages) (string, []*cohere.Message, []*cohere.ToolResult, error) {
+	// Cohere does not use tool call IDs, the calls are looked up to attach them to their results.
+	toolCalls := map[string]schema.ToolCall{}
+
+	toToolResult := func(m *schema.ToolChatMessage) (*cohere.ToolResult, error) {
+		tc, ok := toolCalls[m.ToolCallID()]
+		if !ok {
+			tc = schema.ToolCall{Name: m.Name()}
+		}
+
+		parameters := map[string]any{}
+		if tc.Arguments != "" {
+			if err := json.Unmarshal([]byte(tc.Arguments), &parameters); err != nil {
+				return nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.Name, err)
+			}
+		}
+
+		return &cohere.ToolResult{
+			Call: &cohere.ToolCall{
+				Name:       tc.Name,
+				Parameters: parameters,
+			},
+			Outputs: []map[string]any{{"output": m.Content()}},
+		}, nil
+	}
+
+	last := len(messages)
+	for last > 0 && messages[last-1].Type() == schema.ChatMessageTypeTool {
+		last--
+	}
+
+	history := messages[:last]
+	message := ""
+
+	if last == len(messages) {
+		history = messages[:last-1]
+		message = messages[last-1].Content()
+	}
+
+	chatHistory := []*cohere.Message{}
+
+	for _, m := range history {
+		switch v := m.(type) {
+		case *schema.SystemChatMessage:
+			chatHistory = append(chatHistory, &cohere.Message{
+				Role:   "SYSTEM",
+				System: &cohere.ChatMessage{Message: v.Content()},
+			})
+		case *schema.HumanChatMessage:
+			chatHistory = append(chatHistory, &cohere.Message{
+				Role: "USER",
+				User: &cohere.ChatMessage{Message: v.Content()},
+			})
+		case *schema.AIChatMessage:
+			chatMessage := &cohere.ChatMessage{Message: v.Content()}
+
+			for _, tc := range v.ToolCalls() {
+				toolCalls[tc.ID] = tc
+
+				parameters := map[string]any{}
+				if tc.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Arguments), &parameters); err != nil {
+						return "", nil, nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.Name, err)
+					}
+				}
+
+				chatMessage.ToolCalls = append(chatMessage.ToolCalls, &cohere.ToolCall{
+					Name:       tc.Name,
+					Parameters: parameters,
+				})
+			}
+
+			chatHistory = append(chatHistory, &cohere.Message{
+				Role:    "CHATBOT",
+				Chatbot: chatMessage,
+			})
+		case *schema.ToolChatMessage:
+			toolResult, err := toToolResult(v)
+			if err != nil {
+				return "", nil, nil, err
+			}
+
+			if n := len(chatHistory); n > 0 && chatHistory[n-1].Tool != nil {
+				chatHistory[n-1].Tool.ToolResults = append(chatHistory[n-1].Tool.ToolResults, toolResult)
+				continue
+			}
+
+			chatHistory = append(chatHistory, &cohere.Message{
+				Role: "TOOL",
+				Tool: &cohere.ToolMessage{ToolResults: []*cohere.ToolResult{toolResult}},
+			})
+		default:
+			return "", nil, nil, fmt.Errorf("unsupported chat message type: %s", m.Type())
+		}
+	}
+
+	var toolResults []*cohere.ToolResult
+
+	for _, m := range messages[last:] {
+		toolResult, err := toToolResult(m.(*schema.ToolChatMessage))
+		if err != nil {
+			return "", nil, nil, err
+		}
+
+		toolResults = append(toolResults, toolResult)
+	}
+
+	return message, chatHistory, toolResults, nil
+}
+
+// toCohereTool converts a function definition to a Cohere tool. The JSON schema types
+// of the parameters are mapped to the python types expected by Cohere.
+func toCohereTool(fd schema.FunctionDefinition) *cohere.Tool {
+	required := map[string]bool{}
+	for _, name := range fd.Parameters.Required {
+		required[name] = true
+	}
+
+	parameterDefinitions := make(map[string]*cohere.ToolParameterDefinitionsValue, len(fd.Parameters.Properties))
+
+	for name, property := range fd.Parameters.Properties {
+		var pythonType string
+
+		switch property.Type {
+		case "integer":
+			pythonType = "int"
+		case "number":
+			pythonType = "float"
+		case "boolean":
+			pythonType = "bool"
+		case "array":
+			pythonType = "list"
+		case "object":
+			pythonType = "dict"
+		default:
+			pythonType = "str"
+		}
+
+		parameterDefinitions[name] = &cohere.ToolParameterDefinitionsValue{
+			Description: util.AddrOrNil(property.Description),
+			Type:        pythonType,
+			Required:    util.AddrOrNil(required[name]),
+		}
+	}
+
+	return &cohere.Tool{
+		Name:                 fd.Name,
+		Description:          fd.Description,
+		ParameterDefinitions: parameterDefinitions,
+	}
+}
+
+// fromCohereToolCalls converts Cohere tool calls to schema tool calls. Cohere does not
+// return IDs, so the IDs are derived from the position of the calls.
+func fromCohereToolCalls(toolCalls []*cohere.ToolCall) ([]schema.ToolCall, error) {
+	schemaToolCalls := make([]schema.ToolCall, 0, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		arguments, err := json.Marshal(tc.Parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaToolCalls = append(schemaToolCalls, schema.ToolCall{
+			ID:        fmt.Sprintf("call_%d", i),
+			Name:      tc.Name,
+			Arguments: string(arguments),
+		})
+	}
+
+	return schemaToolCalls, nil
+}
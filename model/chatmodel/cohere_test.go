@@ -42,6 +42,52 @@ func TestCohereGenerate(t *testing.T) {
 		assert.ErrorContains(t, actualErr, "at least one message must be passed")
 	})
 
+	t.Run("Tool calls", func(t *testing.T) {
+		mockClient.ChatFn = func(ctx context.Context, request *cohere.ChatRequest, opts ...core.RequestOption) (*cohere.NonStreamedChatResponse, error) {
+			assert.Equal(t, "", request.Message)
+			assert.Len(t, request.Tools, 1)
+			assert.Equal(t, []*cohere.ToolResult{{
+				Call:    &cohere.ToolCall{Name: "weather", Parameters: map[string]any{"city": "Berlin"}},
+				Outputs: []map[string]any{{"output": "sunny"}},
+			}}, request.ToolResults)
+
+			return &cohere.NonStreamedChatResponse{
+				ToolCalls: []*cohere.ToolCall{
+					{Name: "weather", Parameters: map[string]any{"city": "Paris"}},
+					{Name: "weather", Parameters: map[string]any{"city": "Rome"}},
+				},
+			}, nil
+		}
+
+		result, err := cohereModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Weather in Berlin?"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{{ID: "call_0", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+			}),
+			schema.NewToolChatMessage("call_0", "weather", "sunny"),
+		}, func(o *schema.GenerateOptions) {
+			o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, []schema.ToolCall{
+			{ID: "call_0", Name: "weather", Arguments: `{"city":"Paris"}`},
+			{ID: "call_1", Name: "weather", Arguments: `{"city":"Rome"}`},
+		}, aiMsg.ToolCalls())
+	})
+
+	t.Run("Unsupported tool choice", func(t *testing.T) {
+		_, err := cohereModel.Generate(context.Background(), schema.ChatMessages{
+			schema.NewHumanChatMessage("Weather in Berlin?"),
+		}, func(o *schema.GenerateOptions) {
+			o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+			o.ToolChoice = "weather"
+		})
+		assert.ErrorContains(t, err, "tool choice")
+	})
+
 	t.Run("Type", func(t *testing.T) {
 		assert.Equal(t, "chatmodel.Cohere", cohereModel.Type())
 	})
@@ -80,3 +126,20 @@ func (m *mockCohereClient) Chat(ctx context.Context, request *cohere.ChatRequest
 func (m *mockCohereClient) ChatStream(ctx context.Context, request *cohere.ChatStreamRequest, opts ...core.RequestOption) (*core.Stream[cohere.StreamedChatResponse], error) {
 	return m.ChatStreamFn(ctx, request, opts...)
 }
+
+func TestToCohereMessages(t *testing.T) {
+	message, chatHistory, toolResults, err := toCohereMessages(schema.ChatMessages{
+		schema.NewSystemChatMessage("system"),
+		schema.NewHumanChatMessage("hello"),
+		schema.NewAIChatMessage("hi"),
+		schema.NewHumanChatMessage("how are you?"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "how are you?", message)
+	assert.Nil(t, toolResults)
+	assert.Equal(t, []*cohere.Message{
+		{Role: "SYSTEM", System: &cohere.ChatMessage{Message: "system"}},
+		{Role: "USER", User: &cohere.ChatMessage{Message: "hello"}},
+		{Role: "CHATBOT", Chatbot: &cohere.ChatMessage{Message: "hi"}},
+	}, chatHistory)
+}
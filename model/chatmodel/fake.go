@@ -2,6 +2,8 @@ package chatmodel
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -39,6 +41,32 @@ func NewSimpleFake(response string, optFns ...func(o *FakeOptions)) *Fake {
 	}, optFns...)
 }
 
+// NewSequenceFake creates an instance of the Fake model that answers with the provided responses in order.
+// It returns an error once all responses have been used.
+func NewSequenceFake(responses []*schema.AIChatMessage, optFns ...func(o *FakeOptions)) *Fake {
+	var (
+		mu sync.Mutex
+		i  int
+	)
+
+	return NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if i >= len(responses) {
+			return nil, fmt.Errorf("fake has no response left after %d calls", len(responses))
+		}
+
+		response := responses[i]
+		i++
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: response.Content(), Message: response}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	}, optFns...)
+}
+
 // NewFake creates an instance of the Fake model with the provided custom result function.
 func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fake {
 	opts := FakeOptions{
@@ -53,6 +81,7 @@ func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fak
 	}
 
 	return &Fake{
+		Tokenizer:      opts.Tokenizer,
 		fakeResultFunc: fakeResultFunc,
 		opts:           opts,
 	}
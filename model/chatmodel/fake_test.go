@@ -57,6 +57,28 @@ func TestFake(t *testing.T) {
 		assert.EqualError(t, err, expectedError.Error())
 	})
 
+	t.Run("Generate_Sequence", func(t *testing.T) {
+		// Arrange
+		fake := NewSequenceFake([]*schema.AIChatMessage{
+			schema.NewAIChatMessage("first"),
+			schema.NewAIChatMessage("second"),
+		})
+
+		// Act
+		first, err := fake.Generate(context.Background(), schema.ChatMessages{})
+		assert.NoError(t, err)
+
+		second, err := fake.Generate(context.Background(), schema.ChatMessages{})
+		assert.NoError(t, err)
+
+		_, err = fake.Generate(context.Background(), schema.ChatMessages{})
+
+		// Assert
+		assert.Equal(t, "first", first.Generations[0].Text)
+		assert.Equal(t, "second", second.Generations[0].Message.Content())
+		assert.Error(t, err)
+	})
+
 	t.Run("Type", func(t *testing.T) {
 		// Arrange
 		fake := NewSimpleFake("response")
@@ -2,18 +2,22 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"strings"
 
-	"cloud.google.com/go/ai/generativelanguage/apiv1/generativelanguagepb"
+	generativelanguagepbv1 "cloud.google.com/go/ai/generativelanguage/apiv1/generativelanguagepb"
+	"cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
 	"github.com/googleapis/gax-go/v2"
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/integration/jsonschema"
 	"github.com/hupe1980/golc/internal/util"
 	"github.com/hupe1980/golc/schema"
 	"github.com/hupe1980/golc/tokenizer"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // Compile time check to ensure GoogleGenAI satisfies the ChatModel interface.
@@ -21,14 +25,23 @@ var _ schema.ChatModel = (*GoogleGenAI)(nil)
 
 // GoogleGenAIClient is an interface for the GoogleGenAI model client.
 type GoogleGenAIClient interface {
+	GenerateContent(context.Context, *generativelanguagepbv1.GenerateContentRequest, ...gax.CallOption) (*generativelanguagepbv1.GenerateContentResponse, error)
+	StreamGenerateContent(ctx context.Context, req *generativelanguagepbv1.GenerateContentRequest, opts ...gax.CallOption) (generativelanguagepbv1.GenerativeService_StreamGenerateContentClient, error)
+	CountTokens(context.Context, *generativelanguagepbv1.CountTokensRequest, ...gax.CallOption) (*generativelanguagepbv1.CountTokensResponse, error)
+}
+
+// GoogleGenAIBetaClient is an interface for the GoogleGenAI model client of the v1beta API,
+// which supports system instructions and function calling.
+type GoogleGenAIBetaClient interface {
 	GenerateContent(context.Context, *generativelanguagepb.GenerateContentRequest, ...gax.CallOption) (*generativelanguagepb.GenerateContentResponse, error)
 	StreamGenerateContent(ctx context.Context, req *generativelanguagepb.GenerateContentRequest, opts ...gax.CallOption) (generativelanguagepb.GenerativeService_StreamGenerateContentClient, error)
 	CountTokens(context.Context, *generativelanguagepb.CountTokensRequest, ...gax.CallOption) (*generativelanguagepb.CountTokensResponse, error)
 }
 
 const (
-	roleUser  = "user"
-	roleModel = "model"
+	roleUser     = "user"
+	roleModel    = "model"
+	roleFunction = "function"
 )
 
 type GoogleGenAIOptions struct {
@@ -54,11 +67,22 @@ type GoogleGenAIOptions struct {
 
 type GoogleGenAI struct {
 	schema.Tokenizer
-	client GoogleGenAIClient
+	client GoogleGenAIBetaClient
 	opts   GoogleGenAIOptions
 }
 
+// NewGoogleGenAI creates a new GoogleGenAI chat model with a client of the v1 API. The v1 API
+// does not support system messages and function calling, use NewGoogleGenAIBeta for them.
 func NewGoogleGenAI(client GoogleGenAIClient, optFns ...func(o *GoogleGenAIOptions)) (*GoogleGenAI, error) {
+	return newGoogleGenAI(&googleGenAIV1Client{client: client}, client, optFns...)
+}
+
+// NewGoogleGenAIBeta creates a new GoogleGenAI chat model with a client of the v1beta API.
+func NewGoogleGenAIBeta(client GoogleGenAIBetaClient, optFns ...func(o *GoogleGenAIOptions)) (*GoogleGenAI, error) {
+	return newGoogleGenAI(client, &googleGenAITokenCounter{client: client}, optFns...)
+}
+
+func newGoogleGenAI(client GoogleGenAIBetaClient, tokenCounter tokenizer.GoogleGenAIClient, optFns ...func(o *GoogleGenAIOptions)) (*GoogleGenAI, error) {
 	opts := GoogleGenAIOptions{
 		CallbackOptions: &schema.CallbackOptions{
 			Verbose: golc.Verbose,
@@ -78,7 +102,7 @@ func NewGoogleGenAI(client GoogleGenAIClient, optFns ...func(o *GoogleGenAIOptio
 	}
 
 	if opts.Tokenizer == nil {
-		opts.Tokenizer = tokenizer.NewGoogleGenAI(client, opts.ModelName)
+		opts.Tokenizer = tokenizer.NewGoogleGenAI(tokenCounter, opts.ModelName)
 	}
 
 	return &GoogleGenAI{
@@ -98,26 +122,15 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 		fn(&opts)
 	}
 
-	contents := []*generativelanguagepb.Content{}
-
-	for _, message := range messages {
-		switch message.Type() {
-		case schema.ChatMessageTypeAI:
-			contents = append(contents, &generativelanguagepb.Content{Role: roleModel, Parts: []*generativelanguagepb.Part{{
-				Data: &generativelanguagepb.Part_Text{Text: message.Content()},
-			}}})
-		case schema.ChatMessageTypeHuman:
-			contents = append(contents, &generativelanguagepb.Content{Role: roleUser, Parts: []*generativelanguagepb.Part{{
-				Data: &generativelanguagepb.Part_Text{Text: message.Content()},
-			}}})
-		default:
-			return nil, fmt.Errorf("unsupported message type: %s", message.Type())
-		}
+	systemInstruction, contents, err := toGoogleGenAIContents(messages)
+	if err != nil {
+		return nil, err
 	}
 
 	req := &generativelanguagepb.GenerateContentRequest{
-		Model:    cm.opts.ModelName,
-		Contents: contents,
+		Model:             cm.opts.ModelName,
+		SystemInstruction: systemInstruction,
+		Contents:          contents,
 		GenerationConfig: &generativelanguagepb.GenerationConfig{
 			CandidateCount:  util.AddrOrNil(cm.opts.CandidateCount),
 			MaxOutputTokens: util.AddrOrNil(cm.opts.MaxOutputTokens),
@@ -128,6 +141,38 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 		},
 	}
 
+	if toolChoice := opts.ResolveToolChoice(); toolChoice != "" {
+		req.Tools = []*generativelanguagepb.Tool{{
+			FunctionDeclarations: util.Map(opts.Functions, func(fd schema.FunctionDefinition, _ int) *generativelanguagepb.FunctionDeclaration {
+				return &generativelanguagepb.FunctionDeclaration{
+					Name:        fd.Name,
+					Description: fd.Description,
+					Parameters: toGoogleGenAISchema(&jsonschema.Schema{
+						Type:       fd.Parameters.Type,
+						Properties: fd.Parameters.Properties,
+						Required:   fd.Parameters.Required,
+					}),
+				}
+			}),
+		}}
+
+		config := &generativelanguagepb.FunctionCallingConfig{}
+
+		switch toolChoice {
+		case schema.ToolChoiceAuto:
+			config.Mode = generativelanguagepb.FunctionCallingConfig_AUTO
+		case schema.ToolChoiceNone:
+			config.Mode = generativelanguagepb.FunctionCallingConfig_NONE
+		case schema.ToolChoiceRequired:
+			config.Mode = generativelanguagepb.FunctionCallingConfig_ANY
+		default:
+			config.Mode = generativelanguagepb.FunctionCallingConfig_ANY
+			config.AllowedFunctionNames = []string{toolChoice}
+		}
+
+		req.ToolConfig = &generativelanguagepb.ToolConfig{FunctionCallingConfig: config}
+	}
+
 	generations := []schema.Generation{}
 
 	if cm.opts.Stream {
@@ -137,6 +182,7 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 		}
 
 		tokens := []string{}
+		parts := []*generativelanguagepb.Part{}
 
 	streamProcessing:
 		for {
@@ -156,6 +202,10 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 				var b strings.Builder
 				for _, p := range res.Candidates[0].Content.Parts {
 					fmt.Fprintf(&b, "%s", p.GetText())
+
+					if p.GetFunctionCall() != nil {
+						parts = append(parts, p)
+					}
 				}
 
 				token := b.String()
@@ -170,7 +220,12 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 			}
 		}
 
-		generations = append(generations, newChatGeneraton(strings.Join(tokens, "")))
+		generation, err := newGoogleGenAIGeneration(strings.Join(tokens, ""), parts)
+		if err != nil {
+			return nil, err
+		}
+
+		generations = append(generations, generation)
 	} else {
 		res, err := cm.client.GenerateContent(ctx, req)
 		if err != nil {
@@ -183,7 +238,12 @@ func (cm *GoogleGenAI) Generate(ctx context.Context, messages schema.ChatMessage
 				fmt.Fprintf(&b, "%s", p.GetText())
 			}
 
-			generations = append(generations, newChatGeneraton(b.String()))
+			generation, err := newGoogleGenAIGeneration(b.String(), c.Content.Parts)
+			if err != nil {
+				return nil, err
+			}
+
+			generations = append(generations, generation)
 		}
 	}
 
@@ -212,3 +272,323 @@ func (cm *GoogleGenAI) Callbacks() []schema.Callback {
 func (cm *GoogleGenAI) InvocationParams() map[string]any {
 	return util.StructToMap(cm.opts)
 }
+
+// newGoogleGenAIGeneration creates a generation from the text and the function calls of the parts.
+// GoogleGenAI does not return function call IDs, so the IDs are derived from the position of the calls.
+func newGoogleGenAIGeneration(text string, parts []*generativelanguagepb.Part) (schema.Generation, error) {
+	toolCalls := []schema.ToolCall{}
+
+	for _, p := range parts {
+		fc := p.GetFunctionCall()
+		if fc == nil {
+			continue
+		}
+
+		arguments, err := json.Marshal(fc.GetArgs().AsMap())
+		if err != nil {
+			return schema.Generation{}, err
+		}
+
+		toolCalls = append(toolCalls, schema.ToolCall{
+			ID:        fmt.Sprintf("call_%d", len(toolCalls)),
+			Name:      fc.Name,
+			Arguments: string(arguments),
+		})
+	}
+
+	return schema.Generation{
+		Text:    text,
+		Message: newAIChatMessageWithToolCalls(text, toolCalls),
+	}, nil
+}
+
+// toGoogleGenAIContents converts the chat messages to the system instruction and the contents of
+// a GoogleGenAI request. Consecutive messages of the same role are merged into one content.
+func toGoogleGenAIContents(messages schema.ChatMessages) (*generativelanguagepb.Content, []*generativelanguagepb.Content, error) {
+	var systemInstruction *generativelanguagepb.Content
+
+	contents := []*generativelanguagepb.Content{}
+
+	appendParts := func(role string, parts ...*generativelanguagepb.Part) {
+		if len(parts) == 0 {
+			return
+		}
+
+		if n := len(contents); n > 0 && contents[n-1].Role == role {
+			contents[n-1].Parts = append(contents[n-1].Parts, parts...)
+			return
+		}
+
+		contents = append(contents, &generativelanguagepb.Content{Role: role, Parts: parts})
+	}
+
+	// GoogleGenAI does not use function call IDs, the names of the calls are looked up for the responses.
+	toolCallNames := map[string]string{}
+
+	for _, message := range messages {
+		switch m := message.(type) {
+		case *schema.SystemChatMessage:
+			if systemInstruction == nil {
+				systemInstruction = &generativelanguagepb.Content{}
+			}
+
+			systemInstruction.Parts = append(systemInstruction.Parts, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_Text{Text: m.Content()},
+			})
+		case *schema.HumanChatMessage:
+			appendParts(roleUser, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_Text{Text: m.Content()},
+			})
+		case *schema.AIChatMessage:
+			parts := []*generativelanguagepb.Part{}
+			if m.Content() != "" {
+				parts = append(parts, &generativelanguagepb.Part{
+					Data: &generativelanguagepb.Part_Text{Text: m.Content()},
+				})
+			}
+
+			for _, tc := range m.ToolCalls() {
+				toolCallNames[tc.ID] = tc.Name
+
+				arguments := map[string]any{}
+				if tc.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Arguments), &arguments); err != nil {
+						return nil, nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.Name, err)
+					}
+				}
+
+				args, err := structpb.NewStruct(arguments)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				parts = append(parts, &generativelanguagepb.Part{
+					Data: &generativelanguagepb.Part_FunctionCall{FunctionCall: &generativelanguagepb.FunctionCall{
+						Name: tc.Name,
+						Args: args,
+					}},
+				})
+			}
+
+			appendParts(roleModel, parts...)
+		case *schema.ToolChatMessage:
+			name := m.Name()
+			if name == "" {
+				name = toolCallNames[m.ToolCallID()]
+			}
+
+			response, err := structpb.NewStruct(map[string]any{
+				"name":    name,
+				"content": m.Content(),
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			appendParts(roleFunction, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_FunctionResponse{FunctionResponse: &generativelanguagepb.FunctionResponse{
+					Name:     name,
+					Response: response,
+				}},
+			})
+		default:
+			return nil, nil, fmt.Errorf("unsupported message type: %s", message.Type())
+		}
+	}
+
+	return systemInstruction, contents, nil
+}
+
+// toGoogleGenAISchema converts a JSON schema to the OpenAPI schema subset supported by GoogleGenAI.
+func toGoogleGenAISchema(s *jsonschema.Schema) *generativelanguagepb.Schema {
+	if s == nil {
+		return nil
+	}
+
+	gs := &generativelanguagepb.Schema{
+		Format:      s.Format,
+		Description: s.Description,
+		Nullable:    s.Nullable,
+		Items:       toGoogleGenAISchema(s.Items),
+		Required:    s.Required,
+	}
+
+	switch s.Type {
+	case "string":
+		gs.Type = generativelanguagepb.Type_STRING
+	case "number":
+		gs.Type = generativelanguagepb.Type_NUMBER
+	case "integer":
+		gs.Type = generativelanguagepb.Type_INTEGER
+	case "boolean":
+		gs.Type = generativelanguagepb.Type_BOOLEAN
+	case "array":
+		gs.Type = generativelanguagepb.Type_ARRAY
+	case "object":
+		gs.Type = generativelanguagepb.Type_OBJECT
+	}
+
+	for _, e := range s.Enum {
+		gs.Enum = append(gs.Enum, fmt.Sprint(e))
+	}
+
+	if len(s.Properties) > 0 {
+		gs.Properties = make(map[string]*generativelanguagepb.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			gs.Properties[name] = toGoogleGenAISchema(property)
+		}
+	}
+
+	return gs
+}
+
+// googleGenAITokenCounter adapts the v1beta client to the v1 token counting of the tokenizer.
+type googleGenAITokenCounter struct {
+	client GoogleGenAIBetaClient
+}
+
+// CountTokens counts the tokens of the text parts of the request contents.
+func (c *googleGenAITokenCounter) CountTokens(ctx context.Context, req *generativelanguagepbv1.CountTokensRequest, opts ...gax.CallOption) (*generativelanguagepbv1.CountTokensResponse, error) {
+	contents := make([]*generativelanguagepb.Content, len(req.Contents))
+
+	for i, content := range req.Contents {
+		contents[i] = &generativelanguagepb.Content{Role: content.Role}
+
+		for _, p := range content.Parts {
+			contents[i].Parts = append(contents[i].Parts, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_Text{Text: p.GetText()},
+			})
+		}
+	}
+
+	res, err := c.client.CountTokens(ctx, &generativelanguagepb.CountTokensRequest{
+		Model:    req.Model,
+		Contents: contents,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generativelanguagepbv1.CountTokensResponse{TotalTokens: res.TotalTokens}, nil
+}
+
+// googleGenAIV1Client adapts the v1 client to the v1beta client. Requests that use features of
+// the v1beta API are rejected.
+type googleGenAIV1Client struct {
+	client GoogleGenAIClient
+}
+
+// GenerateContent generates content with the v1 client.
+func (c *googleGenAIV1Client) GenerateContent(ctx context.Context, req *generativelanguagepb.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepb.GenerateContentResponse, error) {
+	v1Req, err := toGoogleGenAIV1Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.GenerateContent(ctx, v1Req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromGoogleGenAIV1Response(res), nil
+}
+
+// StreamGenerateContent streams content with the v1 client.
+func (c *googleGenAIV1Client) StreamGenerateContent(ctx context.Context, req *generativelanguagepb.GenerateContentRequest, opts ...gax.CallOption) (generativelanguagepb.GenerativeService_StreamGenerateContentClient, error) {
+	v1Req, err := toGoogleGenAIV1Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := c.client.StreamGenerateContent(ctx, v1Req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &googleGenAIV1Stream{GenerativeService_StreamGenerateContentClient: stream}, nil
+}
+
+// CountTokens is not used, the tokenizer counts the tokens with the v1 client directly.
+func (c *googleGenAIV1Client) CountTokens(ctx context.Context, req *generativelanguagepb.CountTokensRequest, opts ...gax.CallOption) (*generativelanguagepb.CountTokensResponse, error) {
+	return nil, errors.New("token counting is not supported by the v1 client adapter")
+}
+
+// googleGenAIV1Stream adapts the stream of the v1 client to the stream of the v1beta client.
+type googleGenAIV1Stream struct {
+	generativelanguagepbv1.GenerativeService_StreamGenerateContentClient
+}
+
+// Recv receives the next response of the stream.
+func (s *googleGenAIV1Stream) Recv() (*generativelanguagepb.GenerateContentResponse, error) {
+	res, err := s.GenerativeService_StreamGenerateContentClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return fromGoogleGenAIV1Response(res), nil
+}
+
+// toGoogleGenAIV1Request converts a v1beta request to a v1 request. Only text parts are supported.
+func toGoogleGenAIV1Request(req *generativelanguagepb.GenerateContentRequest) (*generativelanguagepbv1.GenerateContentRequest, error) {
+	if req.SystemInstruction != nil {
+		return nil, fmt.Errorf("unsupported message type: %s, use NewGoogleGenAIBeta for system messages", schema.ChatMessageTypeSystem)
+	}
+
+	if len(req.Tools) > 0 {
+		return nil, errors.New("function calling is not supported by the v1 API, use NewGoogleGenAIBeta")
+	}
+
+	contents := make([]*generativelanguagepbv1.Content, len(req.Contents))
+
+	for i, content := range req.Contents {
+		contents[i] = &generativelanguagepbv1.Content{Role: content.Role}
+
+		for _, p := range content.Parts {
+			text, ok := p.Data.(*generativelanguagepb.Part_Text)
+			if !ok {
+				return nil, fmt.Errorf("unsupported part %T, use NewGoogleGenAIBeta for function calling", p.Data)
+			}
+
+			contents[i].Parts = append(contents[i].Parts, &generativelanguagepbv1.Part{
+				Data: &generativelanguagepbv1.Part_Text{Text: text.Text},
+			})
+		}
+	}
+
+	v1Req := &generativelanguagepbv1.GenerateContentRequest{
+		Model:    req.Model,
+		Contents: contents,
+	}
+
+	if config := req.GenerationConfig; config != nil {
+		v1Req.GenerationConfig = &generativelanguagepbv1.GenerationConfig{
+			CandidateCount:  config.CandidateCount,
+			MaxOutputTokens: config.MaxOutputTokens,
+			Temperature:     config.Temperature,
+			TopP:            config.TopP,
+			TopK:            config.TopK,
+			StopSequences:   config.StopSequences,
+		}
+	}
+
+	return v1Req, nil
+}
+
+// fromGoogleGenAIV1Response converts the text candidates of a v1 response to a v1beta response.
+func fromGoogleGenAIV1Response(res *generativelanguagepbv1.GenerateContentResponse) *generativelanguagepb.GenerateContentResponse {
+	candidates := make([]*generativelanguagepb.Candidate, len(res.Candidates))
+
+	for i, c := range res.Candidates {
+		content := &generativelanguagepb.Content{Role: c.GetContent().GetRole()}
+
+		for _, p := range c.GetContent().GetParts() {
+			content.Parts = append(content.Parts, &generativelanguagepb.Part{
+				Data: &generativelanguagepb.Part_Text{Text: p.GetText()},
+			})
+		}
+
+		candidates[i] = &generativelanguagepb.Candidate{Content: content}
+	}
+
+	return &generativelanguagepb.GenerateContentResponse{Candidates: candidates}
+}
@@ -7,9 +7,12 @@ import (
 	"testing"
 
 	"cloud.google.com/go/ai/generativelanguage/apiv1/generativelanguagepb"
+	generativelanguagepbbeta "cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
 	"github.com/googleapis/gax-go/v2"
+	"github.com/hupe1980/golc/integration/jsonschema"
 	"github.com/hupe1980/golc/schema"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestGoogleGenAI(t *testing.T) {
@@ -57,6 +60,15 @@ func TestGoogleGenAI(t *testing.T) {
 		assert.ErrorContains(t, err, "google genai error")
 	})
 
+	t.Run("Generate_ToolCallsUnsupported", func(t *testing.T) {
+		_, err := model.Generate(context.Background(), []schema.ChatMessage{
+			schema.NewHumanChatMessage("Weather in Berlin?"),
+		}, func(o *schema.GenerateOptions) {
+			o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+		})
+		assert.ErrorContains(t, err, "NewGoogleGenAIBeta")
+	})
+
 	// Test the Type method
 	t.Run("Type", func(t *testing.T) {
 		expectedType := "chatmodel.GoogleGenAI"
@@ -115,3 +127,86 @@ func (m *mockGoogleGenAIClient) CountTokens(ctx context.Context, req *generative
 
 	return nil, errors.New("CountTokens not implemented in the mock")
 }
+
+func TestGoogleGenAIBeta(t *testing.T) {
+	betaClient := &mockGoogleGenAIBetaClient{}
+	betaModel, err := NewGoogleGenAIBeta(betaClient)
+	assert.NoError(t, err)
+
+	t.Run("Generate_ToolCalls", func(t *testing.T) {
+		betaClient.GenerateContentFn = func(ctx context.Context, req *generativelanguagepbbeta.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepbbeta.GenerateContentResponse, error) {
+			assert.Equal(t, "system", req.SystemInstruction.Parts[0].GetText())
+			assert.Equal(t, generativelanguagepbbeta.FunctionCallingConfig_ANY, req.ToolConfig.FunctionCallingConfig.Mode)
+			assert.Equal(t, []string{"weather"}, req.ToolConfig.FunctionCallingConfig.AllowedFunctionNames)
+			assert.Equal(t, "weather", req.Tools[0].FunctionDeclarations[0].Name)
+			assert.Equal(t, generativelanguagepbbeta.Type_STRING, req.Tools[0].FunctionDeclarations[0].Parameters.Properties["city"].Type)
+			assert.Len(t, req.Contents, 3)
+			assert.Equal(t, "Berlin", req.Contents[1].Parts[0].GetFunctionCall().GetArgs().AsMap()["city"])
+			assert.Equal(t, "function", req.Contents[2].Role)
+			assert.Equal(t, "weather", req.Contents[2].Parts[0].GetFunctionResponse().Name)
+
+			args, err := structpb.NewStruct(map[string]any{"city": "Paris"})
+			assert.NoError(t, err)
+
+			return &generativelanguagepbbeta.GenerateContentResponse{
+				Candidates: []*generativelanguagepbbeta.Candidate{{
+					Content: &generativelanguagepbbeta.Content{
+						Parts: []*generativelanguagepbbeta.Part{{Data: &generativelanguagepbbeta.Part_FunctionCall{
+							FunctionCall: &generativelanguagepbbeta.FunctionCall{Name: "weather", Args: args},
+						}}},
+					},
+				}},
+			}, nil
+		}
+
+		chatMessages := []schema.ChatMessage{
+			schema.NewSystemChatMessage("system"),
+			schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{{ID: "call_0", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+			}),
+			schema.NewToolChatMessage("call_0", "weather", "sunny"),
+		}
+
+		result, err := betaModel.Generate(context.Background(), chatMessages, func(o *schema.GenerateOptions) {
+			o.Functions = []schema.FunctionDefinition{{
+				Name: "weather",
+				Parameters: schema.FunctionDefinitionParameters{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{"city": {Type: "string"}},
+					Required:   []string{"city"},
+				},
+			}}
+			o.ToolChoice = "weather"
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, []schema.ToolCall{{ID: "call_0", Name: "weather", Arguments: `{"city":"Paris"}`}}, aiMsg.ToolCalls())
+	})
+}
+
+// mockGoogleGenAIBetaClient is a custom mock implementation of the GoogleGenAIBetaClient interface.
+type mockGoogleGenAIBetaClient struct {
+	GenerateContentFn func(ctx context.Context, req *generativelanguagepbbeta.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepbbeta.GenerateContentResponse, error)
+}
+
+// GenerateContent is a mocked method for the GenerateContent function.
+func (m *mockGoogleGenAIBetaClient) GenerateContent(ctx context.Context, req *generativelanguagepbbeta.GenerateContentRequest, opts ...gax.CallOption) (*generativelanguagepbbeta.GenerateContentResponse, error) {
+	if m.GenerateContentFn != nil {
+		return m.GenerateContentFn(ctx, req, opts...)
+	}
+
+	return nil, errors.New("GenerateContent not implemented in the mock")
+}
+
+// StreamGenerateContent is a mocked method for the StreamGenerateContent function.
+func (m *mockGoogleGenAIBetaClient) StreamGenerateContent(ctx context.Context, req *generativelanguagepbbeta.GenerateContentRequest, opts ...gax.CallOption) (generativelanguagepbbeta.GenerativeService_StreamGenerateContentClient, error) {
+	return nil, errors.New("StreamGenerateContent not implemented in the mock")
+}
+
+// CountTokens is a mocked method for the CountTokens function.
+func (m *mockGoogleGenAIBetaClient) CountTokens(ctx context.Context, req *generativelanguagepbbeta.CountTokensRequest, opts ...gax.CallOption) (*generativelanguagepbbeta.CountTokensResponse, error) {
+	return nil, errors.New("CountTokens not implemented in the mock")
+}
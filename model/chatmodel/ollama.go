@@ -2,6 +2,7 @@ package chatmodel
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -104,22 +105,60 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 	ollamaMessages := make([]ollama.Message, len(messages))
 
 	for i, m := range messages {
-		switch m.Type() { // nolint exhaustive
-		case schema.ChatMessageTypeSystem:
-			ollamaMessages[i] = ollama.Message{Role: "system", Content: m.Content()}
-		case schema.ChatMessageTypeAI:
-			ollamaMessages[i] = ollama.Message{Role: "assistant", Content: m.Content()}
-		case schema.ChatMessageTypeHuman:
-			ollamaMessages[i] = ollama.Message{Role: "user", Content: m.Content()}
+		switch v := m.(type) {
+		case *schema.SystemChatMessage:
+			ollamaMessages[i] = ollama.Message{Role: "system", Content: v.Content()}
+		case *schema.AIChatMessage:
+			toolCalls := make([]ollama.ToolCall, 0, len(v.ToolCalls()))
+
+			for _, tc := range v.ToolCalls() {
+				arguments := map[string]any{}
+				if tc.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Arguments), &arguments); err != nil {
+						return nil, fmt.Errorf("invalid arguments of tool call %s: %w", tc.Name, err)
+					}
+				}
+
+				toolCalls = append(toolCalls, ollama.ToolCall{
+					Function: ollama.ToolCallFunction{Name: tc.Name, Arguments: arguments},
+				})
+			}
+
+			ollamaMessages[i] = ollama.Message{Role: "assistant", Content: v.Content(), ToolCalls: toolCalls}
+		case *schema.HumanChatMessage:
+			ollamaMessages[i] = ollama.Message{Role: "user", Content: v.Content()}
+		case *schema.ToolChatMessage:
+			ollamaMessages[i] = ollama.Message{Role: "tool", Content: v.Content()}
 		default:
 			return nil, fmt.Errorf("unknown message type: %s", m.Type())
 		}
 	}
 
+	// Ollama does not support forcing tool calls, tools are offered unless disabled.
+	toolChoice := opts.ResolveToolChoice()
+	if toolChoice != "" && toolChoice != schema.ToolChoiceAuto && toolChoice != schema.ToolChoiceNone {
+		return nil, fmt.Errorf("ollama does not support tool choice %q, only %q and %q are supported", toolChoice, schema.ToolChoiceAuto, schema.ToolChoiceNone)
+	}
+
+	var tools []ollama.Tool
+	if toolChoice != "" && toolChoice != schema.ToolChoiceNone {
+		tools = util.Map(opts.Functions, func(fd schema.FunctionDefinition, _ int) ollama.Tool {
+			return ollama.Tool{
+				Type: "function",
+				Function: ollama.ToolFunction{
+					Name:        fd.Name,
+					Description: fd.Description,
+					Parameters:  fd.Parameters,
+				},
+			}
+		})
+	}
+
 	req := &ollama.ChatRequest{
 		Model:    cm.opts.ModelName,
 		Messages: ollamaMessages,
 		Stream:   util.AddrOrNil(false),
+		Tools:    tools,
 		Options: ollama.Options{
 			Temperature:      cm.opts.Temperature,
 			NumPredict:       cm.opts.MaxTokens,
@@ -131,7 +170,10 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 		},
 	}
 
-	content := ""
+	var (
+		content   string
+		toolCalls []ollama.ToolCall
+	)
 
 	if cm.opts.Stream {
 		req.Stream = util.PTR(true)
@@ -168,6 +210,7 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 					}
 
 					tokens = append(tokens, res.Message.Content)
+					toolCalls = append(toolCalls, res.Message.ToolCalls...)
 				}
 				// else {
 				// 	// TODO Metrics, EvalCount, ... -> LLMOutput?
@@ -183,11 +226,31 @@ func (cm *Ollama) Generate(ctx context.Context, messages schema.ChatMessages, op
 		}
 
 		content = res.Message.Content
+		toolCalls = res.Message.ToolCalls
+	}
+
+	// Ollama does not return tool call IDs, so the IDs are derived from the position of the calls.
+	schemaToolCalls := make([]schema.ToolCall, 0, len(toolCalls))
+
+	for i, tc := range toolCalls {
+		arguments, err := json.Marshal(tc.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaToolCalls = append(schemaToolCalls, schema.ToolCall{
+			ID:        fmt.Sprintf("call_%d", i),
+			Name:      tc.Function.Name,
+			Arguments: string(arguments),
+		})
 	}
 
 	return &schema.ModelResult{
-		Generations: []schema.Generation{newChatGeneraton(content)},
-		LLMOutput:   map[string]any{},
+		Generations: []schema.Generation{{
+			Text:    content,
+			Message: newAIChatMessageWithToolCalls(content, schemaToolCalls),
+		}},
+		LLMOutput: map[string]any{},
 	}, nil
 }
 
@@ -56,6 +56,48 @@ func TestOllama(t *testing.T) {
 			assert.Equal(t, "I can help you with that.", result.Generations[0].Text)
 		})
 
+		t.Run("ToolCalls", func(t *testing.T) {
+			t.Parallel()
+
+			mockClient := &mockOllamaClient{
+				GenerateChatFunc: func(ctx context.Context, req *ollama.ChatRequest) (*ollama.ChatResponse, error) {
+					assert.Len(t, req.Tools, 1)
+					assert.Equal(t, "weather", req.Tools[0].Function.Name)
+					assert.Equal(t, []ollama.ToolCall{{Function: ollama.ToolCallFunction{Name: "weather", Arguments: map[string]any{"city": "Berlin"}}}}, req.Messages[1].ToolCalls)
+					assert.Equal(t, ollama.Message{Role: "tool", Content: "sunny"}, req.Messages[2])
+
+					return &ollama.ChatResponse{
+						Message: &ollama.Message{
+							Role: "assistant",
+							ToolCalls: []ollama.ToolCall{
+								{Function: ollama.ToolCallFunction{Name: "weather", Arguments: map[string]any{"city": "Paris"}}},
+							},
+						},
+					}, nil
+				},
+			}
+
+			ollamaModel, err := NewOllama(mockClient)
+			assert.NoError(t, err)
+
+			messages := []schema.ChatMessage{
+				schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+				schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+					o.ToolCalls = []schema.ToolCall{{ID: "call_0", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+				}),
+				schema.NewToolChatMessage("call_0", "weather", "sunny"),
+			}
+
+			result, err := ollamaModel.Generate(context.Background(), messages, func(o *schema.GenerateOptions) {
+				o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+			})
+			assert.NoError(t, err)
+
+			aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+			assert.True(t, ok)
+			assert.Equal(t, []schema.ToolCall{{ID: "call_0", Name: "weather", Arguments: `{"city":"Paris"}`}}, aiMsg.ToolCalls())
+		})
+
 		t.Run("Error", func(t *testing.T) {
 			t.Parallel()
 
@@ -77,6 +119,23 @@ func TestOllama(t *testing.T) {
 			assert.Error(t, err)
 			assert.Nil(t, result)
 		})
+
+		t.Run("Unsupported tool choice", func(t *testing.T) {
+			t.Parallel()
+
+			ollamaModel, err := NewOllama(&mockOllamaClient{})
+			assert.NoError(t, err)
+
+			for _, toolChoice := range []string{schema.ToolChoiceRequired, "weather"} {
+				_, err = ollamaModel.Generate(context.Background(), schema.ChatMessages{
+					schema.NewHumanChatMessage("Weather in Berlin?"),
+				}, func(o *schema.GenerateOptions) {
+					o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+					o.ToolChoice = toolChoice
+				})
+				assert.ErrorContains(t, err, "tool choice")
+			}
+		})
 	})
 
 	t.Run("Type", func(t *testing.T) {
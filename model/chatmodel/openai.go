@@ -154,15 +154,10 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 		FrequencyPenalty: cm.opts.PresencePenalty,
 		Messages:         openAIMessages,
 		Tools:            tools,
+		ToolChoice:       integration.ToOpenAIToolChoice(opts.ResolveToolChoice()),
 		Stop:             opts.Stop,
 	}
 
-	if opts.ForceFunctionCall && len(opts.Functions) == 1 {
-		request.ToolChoice = openai.ToolChoice{Type: openai.ToolTypeFunction, Function: openai.ToolFunction{
-			Name: opts.Functions[0].Name,
-		}}
-	}
-
 	choices := []openai.ChatCompletionChoice{}
 	tokenUsage := make(map[string]int)
 
@@ -179,7 +174,7 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 		var (
 			role         string
 			tokens       []string
-			functionCall *openai.FunctionCall
+			toolCalls    []openai.ToolCall
 			finishReason openai.FinishReason
 		)
 
@@ -204,21 +199,25 @@ func (cm *OpenAI) Generate(ctx context.Context, messages schema.ChatMessages, op
 					return nil, err
 				}
 
-				role = res.Choices[0].Delta.Role
+				if res.Choices[0].Delta.Role != "" {
+					role = res.Choices[0].Delta.Role
+				}
+
 				tokens = append(tokens, res.Choices[0].Delta.Content)
-				finishReason = res.Choices[0].FinishReason
 
-				if len(res.Choices[0].Delta.ToolCalls) > 0 {
-					functionCall = &res.Choices[0].Delta.ToolCalls[0].Function
+				if res.Choices[0].FinishReason != "" {
+					finishReason = res.Choices[0].FinishReason
 				}
+
+				toolCalls = mergeOpenAIToolCallDeltas(toolCalls, res.Choices[0].Delta.ToolCalls)
 			}
 		}
 
 		choices = append(choices, openai.ChatCompletionChoice{
 			Message: openai.ChatCompletionMessage{
-				Role:         role,
-				Content:      strings.Join(tokens, ""),
-				FunctionCall: functionCall,
+				Role:      role,
+				Content:   strings.Join(tokens, ""),
+				ToolCalls: toolCalls,
 			},
 			FinishReason: finishReason,
 		})
@@ -319,12 +318,15 @@ func openAIResponseToChatMessage(msg openai.ChatCompletionMessage) schema.ChatMe
 		return schema.NewHumanChatMessage(msg.Content)
 	case "assistant":
 		if len(msg.ToolCalls) > 0 {
-			return schema.NewAIChatMessage(msg.Content, func(o *schema.ChatMessageExtension) {
-				o.FunctionCall = &schema.FunctionCall{
-					Name:      msg.ToolCalls[0].Function.Name,
-					Arguments: msg.ToolCalls[0].Function.Arguments,
+			toolCalls := util.Map(msg.ToolCalls, func(tc openai.ToolCall, _ int) schema.ToolCall {
+				return schema.ToolCall{
+					ID:        tc.ID,
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
 				}
 			})
+
+			return newAIChatMessageWithToolCalls(msg.Content, toolCalls)
 		}
 
 		return schema.NewAIChatMessage(msg.Content)
@@ -332,7 +334,33 @@ func openAIResponseToChatMessage(msg openai.ChatCompletionMessage) schema.ChatMe
 		return schema.NewSystemChatMessage(msg.Content)
 	case "function":
 		return schema.NewFunctionChatMessage(msg.Content, msg.Name)
+	case "tool":
+		return schema.NewToolChatMessage(msg.ToolCallID, msg.Name, msg.Content)
 	}
 
 	return schema.NewGenericChatMessage(msg.Content, "unknown")
 }
+
+// mergeOpenAIToolCallDeltas merges the tool call deltas of a stream chunk into the tool calls
+// received so far. Deltas are correlated by their index, arguments arrive in several chunks.
+func mergeOpenAIToolCallDeltas(toolCalls []openai.ToolCall, deltas []openai.ToolCall) []openai.ToolCall {
+	for _, delta := range deltas {
+		index := len(toolCalls)
+		if delta.Index != nil {
+			index = *delta.Index
+		}
+
+		for len(toolCalls) <= index {
+			toolCalls = append(toolCalls, openai.ToolCall{Type: openai.ToolTypeFunction})
+		}
+
+		if delta.ID != "" {
+			toolCalls[index].ID = delta.ID
+		}
+
+		toolCalls[index].Function.Name += delta.Function.Name
+		toolCalls[index].Function.Arguments += delta.Function.Arguments
+	}
+
+	return toolCalls
+}
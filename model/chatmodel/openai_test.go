@@ -86,6 +86,52 @@ func TestOpenAI_Generate(t *testing.T) {
 		assert.EqualError(t, errors.New("All attempts fail:\n#1: generation error"), err.Error())
 		assert.Nil(t, result)
 	})
+
+	// Test case for parallel tool calls
+	t.Run("ToolCalls", func(t *testing.T) {
+		ctx := context.Background()
+		messages := schema.ChatMessages{
+			schema.NewHumanChatMessage("Weather in Berlin and Paris?"),
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{{ID: "call_1", Name: "weather", Arguments: `{"city":"Berlin"}`}}
+			}),
+			schema.NewToolChatMessage("call_1", "weather", "sunny"),
+		}
+
+		mockClient.createChatCompletionFn = func(ctx context.Context, request openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+			assert.Equal(t, "required", request.ToolChoice)
+			assert.Len(t, request.Tools, 1)
+			assert.Equal(t, []openai.ToolCall{{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Berlin"}`}}}, request.Messages[1].ToolCalls)
+			assert.Equal(t, openai.ChatCompletionMessage{Role: "tool", Content: "sunny", Name: "weather", ToolCallID: "call_1"}, request.Messages[2])
+
+			return openai.ChatCompletionResponse{
+				Choices: []openai.ChatCompletionChoice{{
+					Message: openai.ChatCompletionMessage{
+						Role: "assistant",
+						ToolCalls: []openai.ToolCall{
+							{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}},
+							{ID: "call_3", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Rome"}`}},
+						},
+					},
+				}},
+			}, nil
+		}
+
+		result, err := openAI.Generate(ctx, messages, func(o *schema.GenerateOptions) {
+			o.Functions = []schema.FunctionDefinition{{Name: "weather"}}
+			o.ToolChoice = schema.ToolChoiceRequired
+		})
+		assert.NoError(t, err)
+
+		aiMsg, ok := result.Generations[0].Message.(*schema.AIChatMessage)
+		assert.True(t, ok)
+		assert.Equal(t, []schema.ToolCall{
+			{ID: "call_2", Name: "weather", Arguments: `{"city":"Paris"}`},
+			{ID: "call_3", Name: "weather", Arguments: `{"city":"Rome"}`},
+		}, aiMsg.ToolCalls())
+		assert.Equal(t, "weather", aiMsg.Extension().FunctionCall.Name)
+	})
+
 	// Test case for Type method
 	t.Run("Type", func(t *testing.T) {
 		assert.Equal(t, "chatmodel.OpenAI", openAI.Type())
@@ -154,3 +200,20 @@ func TestOpenAIResponseToChatMessage(t *testing.T) {
 	assert.IsType(t, &schema.GenericChatMessage{}, unknownChatMessage)
 	assert.Equal(t, "Unknown message", unknownChatMessage.Content())
 }
+
+func TestMergeOpenAIToolCallDeltas(t *testing.T) {
+	index := func(i int) *int { return &i }
+
+	toolCalls := mergeOpenAIToolCallDeltas(nil, []openai.ToolCall{
+		{Index: index(0), ID: "call_1", Function: openai.FunctionCall{Name: "weather", Arguments: `{"ci`}},
+	})
+	toolCalls = mergeOpenAIToolCallDeltas(toolCalls, []openai.ToolCall{
+		{Index: index(0), Function: openai.FunctionCall{Arguments: `ty":"Berlin"}`}},
+		{Index: index(1), ID: "call_2", Function: openai.FunctionCall{Name: "time", Arguments: `{}`}},
+	})
+
+	assert.Equal(t, []openai.ToolCall{
+		{ID: "call_1", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "weather", Arguments: `{"city":"Berlin"}`}},
+		{ID: "call_2", Type: openai.ToolTypeFunction, Function: openai.FunctionCall{Name: "time", Arguments: `{}`}},
+	}, toolCalls)
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+var statusCodeRegex = regexp.MustCompile(`status code:? (\d{3})`)
+
+var (
+	rateLimitMessages = []string{
+		"rate limit",
+		"too many requests",
+		"throttl",
+		"quota exceeded",
+		"overloaded",
+	}
+
+	contextLengthMessages = []string{
+		"context length",
+		"context_length_exceeded",
+		"maximum context",
+		"too many tokens",
+		"prompt is too long",
+		"input is too long",
+	}
+)
+
+// IsRateLimitError reports whether the error is caused by a rate limit of the provider.
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if code, ok := statusCode(err); ok && code == http.StatusTooManyRequests {
+		return true
+	}
+
+	return containsAny(err.Error(), rateLimitMessages)
+}
+
+// IsServerError reports whether the error is caused by a server error (5xx) or a timeout of the provider.
+func IsServerError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if code, ok := statusCode(err); ok {
+		return code >= http.StatusInternalServerError || code == http.StatusRequestTimeout
+	}
+
+	return false
+}
+
+// IsContextLengthError reports whether the error is caused by a prompt exceeding the context length of the model.
+func IsContextLengthError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return containsAny(err.Error(), contextLengthMessages)
+}
+
+// IsRetryableError reports whether the error is a rate limit, server or context length error.
+// Cancelled contexts are never retryable.
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	return IsRateLimitError(err) || IsServerError(err) || IsContextLengthError(err)
+}
+
+// statusCode extracts the HTTP status code of a provider error.
+func statusCode(err error) (int, bool) {
+	apiErr := &openai.APIError{}
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode > 0 {
+		return apiErr.HTTPStatusCode, true
+	}
+
+	reqErr := &openai.RequestError{}
+	if errors.As(err, &reqErr) && reqErr.HTTPStatusCode > 0 {
+		return reqErr.HTTPStatusCode, true
+	}
+
+	var httpErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &httpErr) {
+		return httpErr.HTTPStatusCode(), true
+	}
+
+	if matches := statusCodeRegex.FindStringSubmatch(err.Error()); len(matches) == 2 {
+		code, _ := strconv.Atoi(matches[1])
+		return code, true
+	}
+
+	return 0, false
+}
+
+func containsAny(s string, substrs []string) bool {
+	s = strings.ToLower(s)
+
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+
+	return false
+}
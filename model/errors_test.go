@@ -0,0 +1,35 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Nil", nil, false},
+		{"OpenAI rate limit", &openai.APIError{HTTPStatusCode: 429, Message: "slow down"}, true},
+		{"OpenAI server error", fmt.Errorf("wrapped: %w", &openai.RequestError{HTTPStatusCode: 503, Err: errors.New("unavailable")}), true},
+		{"OpenAI bad request", &openai.APIError{HTTPStatusCode: 400, Message: "invalid"}, false},
+		{"Status code in message", errors.New("completion API returned unexpected status code: 502"), true},
+		{"Throttling", errors.New("ThrottlingException: Rate exceeded"), true},
+		{"Context length", errors.New("prompt is too long: 200000 tokens > 100000 maximum"), true},
+		{"Cancelled", context.Canceled, false},
+		{"Other", errors.New("invalid argument"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsRetryableError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// FallbacksOptions contains options for models with fallbacks.
+type FallbacksOptions struct {
+	// FallbackIf decides whether the next model is tried after an error. It defaults to IsRetryableError.
+	FallbackIf func(err error) bool
+}
+
+// WithFallbacks returns a model that switches to the next fallback if the primary model fails with
+// a retryable error. The primary model and all fallbacks must either be LLMs or chat models.
+func WithFallbacks(primary schema.Model, fallbacks ...schema.Model) (schema.Model, error) {
+	return WithFallbacksOptions(primary, fallbacks)
+}
+
+// WithFallbacksOptions is like WithFallbacks, but accepts options for the fallbacks.
+func WithFallbacksOptions(primary schema.Model, fallbacks []schema.Model, optFns ...func(o *FallbacksOptions)) (schema.Model, error) {
+	switch p := primary.(type) {
+	case schema.LLM:
+		llms := make([]schema.LLM, len(fallbacks))
+
+		for i, f := range fallbacks {
+			llm, ok := f.(schema.LLM)
+			if !ok {
+				return nil, errors.New("fallback of an llm must be an llm")
+			}
+
+			llms[i] = llm
+		}
+
+		return NewLLMWithFallbacks(p, llms, optFns...), nil
+	case schema.ChatModel:
+		chatModels := make([]schema.ChatModel, len(fallbacks))
+
+		for i, f := range fallbacks {
+			chatModel, ok := f.(schema.ChatModel)
+			if !ok {
+				return nil, errors.New("fallback of a chat model must be a chat model")
+			}
+
+			chatModels[i] = chatModel
+		}
+
+		return NewChatModelWithFallbacks(p, chatModels, optFns...), nil
+	default:
+		return nil, errors.New("invalid model type")
+	}
+}
+
+// Compile time check to ensure LLMWithFallbacks satisfies the LLM interface.
+var _ schema.LLM = (*LLMWithFallbacks)(nil)
+
+// LLMWithFallbacks is an LLM that switches to the next fallback if the current LLM fails.
+// Type and InvocationParams report the LLM that produced the last result.
+type LLMWithFallbacks struct {
+	schema.LLM
+	fallbacks []schema.LLM
+	opts      FallbacksOptions
+	answered  atomic.Int64
+}
+
+// NewLLMWithFallbacks creates a new LLM with fallbacks.
+func NewLLMWithFallbacks(primary schema.LLM, fallbacks []schema.LLM, optFns ...func(o *FallbacksOptions)) *LLMWithFallbacks {
+	opts := FallbacksOptions{
+		FallbackIf: IsRetryableError,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &LLMWithFallbacks{
+		LLM:       primary,
+		fallbacks: fallbacks,
+		opts:      opts,
+	}
+}
+
+// Generate generates text with the primary LLM and the fallbacks in order, until an LLM succeeds.
+func (l *LLMWithFallbacks) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	llms := append([]schema.LLM{l.LLM}, l.fallbacks...)
+
+	return generateWithFallbacks(ctx, len(llms), l.opts, &l.answered, func(i int) (*schema.ModelResult, error) {
+		return llms[i].Generate(ctx, prompt, optFns...)
+	})
+}
+
+// Type returns the type of the LLM that produced the last result.
+func (l *LLMWithFallbacks) Type() string {
+	return l.current().Type()
+}
+
+// InvocationParams returns the parameters of the LLM that produced the last result.
+func (l *LLMWithFallbacks) InvocationParams() map[string]any {
+	return l.current().InvocationParams()
+}
+
+// current returns the LLM that produced the last result or the primary LLM, if there is no result yet.
+func (l *LLMWithFallbacks) current() schema.LLM {
+	if i := l.answered.Load(); i > 0 {
+		return l.fallbacks[i-1]
+	}
+
+	return l.LLM
+}
+
+// Compile time check to ensure ChatModelWithFallbacks satisfies the ChatModel interface.
+var _ schema.ChatModel = (*ChatModelWithFallbacks)(nil)
+
+// ChatModelWithFallbacks is a chat model that switches to the next fallback if the current chat model fails.
+// Type and InvocationParams report the chat model that produced the last result.
+type ChatModelWithFallbacks struct {
+	schema.ChatModel
+	fallbacks []schema.ChatModel
+	opts      FallbacksOptions
+	answered  atomic.Int64
+}
+
+// NewChatModelWithFallbacks creates a new chat model with fallbacks.
+func NewChatModelWithFallbacks(primary schema.ChatModel, fallbacks []schema.ChatModel, optFns ...func(o *FallbacksOptions)) *ChatModelWithFallbacks {
+	opts := FallbacksOptions{
+		FallbackIf: IsRetryableError,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &ChatModelWithFallbacks{
+		ChatModel: primary,
+		fallbacks: fallbacks,
+		opts:      opts,
+	}
+}
+
+// Generate generates text with the primary chat model and the fallbacks in order, until a chat model succeeds.
+func (cm *ChatModelWithFallbacks) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	chatModels := append([]schema.ChatModel{cm.ChatModel}, cm.fallbacks...)
+
+	return generateWithFallbacks(ctx, len(chatModels), cm.opts, &cm.answered, func(i int) (*schema.ModelResult, error) {
+		return chatModels[i].Generate(ctx, messages, optFns...)
+	})
+}
+
+// Type returns the type of the chat model that produced the last result.
+func (cm *ChatModelWithFallbacks) Type() string {
+	return cm.current().Type()
+}
+
+// InvocationParams returns the parameters of the chat model that produced the last result.
+func (cm *ChatModelWithFallbacks) InvocationParams() map[string]any {
+	return cm.current().InvocationParams()
+}
+
+// current returns the chat model that produced the last result or the primary chat model, if there is no result yet.
+func (cm *ChatModelWithFallbacks) current() schema.ChatModel {
+	if i := cm.answered.Load(); i > 0 {
+		return cm.fallbacks[i-1]
+	}
+
+	return cm.ChatModel
+}
+
+// generateWithFallbacks calls the generate function for each model until it succeeds or
+// fails with an error that does not trigger a fallback. The index of the model that succeeded is stored
+// in answered. It returns the joined errors of all attempts.
+func generateWithFallbacks(ctx context.Context, n int, opts FallbacksOptions, answered *atomic.Int64, generateFn func(i int) (*schema.ModelResult, error)) (*schema.ModelResult, error) {
+	errs := []error{}
+
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		result, err := generateFn(i)
+		if err == nil {
+			answered.Store(int64(i))
+			return result, nil
+		}
+
+		errs = append(errs, err)
+
+		if opts.FallbackIf != nil && !opts.FallbackIf(err) {
+			break
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/cache"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFallbacks(t *testing.T) {
+	errRateLimit := errors.New("error, status code: 429, message: rate limit reached")
+	errInvalid := errors.New("invalid request")
+
+	failingLLM := func(err error) *llm.Fake {
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			return nil, err
+		})
+	}
+
+	t.Run("LLM fallback on retryable error", func(t *testing.T) {
+		m, err := WithFallbacks(failingLLM(errRateLimit), llm.NewSimpleFake("fallback"))
+		require.NoError(t, err)
+
+		result, err := LLMGenerate(context.Background(), m.(schema.LLM), "prompt")
+		require.NoError(t, err)
+		require.Equal(t, "fallback", result.Generations[0].Text)
+	})
+
+	t.Run("No fallback on non retryable error", func(t *testing.T) {
+		m, err := WithFallbacks(failingLLM(errInvalid), llm.NewSimpleFake("fallback"))
+		require.NoError(t, err)
+
+		_, err = LLMGenerate(context.Background(), m.(schema.LLM), "prompt")
+		require.ErrorIs(t, err, errInvalid)
+	})
+
+	t.Run("FallbackIf", func(t *testing.T) {
+		m, err := WithFallbacksOptions(failingLLM(errInvalid), []schema.Model{llm.NewSimpleFake("fallback")}, func(o *FallbacksOptions) {
+			o.FallbackIf = func(err error) bool { return true }
+		})
+		require.NoError(t, err)
+
+		result, err := LLMGenerate(context.Background(), m.(schema.LLM), "prompt")
+		require.NoError(t, err)
+		require.Equal(t, "fallback", result.Generations[0].Text)
+	})
+
+	t.Run("All fallbacks fail", func(t *testing.T) {
+		m := NewLLMWithFallbacks(failingLLM(errRateLimit), []schema.LLM{failingLLM(errInvalid)})
+
+		_, err := LLMGenerate(context.Background(), m, "prompt")
+		require.ErrorIs(t, err, errRateLimit)
+		require.ErrorIs(t, err, errInvalid)
+	})
+
+	t.Run("Chat model fallback", func(t *testing.T) {
+		primary := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			return nil, errors.New("This model's maximum context length is 4097 tokens")
+		})
+
+		m, err := WithFallbacks(primary, chatmodel.NewSimpleFake("fallback"))
+		require.NoError(t, err)
+
+		result, err := ChatModelGenerate(context.Background(), m.(schema.ChatModel), schema.ChatMessages{schema.NewHumanChatMessage("prompt")})
+		require.NoError(t, err)
+		require.Equal(t, "fallback", result.Generations[0].Text)
+	})
+
+	t.Run("Reports the answering model", func(t *testing.T) {
+		primary := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			return nil, errRateLimit
+		}, func(o *llm.FakeOptions) {
+			o.LLMType = "primary"
+		})
+
+		fallback := llm.NewSimpleFake("fallback", func(o *llm.FakeOptions) {
+			o.LLMType = "fallback"
+		})
+
+		m := NewLLMWithFallbacks(primary, []schema.LLM{fallback})
+		require.Equal(t, "primary", m.Type())
+
+		c := cache.NewInMemory()
+
+		_, err := LLMGenerate(context.Background(), m, "prompt", func(o *Options) {
+			o.Cache = c
+		})
+		require.NoError(t, err)
+		require.Equal(t, "fallback", m.Type())
+
+		// The result is cached for the fallback, so a failing model of the same type returns it.
+		result, err := LLMGenerate(context.Background(), llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			return nil, errInvalid
+		}, func(o *llm.FakeOptions) {
+			o.LLMType = "fallback"
+		}), "prompt", func(o *Options) {
+			o.Cache = c
+		})
+		require.NoError(t, err)
+		require.Equal(t, "fallback", result.Generations[0].Text)
+	})
+
+	t.Run("Mixed model types", func(t *testing.T) {
+		_, err := WithFallbacks(llm.NewSimpleFake("primary"), chatmodel.NewSimpleFake("fallback"))
+		require.Error(t, err)
+	})
+}
@@ -2,6 +2,8 @@ package llm
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -39,6 +41,32 @@ func NewSimpleFake(resultText string, optFns ...func(o *FakeOptions)) *Fake {
 	}, optFns...)
 }
 
+// NewSequenceFake creates an instance of the Fake LLM model that answers with the provided responses in order.
+// It returns an error once all responses have been used.
+func NewSequenceFake(responses []string, optFns ...func(o *FakeOptions)) *Fake {
+	var (
+		mu sync.Mutex
+		i  int
+	)
+
+	return NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if i >= len(responses) {
+			return nil, fmt.Errorf("fake has no response left after %d calls", len(responses))
+		}
+
+		response := responses[i]
+		i++
+
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: response}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	}, optFns...)
+}
+
 // NewFake creates a new instance of the Fake LLM model with the provided response function and options.
 func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fake {
 	opts := FakeOptions{
@@ -53,6 +81,7 @@ func NewFake(fakeResultFunc FakeResultFunc, optFns ...func(o *FakeOptions)) *Fak
 	}
 
 	return &Fake{
+		Tokenizer:      opts.Tokenizer,
 		fakeResultFunc: fakeResultFunc,
 		opts:           opts,
 	}
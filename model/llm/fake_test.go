@@ -56,3 +56,18 @@ func TestFake(t *testing.T) {
 		assert.NotNil(t, invocationParams)
 	})
 }
+
+func TestSequenceFake(t *testing.T) {
+	fake := NewSequenceFake([]string{"first", "second"})
+
+	first, err := fake.Generate(context.Background(), "prompt")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", first.Generations[0].Text)
+
+	second, err := fake.Generate(context.Background(), "prompt")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", second.Generations[0].Text)
+
+	_, err = fake.Generate(context.Background(), "prompt")
+	assert.Error(t, err)
+}
@@ -3,7 +3,9 @@ package model
 
 import (
 	"context"
+	"encoding/json"
 
+	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
 	"github.com/hupe1980/golc/schema"
 )
@@ -14,6 +16,10 @@ type Options struct {
 	ParentRunID       string
 	Functions         []schema.FunctionDefinition
 	ForceFunctionCall bool
+	// ToolChoice controls which tools the model calls. See schema.GenerateOptions.
+	ToolChoice string
+	// Cache is the cache for model results. It defaults to golc.Cache.
+	Cache schema.Cache
 }
 
 func GeneratePrompt(ctx context.Context, model schema.Model, promptValue schema.PromptValue, optFns ...func(o *Options)) (*schema.ModelResult, error) {
@@ -30,7 +36,9 @@ func GeneratePrompt(ctx context.Context, model schema.Model, promptValue schema.
 }
 
 func LLMGenerate(ctx context.Context, model schema.LLM, prompt string, optFns ...func(o *Options)) (*schema.ModelResult, error) {
-	opts := Options{}
+	opts := Options{
+		Cache: golc.Cache,
+	}
 
 	for _, fn := range optFns {
 		fn(&opts)
@@ -49,31 +57,18 @@ func LLMGenerate(ctx context.Context, model schema.LLM, prompt string, optFns ..
 		return nil, err
 	}
 
-	result, err := model.Generate(ctx, prompt, func(o *schema.GenerateOptions) {
-		o.CallbackManger = rm
-		o.Stop = opts.Stop
+	return generate(ctx, rm, model, prompt, opts, func() (*schema.ModelResult, error) {
+		return model.Generate(ctx, prompt, func(o *schema.GenerateOptions) {
+			o.CallbackManger = rm
+			o.Stop = opts.Stop
+		})
 	})
-	if err != nil {
-		if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
-			Error: err,
-		}); cbErr != nil {
-			return nil, cbErr
-		}
-
-		return nil, err
-	}
-
-	if err := rm.OnModelEnd(ctx, &schema.ModelEndManagerInput{
-		Result: result,
-	}); err != nil {
-		return nil, err
-	}
-
-	return result, nil
 }
 
 func ChatModelGenerate(ctx context.Context, model schema.ChatModel, messages schema.ChatMessages, optFns ...func(o *Options)) (*schema.ModelResult, error) {
-	opts := Options{}
+	opts := Options{
+		Cache: golc.Cache,
+	}
 
 	for _, fn := range optFns {
 		fn(&opts)
@@ -94,12 +89,55 @@ func ChatModelGenerate(ctx context.Context, model schema.ChatModel, messages sch
 		return nil, err
 	}
 
-	result, err := model.Generate(ctx, messages, func(o *schema.GenerateOptions) {
-		o.CallbackManger = rm
-		o.Stop = opts.Stop
-		o.Functions = opts.Functions
-		o.ForceFunctionCall = opts.ForceFunctionCall
+	var prompt string
+
+	if opts.Cache != nil {
+		p, err := cachePrompt(messages)
+		if err != nil {
+			return nil, err
+		}
+
+		prompt = p
+	}
+
+	return generate(ctx, rm, model, prompt, opts, func() (*schema.ModelResult, error) {
+		return model.Generate(ctx, messages, func(o *schema.GenerateOptions) {
+			o.CallbackManger = rm
+			o.Stop = opts.Stop
+			o.Functions = opts.Functions
+			o.ForceFunctionCall = opts.ForceFunctionCall
+			o.ToolChoice = opts.ToolChoice
+		})
 	})
+}
+
+// generate returns the cached result for the prompt, if any. Otherwise it calls the generate function
+// and stores the result in the cache. The callbacks are fired for cached and generated results.
+func generate(ctx context.Context, rm schema.CallbackManagerForModelRun, model schema.Model, prompt string, opts Options, generateFn func() (*schema.ModelResult, error)) (*schema.ModelResult, error) {
+	if opts.Cache != nil {
+		key, err := cacheModelKey(model, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		cached, err := opts.Cache.Lookup(ctx, prompt, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if cached != nil {
+			if err := rm.OnModelEnd(ctx, &schema.ModelEndManagerInput{
+				Result: cached,
+				Cached: true,
+			}); err != nil {
+				return nil, err
+			}
+
+			return cached, nil
+		}
+	}
+
+	result, err := generateFn()
 	if err != nil {
 		if cbErr := rm.OnModelError(ctx, &schema.ModelErrorManagerInput{
 			Error: err,
@@ -110,6 +148,18 @@ func ChatModelGenerate(ctx context.Context, model schema.ChatModel, messages sch
 		return nil, err
 	}
 
+	if opts.Cache != nil {
+		// The key is computed again, because models with fallbacks report the model that produced the result.
+		key, err := cacheModelKey(model, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := opts.Cache.Update(ctx, prompt, key, result); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := rm.OnModelEnd(ctx, &schema.ModelEndManagerInput{
 		Result: result,
 	}); err != nil {
@@ -118,3 +168,58 @@ func ChatModelGenerate(ctx context.Context, model schema.ChatModel, messages sch
 
 	return result, nil
 }
+
+// cachePrompt returns the cache prompt of the chat messages. It contains all message fields that are
+// sent to the model, including the function and tool calls of AI messages.
+func cachePrompt(messages schema.ChatMessages) (string, error) {
+	maps := make([]map[string]string, len(messages))
+
+	for i, m := range messages {
+		maps[i] = schema.ChatMessageToMap(m)
+
+		if aiMsg, ok := m.(*schema.AIChatMessage); ok && aiMsg.Extension().FunctionCall != nil {
+			b, err := json.Marshal(aiMsg.Extension().FunctionCall)
+			if err != nil {
+				return "", err
+			}
+
+			maps[i]["functionCall"] = string(b)
+		}
+	}
+
+	b, err := json.Marshal(maps)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// cacheModelKey returns a key identifying the model and all options that influence the model result.
+// The tool choice is resolved, so that the legacy ForceFunctionCall and the equivalent tool choice share results.
+func cacheModelKey(model schema.Model, opts Options) (string, error) {
+	generateOpts := schema.GenerateOptions{
+		Functions:         opts.Functions,
+		ForceFunctionCall: opts.ForceFunctionCall,
+		ToolChoice:        opts.ToolChoice,
+	}
+
+	b, err := json.Marshal(struct {
+		Type             string                      `json:"type"`
+		InvocationParams map[string]any              `json:"invocationParams"`
+		Stop             []string                    `json:"stop,omitempty"`
+		Functions        []schema.FunctionDefinition `json:"functions,omitempty"`
+		ToolChoice       string                      `json:"toolChoice,omitempty"`
+	}{
+		Type:             model.Type(),
+		InvocationParams: model.InvocationParams(),
+		Stop:             opts.Stop,
+		Functions:        opts.Functions,
+		ToolChoice:       generateOpts.ResolveToolChoice(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/cache"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedHandler struct {
+	callback.NoopHandler
+	cached []bool
+}
+
+func (h *cachedHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *cachedHandler) OnModelEnd(ctx context.Context, input *schema.ModelEndInput) error {
+	h.cached = append(h.cached, input.Cached)
+	return nil
+}
+
+func TestLLMGenerate(t *testing.T) {
+	t.Run("Cache", func(t *testing.T) {
+		calls := 0
+
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "bar"}},
+			}, nil
+		})
+
+		handler := &cachedHandler{}
+		c := cache.NewInMemory()
+
+		for i := 0; i < 2; i++ {
+			result, err := LLMGenerate(context.Background(), fake, "foo", func(o *Options) {
+				o.Cache = c
+				o.Callbacks = []schema.Callback{handler}
+			})
+			require.NoError(t, err)
+			require.Equal(t, "bar", result.Generations[0].Text)
+		}
+
+		_, err := LLMGenerate(context.Background(), fake, "foo", func(o *Options) {
+			o.Cache = c
+			o.Stop = []string{"\n"}
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, calls)
+		require.Equal(t, []bool{false, true}, handler.cached)
+	})
+}
+
+func TestChatModelGenerate(t *testing.T) {
+	t.Run("Cache", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "bar", Message: schema.NewAIChatMessage("bar")}},
+			}, nil
+		})
+
+		c := cache.NewInMemory()
+
+		for _, content := range []string{"foo", "foo", "baz"} {
+			_, err := ChatModelGenerate(context.Background(), fake, schema.ChatMessages{schema.NewHumanChatMessage(content)}, func(o *Options) {
+				o.Cache = c
+			})
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("Cache key", func(t *testing.T) {
+		calls := 0
+
+		fake := chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			calls++
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "bar", Message: schema.NewAIChatMessage("bar")}},
+			}, nil
+		})
+
+		c := cache.NewInMemory()
+		functions := []schema.FunctionDefinition{{Name: "weather"}, {Name: "search"}}
+
+		for _, tc := range []struct {
+			messages schema.ChatMessages
+			optFn    func(o *Options)
+		}{
+			{messages: schema.ChatMessages{schema.NewHumanChatMessage("foo")}, optFn: func(o *Options) {}},
+			{messages: schema.ChatMessages{schema.NewHumanChatMessage("foo")}, optFn: func(o *Options) { o.Functions = functions }},
+			{messages: schema.ChatMessages{schema.NewHumanChatMessage("foo")}, optFn: func(o *Options) {
+				o.Functions = functions
+				o.ToolChoice = "search"
+			}},
+			{messages: schema.ChatMessages{schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Berlin"}`}
+			})}, optFn: func(o *Options) {}},
+			{messages: schema.ChatMessages{schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.FunctionCall = &schema.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}
+			})}, optFn: func(o *Options) {}},
+		} {
+			_, err := ChatModelGenerate(context.Background(), fake, tc.messages, tc.optFn, func(o *Options) {
+				o.Cache = c
+			})
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 5, calls)
+	})
+}
@@ -0,0 +1,11 @@
+package multiagent
+
+import "errors"
+
+var (
+	ErrNoWorkers             = errors.New("no workers")
+	ErrDuplicateWorker       = errors.New("duplicate worker")
+	ErrMaxTurnsExceeded      = errors.New("supervisor not finished before max turns")
+	ErrRecursionLimitReached = errors.New("supervisor recursion limit reached")
+	ErrInvalidWorkerInput    = errors.New("invalid worker input")
+)
@@ -0,0 +1,341 @@
+package multiagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// Compile time check to ensure Supervisor satisfies the chain interface.
+var _ schema.Chain = (*Supervisor)(nil)
+
+const (
+	defaultSupervisorTemplate = `You are a supervisor tasked with managing a conversation between the following workers:
+{{.workers}}
+
+Given the user request, call the worker that should act next with a task that contains all information the worker needs.
+Each worker will perform its task and respond with its results. When the request is fulfilled, respond to the user with the final answer.`
+
+	// DefaultMaxTurns is the default maximum number of turns of the supervisor.
+	DefaultMaxTurns = 10
+
+	// DefaultMaxDepth is the default maximum number of nested supervisors.
+	DefaultMaxDepth = 3
+)
+
+// depthKey is the context key of the current supervisor nesting depth.
+type depthKey struct{}
+
+// SupervisorOptions represents the configuration options for the Supervisor.
+type SupervisorOptions struct {
+	*schema.CallbackOptions
+	// InputKey is the key of the user request in the ChainValues.
+	InputKey string
+	// OutputKey is the key to store the final answer in the ChainValues.
+	OutputKey string
+	// MessagesKey is the key of the shared message history in the ChainValues. A history in the
+	// inputs is continued, the history of the run is returned in the outputs.
+	MessagesKey string
+	// SystemTemplate is the system message of the supervisor model. It is formatted with the
+	// descriptions of the workers.
+	SystemTemplate string
+	// MaxTurns is the maximum number of turns of the supervisor model.
+	MaxTurns int
+	// MaxDepth is the maximum number of nested supervisors, e.g. a supervisor acting as worker
+	// of another supervisor.
+	MaxDepth int
+}
+
+// Supervisor is a chain in which a supervisor model routes each turn to one of several named
+// workers. The workers are exposed to the model as tools and their results are added to a shared
+// message history. A worker with handoff takes over the conversation and answers the user directly.
+type Supervisor struct {
+	model     schema.ChatModel
+	workers   map[string]*Worker
+	functions []schema.FunctionDefinition
+	system    string
+	opts      SupervisorOptions
+}
+
+// NewSupervisor creates a new Supervisor with the given chat model and workers.
+func NewSupervisor(model schema.ChatModel, workers []*Worker, optFns ...func(o *SupervisorOptions)) (*Supervisor, error) {
+	opts := SupervisorOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		InputKey:       "input",
+		OutputKey:      "output",
+		MessagesKey:    "messages",
+		SystemTemplate: defaultSupervisorTemplate,
+		MaxTurns:       DefaultMaxTurns,
+		MaxDepth:       DefaultMaxDepth,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if len(workers) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	workersMap := make(map[string]*Worker, len(workers))
+	functions := make([]schema.FunctionDefinition, len(workers))
+	descriptions := make([]string, len(workers))
+
+	for i, w := range workers {
+		if _, ok := workersMap[w.Name()]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateWorker, w.Name())
+		}
+
+		workersMap[w.Name()] = w
+
+		f, err := tool.ToFunction(w)
+		if err != nil {
+			return nil, err
+		}
+
+		functions[i] = *f
+		descriptions[i] = fmt.Sprintf("- %s: %s", w.Name(), w.Description())
+	}
+
+	system, err := prompt.NewTemplate(opts.SystemTemplate).Format(map[string]any{
+		"workers": strings.Join(descriptions, "\n"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Supervisor{
+		model:     model,
+		workers:   workersMap,
+		functions: functions,
+		system:    system,
+		opts:      opts,
+	}, nil
+}
+
+// Call executes the supervisor with the given context and inputs.
+// It returns the final answer and the shared message history or an error, if any.
+func (s *Supervisor) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	depth, _ := ctx.Value(depthKey{}).(int)
+	if depth >= s.opts.MaxDepth {
+		return nil, fmt.Errorf("%w: %d", ErrRecursionLimitReached, s.opts.MaxDepth)
+	}
+
+	ctx = context.WithValue(ctx, depthKey{}, depth+1)
+
+	input, err := inputs.GetString(s.opts.InputKey)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := schema.ChatMessages{}
+	if history, ok := inputs[s.opts.MessagesKey].(schema.ChatMessages); ok {
+		messages = append(messages, history...)
+	}
+
+	messages = append(messages, schema.NewHumanChatMessage(input))
+
+	for turn := 0; turn < s.opts.MaxTurns; turn++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		result, err := model.ChatModelGenerate(ctx, s.model, append(schema.ChatMessages{schema.NewSystemChatMessage(s.system)}, messages...), func(o *model.Options) {
+			o.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
+			o.ParentRunID = opts.CallbackManger.RunID()
+			o.Functions = s.functions
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		msg := result.Generations[0].Message
+
+		aiMsg, ok := msg.(*schema.AIChatMessage)
+		if !ok {
+			return nil, fmt.Errorf("unexpected chatMessage type. Expected ai, but got %s", msg.Type())
+		}
+
+		messages = append(messages, aiMsg)
+
+		toolCalls := aiMsg.ToolCalls()
+		if len(toolCalls) == 0 {
+			return s.finish(ctx, aiMsg.Content(), messages, opts)
+		}
+
+		// handoff is the worker that took over the conversation. The remaining tool calls of
+		// the turn are cancelled, so that every tool call is answered in the message history.
+		var (
+			handoff *Worker
+			answer  string
+		)
+
+		for _, tc := range toolCalls {
+			if handoff != nil {
+				messages = append(messages, schema.NewToolChatMessage(tc.ID, tc.Name, fmt.Sprintf("Cancelled, the conversation was handed off to %s.", handoff.Name())))
+				continue
+			}
+
+			w, ok := s.workers[tc.Name]
+			if !ok {
+				messages = append(messages, schema.NewToolChatMessage(tc.ID, tc.Name, fmt.Sprintf("%s is not a valid worker, try one of [%s].", tc.Name, s.workerNames())))
+				continue
+			}
+
+			args := WorkerArgs{}
+			if err := json.Unmarshal([]byte(tc.Arguments), &args); err != nil {
+				messages = append(messages, schema.NewToolChatMessage(tc.ID, tc.Name, fmt.Sprintf("Invalid arguments for worker %s: %s", tc.Name, err)))
+				continue
+			}
+
+			output, err := s.runWorker(ctx, w, tc, args.Task, messages, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			messages = append(messages, schema.NewToolChatMessage(tc.ID, tc.Name, output))
+
+			if w.Handoff() {
+				handoff, answer = w, output
+			}
+		}
+
+		if handoff != nil {
+			return s.finish(ctx, answer, messages, opts)
+		}
+	}
+
+	return nil, ErrMaxTurnsExceeded
+}
+
+// runWorker reports the action of the worker to the callbacks and executes the worker with the
+// given task. A worker with handoff additionally receives the shared message history.
+func (s *Supervisor) runWorker(ctx context.Context, w *Worker, tc schema.ToolCall, task string, messages schema.ChatMessages, opts schema.CallOptions) (string, error) {
+	if cbErr := opts.CallbackManger.OnAgentAction(ctx, &schema.AgentActionManagerInput{
+		Action: &schema.AgentAction{
+			Tool:       w.Name(),
+			ToolInput:  schema.NewToolInputFromArguments(tc.Arguments),
+			Log:        fmt.Sprintf("\nRouting to %s: %s\n", w.Name(), task),
+			ToolCallID: tc.ID,
+		},
+	}); cbErr != nil {
+		return "", cbErr
+	}
+
+	input := task
+	if w.Handoff() {
+		input = fmt.Sprintf("Conversation so far:\n%s\n\nTask: %s", formatHistory(messages), task)
+	}
+
+	output, err := w.call(ctx, input, opts.CallbackManger.GetInheritableCallbacks(), opts.CallbackManger.RunID())
+	if err != nil {
+		return "", err
+	}
+
+	if cbErr := opts.CallbackManger.OnText(ctx, &schema.TextManagerInput{
+		Text: fmt.Sprintf("\n%s: %s", w.Name(), output),
+	}); cbErr != nil {
+		return "", cbErr
+	}
+
+	return output, nil
+}
+
+// finish reports the final answer to the callbacks and creates the outputs.
+func (s *Supervisor) finish(ctx context.Context, answer string, messages schema.ChatMessages, opts schema.CallOptions) (schema.ChainValues, error) {
+	outputs := schema.ChainValues{
+		s.opts.OutputKey:   answer,
+		s.opts.MessagesKey: messages,
+	}
+
+	if cbErr := opts.CallbackManger.OnAgentFinish(ctx, &schema.AgentFinishManagerInput{
+		Finish: &schema.AgentFinish{
+			ReturnValues: outputs,
+			Log:          answer,
+		},
+	}); cbErr != nil {
+		return nil, cbErr
+	}
+
+	return outputs, nil
+}
+
+// workerNames returns the comma separated names of the workers.
+func (s *Supervisor) workerNames() string {
+	names := make([]string, len(s.functions))
+	for i, f := range s.functions {
+		names[i] = f.Name
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Memory returns the memory associated with the chain.
+func (s *Supervisor) Memory() schema.Memory {
+	return nil
+}
+
+// Type returns the type of the chain.
+func (s *Supervisor) Type() string {
+	return "Supervisor"
+}
+
+// Verbose returns the verbosity setting of the chain.
+func (s *Supervisor) Verbose() bool {
+	return s.opts.Verbose
+}
+
+// Callbacks returns the callbacks associated with the chain.
+func (s *Supervisor) Callbacks() []schema.Callback {
+	return s.opts.Callbacks
+}
+
+// InputKeys returns the expected input keys.
+func (s *Supervisor) InputKeys() []string {
+	return []string{s.opts.InputKey}
+}
+
+// OutputKeys returns the output keys the chain will return.
+func (s *Supervisor) OutputKeys() []string {
+	return []string{s.opts.OutputKey, s.opts.MessagesKey}
+}
+
+// formatHistory formats the shared message history with the names of the acting workers.
+func formatHistory(messages schema.ChatMessages) string {
+	lines := []string{}
+
+	for _, m := range messages {
+		switch msg := m.(type) {
+		case *schema.HumanChatMessage:
+			lines = append(lines, fmt.Sprintf("User: %s", msg.Content()))
+		case *schema.AIChatMessage:
+			if msg.Content() != "" {
+				lines = append(lines, fmt.Sprintf("Supervisor: %s", msg.Content()))
+			}
+		case *schema.ToolChatMessage:
+			lines = append(lines, fmt.Sprintf("%s: %s", msg.Name(), msg.Content()))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,216 @@
+package multiagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupervisor(t *testing.T) {
+	t.Parallel()
+
+	newModel := func(messages ...*schema.AIChatMessage) *chatmodel.Fake {
+		return chatmodel.NewSequenceFake(messages)
+	}
+
+	route := func(id, name, task string) *schema.AIChatMessage {
+		return schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+			o.ToolCalls = []schema.ToolCall{{ID: id, Name: name, Arguments: fmt.Sprintf(`{"task": %q}`, task)}}
+		})
+	}
+
+	newWorker := func(t *testing.T, name string, optFns ...func(o *WorkerOptions)) *Worker {
+		executor, err := chain.NewLLM(chatmodel.NewFake(func(ctx context.Context, messages schema.ChatMessages) (*schema.ModelResult, error) {
+			text := fmt.Sprintf("%s did %s", name, messages[0].Content())
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: text, Message: schema.NewAIChatMessage(text)}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}), prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		return NewWorker(name, fmt.Sprintf("The %s worker.", name), executor, optFns...)
+	}
+
+	t.Run("Route", func(t *testing.T) {
+		t.Parallel()
+
+		handler := &recordingHandler{}
+
+		supervisor, err := NewSupervisor(newModel(
+			route("call_1", "sql", "count users"),
+			route("call_2", "unknown", "guess"),
+			route("call_3", "retrieval", "find docs"),
+			schema.NewAIChatMessage("42 users, see docs"),
+		), []*Worker{newWorker(t, "sql"), newWorker(t, "retrieval")}, func(o *SupervisorOptions) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), supervisor, schema.ChainValues{"input": "How many users?"})
+		require.NoError(t, err)
+		assert.Equal(t, "42 users, see docs", outputs["output"])
+
+		messages, ok := outputs["messages"].(schema.ChatMessages)
+		require.True(t, ok)
+		require.Len(t, messages, 8)
+		assert.Equal(t, "sql did count users", messages[2].Content())
+		assert.Equal(t, "unknown is not a valid worker, try one of [sql, retrieval].", messages[4].Content())
+		assert.Equal(t, "retrieval did find docs", messages[6].Content())
+
+		assert.Equal(t, []string{"sql", "retrieval"}, handler.actions)
+	})
+
+	t.Run("Handoff", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor, err := NewSupervisor(newModel(
+			route("call_1", "sql", "count users"),
+			route("call_2", "browser", "open the dashboard"),
+		), []*Worker{newWorker(t, "sql"), newWorker(t, "browser", func(o *WorkerOptions) {
+			o.Handoff = true
+		})})
+		require.NoError(t, err)
+
+		outputs, err := supervisor.Call(context.Background(), schema.ChainValues{"input": "Show the users"})
+		require.NoError(t, err)
+
+		output, ok := outputs["output"].(string)
+		require.True(t, ok)
+		assert.True(t, strings.HasPrefix(output, "browser did Conversation so far:\nUser: Show the users\nsql: sql did count users"))
+		assert.True(t, strings.HasSuffix(output, "Task: open the dashboard"))
+	})
+
+	t.Run("Handoff cancels remaining tool calls", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor, err := NewSupervisor(newModel(
+			schema.NewAIChatMessage("", func(o *schema.ChatMessageExtension) {
+				o.ToolCalls = []schema.ToolCall{
+					{ID: "call_1", Name: "browser", Arguments: `{"task": "open the dashboard"}`},
+					{ID: "call_2", Name: "sql", Arguments: `{"task": "count users"}`},
+				}
+			}),
+		), []*Worker{newWorker(t, "sql"), newWorker(t, "browser", func(o *WorkerOptions) {
+			o.Handoff = true
+		})})
+		require.NoError(t, err)
+
+		outputs, err := supervisor.Call(context.Background(), schema.ChainValues{"input": "Show the users"})
+		require.NoError(t, err)
+
+		messages, ok := outputs["messages"].(schema.ChatMessages)
+		require.True(t, ok)
+		require.Len(t, messages, 4)
+		assert.Equal(t, "call_2", messages[3].(*schema.ToolChatMessage).ToolCallID())
+		assert.Equal(t, "Cancelled, the conversation was handed off to browser.", messages[3].Content())
+	})
+
+	t.Run("MaxTurnsExceeded", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor, err := NewSupervisor(newModel(
+			route("call_1", "sql", "count users"),
+			route("call_2", "sql", "count users"),
+		), []*Worker{newWorker(t, "sql")}, func(o *SupervisorOptions) {
+			o.MaxTurns = 2
+		})
+		require.NoError(t, err)
+
+		_, err = supervisor.Call(context.Background(), schema.ChainValues{"input": "How many users?"})
+		assert.ErrorIs(t, err, ErrMaxTurnsExceeded)
+	})
+
+	t.Run("RecursionLimit", func(t *testing.T) {
+		t.Parallel()
+
+		inner, err := NewSupervisor(newModel(schema.NewAIChatMessage("inner answer")), []*Worker{newWorker(t, "sql")}, func(o *SupervisorOptions) {
+			o.MaxDepth = 1
+		})
+		require.NoError(t, err)
+
+		outer, err := NewSupervisor(newModel(route("call_1", "team", "ask the team")), []*Worker{NewWorker("team", "A team of workers.", inner)})
+		require.NoError(t, err)
+
+		_, err = outer.Call(context.Background(), schema.ChainValues{"input": "question"})
+		assert.ErrorIs(t, err, ErrRecursionLimitReached)
+	})
+
+	t.Run("InvalidWorkers", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewSupervisor(newModel(), nil)
+		assert.ErrorIs(t, err, ErrNoWorkers)
+
+		_, err = NewSupervisor(newModel(), []*Worker{newWorker(t, "sql"), newWorker(t, "sql")})
+		assert.ErrorIs(t, err, ErrDuplicateWorker)
+	})
+}
+
+func TestWorker(t *testing.T) {
+	t.Parallel()
+
+	executor, err := chain.NewLLM(chatmodel.NewSimpleFake("done"), prompt.NewTemplate("{{.input}}"))
+	require.NoError(t, err)
+
+	worker := NewWorker("sql", "Runs SQL queries.", executor)
+
+	output, err := worker.Run(context.Background(), WorkerArgs{Task: "count users"})
+	require.NoError(t, err)
+	assert.Equal(t, "done", output)
+
+	_, err = worker.Run(context.Background(), 42)
+	assert.ErrorIs(t, err, ErrInvalidWorkerInput)
+
+	t.Run("Inherits callbacks of the tool run", func(t *testing.T) {
+		handler := &recordingHandler{}
+
+		_, err := tool.Run(context.Background(), worker, schema.NewToolInputFromString("count users"), func(o *tool.Options) {
+			o.Callbacks = []schema.Callback{handler}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, handler.chains)
+	})
+}
+
+type recordingHandler struct {
+	callback.NoopHandler
+	mu      sync.Mutex
+	actions []string
+	chains  int
+}
+
+func (h *recordingHandler) AlwaysVerbose() bool {
+	return true
+}
+
+func (h *recordingHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.chains++
+
+	return nil
+}
+
+func (h *recordingHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.actions = append(h.actions, input.Action.Tool)
+
+	return nil
+}
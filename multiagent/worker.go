@@ -0,0 +1,121 @@
+// Package multiagent provides the cooperation of several specialized agents under a supervisor.
+package multiagent
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// Compile time check to ensure Worker satisfies the Tool interface.
+var _ schema.Tool = (*Worker)(nil)
+
+// WorkerArgs represents the input of a worker.
+type WorkerArgs struct {
+	Task string `json:"task" description:"The task for the worker, including all information needed to complete it."`
+}
+
+// WorkerOptions contains options for configuring a worker.
+type WorkerOptions struct {
+	*schema.CallbackOptions
+	// Handoff transfers the conversation to the worker. The worker receives the shared message
+	// history and its answer is returned to the user without going back to the supervisor.
+	Handoff bool
+}
+
+// Worker is a named agent, e.g. an agent.Executor, that carries out tasks. Workers are exposed
+// as tools, so they can be used by a supervisor or by any other agent.
+type Worker struct {
+	name        string
+	description string
+	executor    schema.Chain
+	opts        WorkerOptions
+}
+
+// NewWorker creates a new Worker with the given name, description and executor.
+func NewWorker(name, description string, executor schema.Chain, optFns ...func(o *WorkerOptions)) *Worker {
+	opts := WorkerOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Worker{
+		name:        name,
+		description: description,
+		executor:    executor,
+		opts:        opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (w *Worker) Name() string {
+	return w.name
+}
+
+// Description returns the description of the tool.
+func (w *Worker) Description() string {
+	return w.description
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (w *Worker) ArgsType() reflect.Type {
+	return reflect.TypeOf(WorkerArgs{})
+}
+
+// Run executes the tool with the given input and returns the output. If the worker is executed
+// by tool.Run, e.g. by an agent, the executor inherits the callbacks of the tool run.
+func (w *Worker) Run(ctx context.Context, input any) (string, error) {
+	var task string
+
+	switch v := input.(type) {
+	case WorkerArgs:
+		task = v.Task
+	case string:
+		task = v
+	default:
+		return "", fmt.Errorf("%w: %T", ErrInvalidWorkerInput, input)
+	}
+
+	if rm, ok := tool.RunManagerFromContext(ctx); ok {
+		return w.call(ctx, task, rm.GetInheritableCallbacks(), rm.RunID())
+	}
+
+	return w.call(ctx, task, nil, "")
+}
+
+// Handoff reports whether the conversation is transferred to the worker.
+func (w *Worker) Handoff() bool {
+	return w.opts.Handoff
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (w *Worker) Verbose() bool {
+	return w.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (w *Worker) Callbacks() []schema.Callback {
+	return w.opts.Callbacks
+}
+
+// call executes the executor of the worker with the given input.
+func (w *Worker) call(ctx context.Context, input string, callbacks []schema.Callback, parentRunID string) (string, error) {
+	resp, err := golc.Call(ctx, w.executor, schema.ChainValues{w.executor.InputKeys()[0]: input}, func(co *golc.CallOptions) {
+		co.Callbacks = callbacks
+		co.ParentRunID = parentRunID
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.GetString(w.executor.OutputKeys()[0])
+}
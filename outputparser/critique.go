@@ -0,0 +1,88 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Critique satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*Critique)(nil)
+
+// CritiqueOutput represents the verdict of a critic.
+type CritiqueOutput struct {
+	// Pass reports whether the critiqued text needs no further revision.
+	Pass bool
+	// Feedback explains the verdict and describes the required changes.
+	Feedback string
+}
+
+// Critique represents a parser for the JSON verdict of a critic.
+type Critique struct{}
+
+// NewCritique creates a new instance of the Critique parser.
+func NewCritique() *Critique {
+	return &Critique{}
+}
+
+// ParseResult parses the generation text and returns a CritiqueOutput.
+func (p *Critique) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// Parse parses a JSON object, optionally enclosed in a markdown code block, and returns a CritiqueOutput.
+func (p *Critique) Parse(text string) (any, error) {
+	jsonText := strings.TrimSpace(text)
+
+	if matches := jsonCodeBlockRegexp.FindStringSubmatch(jsonText); len(matches) == 2 {
+		jsonText = strings.TrimSpace(matches[1])
+	}
+
+	parsed := struct {
+		Verdict  string `json:"verdict"`
+		Feedback string `json:"feedback"`
+	}{}
+
+	if err := json.Unmarshal([]byte(jsonText), &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCannotParseOutput, text)
+	}
+
+	var pass bool
+
+	switch strings.ToLower(strings.TrimSpace(parsed.Verdict)) {
+	case "pass":
+		pass = true
+	case "fail":
+		pass = false
+	default:
+		return nil, fmt.Errorf("%w: invalid verdict: %s", ErrCannotParseOutput, parsed.Verdict)
+	}
+
+	return CritiqueOutput{
+		Pass:     pass,
+		Feedback: strings.TrimSpace(parsed.Feedback),
+	}, nil
+}
+
+// ParseWithPrompt is not used for this parser, so it simply calls Parse.
+func (p *Critique) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+// GetFormatInstructions returns a string describing the expected JSON verdict.
+func (p *Critique) GetFormatInstructions() string {
+	return `Return a markdown code snippet with a JSON object formatted to look like:
+` + "```json" + `
+{
+    "verdict": string \ "pass" if the text needs no further revision, otherwise "fail"
+    "feedback": string \ the reasons for the verdict and the changes required to pass
+}
+` + "```"
+}
+
+// Type returns the type identifier of the parser, which is "critique".
+func (p *Critique) Type() string {
+	return "critique"
+}
@@ -0,0 +1,33 @@
+package outputparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCritique(t *testing.T) {
+	parser := NewCritique()
+
+	t.Run("Parse markdown code block", func(t *testing.T) {
+		output, err := parser.Parse("```json\n{\"verdict\": \"fail\", \"feedback\": \"Too long.\"}\n```")
+		require.NoError(t, err)
+		require.Equal(t, CritiqueOutput{Pass: false, Feedback: "Too long."}, output)
+	})
+
+	t.Run("Parse pass verdict", func(t *testing.T) {
+		output, err := parser.Parse(`{"verdict": "PASS"}`)
+		require.NoError(t, err)
+		require.Equal(t, CritiqueOutput{Pass: true}, output)
+	})
+
+	t.Run("Parse invalid verdict", func(t *testing.T) {
+		_, err := parser.Parse(`{"verdict": "maybe"}`)
+		require.ErrorIs(t, err, ErrCannotParseOutput)
+	})
+
+	t.Run("Parse invalid output", func(t *testing.T) {
+		_, err := parser.Parse("no json")
+		require.ErrorIs(t, err, ErrCannotParseOutput)
+	})
+}
@@ -0,0 +1,7 @@
+package outputparser
+
+import "errors"
+
+var (
+	ErrCannotParseOutput = errors.New("cannot parse output")
+)
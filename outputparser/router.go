@@ -0,0 +1,116 @@
+package outputparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Router satisfies the OutputParser interface.
+var _ schema.OutputParser[any] = (*Router)(nil)
+
+// jsonCodeBlockRegexp matches a JSON object enclosed in a markdown code block.
+var jsonCodeBlockRegexp = regexp.MustCompile("(?s)```(?:json)?(.*?)```")
+
+// RouterOutput represents the routing decision of a model.
+type RouterOutput struct {
+	// Destination is the name of the selected destination. It is empty if the default destination was selected.
+	Destination string
+	// NextInputs are the (optionally rewritten) inputs for the destination.
+	NextInputs schema.ChainValues
+}
+
+// RouterOptions contains options for the Router parser.
+type RouterOptions struct {
+	// DefaultDestination is the destination name the model uses to select the default destination.
+	DefaultDestination string
+	// NextInputsKey is the key of the next inputs if the model returns the next inputs as plain string.
+	NextInputsKey string
+}
+
+// Router represents a parser for the JSON routing decision of a model.
+type Router struct {
+	opts RouterOptions
+}
+
+// NewRouter creates a new instance of the Router parser.
+func NewRouter(optFns ...func(o *RouterOptions)) *Router {
+	opts := RouterOptions{
+		DefaultDestination: "DEFAULT",
+		NextInputsKey:      "input",
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Router{
+		opts: opts,
+	}
+}
+
+// ParseResult parses the generation text and returns a RouterOutput.
+func (p *Router) ParseResult(result schema.Generation) (any, error) {
+	return p.Parse(result.Text)
+}
+
+// Parse parses a JSON object, optionally enclosed in a markdown code block, and returns a RouterOutput.
+func (p *Router) Parse(text string) (any, error) {
+	jsonText := strings.TrimSpace(text)
+
+	if matches := jsonCodeBlockRegexp.FindStringSubmatch(jsonText); len(matches) == 2 {
+		jsonText = strings.TrimSpace(matches[1])
+	}
+
+	parsed := struct {
+		Destination string          `json:"destination"`
+		NextInputs  json.RawMessage `json:"next_inputs"`
+	}{}
+
+	if err := json.Unmarshal([]byte(jsonText), &parsed); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCannotParseOutput, text)
+	}
+
+	output := RouterOutput{
+		Destination: strings.TrimSpace(parsed.Destination),
+	}
+
+	if strings.EqualFold(output.Destination, p.opts.DefaultDestination) {
+		output.Destination = ""
+	}
+
+	if len(parsed.NextInputs) > 0 && string(parsed.NextInputs) != "null" {
+		var nextInput string
+		if err := json.Unmarshal(parsed.NextInputs, &nextInput); err == nil {
+			output.NextInputs = schema.ChainValues{p.opts.NextInputsKey: nextInput}
+		} else if err := json.Unmarshal(parsed.NextInputs, &output.NextInputs); err != nil {
+			return nil, fmt.Errorf("%w: invalid next inputs: %s", ErrCannotParseOutput, parsed.NextInputs)
+		}
+	}
+
+	return output, nil
+}
+
+// ParseWithPrompt is not used for this parser, so it simply calls Parse.
+func (p *Router) ParseWithPrompt(text string, prompt schema.PromptValue) (any, error) {
+	return p.Parse(text)
+}
+
+// GetFormatInstructions returns a string describing the expected JSON routing decision.
+func (p *Router) GetFormatInstructions() string {
+	return `Return a markdown code snippet with a JSON object formatted to look like:
+` + "```json" + `
+{
+    "destination": string \ name of the destination to use or "` + p.opts.DefaultDestination + `"
+    "next_inputs": string \ a potentially modified version of the original input
+}
+` + "```"
+}
+
+// Type returns the type identifier of the parser, which is "router".
+func (p *Router) Type() string {
+	return "router"
+}
@@ -0,0 +1,41 @@
+package outputparser
+
+import (
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	parser := NewRouter()
+
+	t.Run("Parse markdown code block", func(t *testing.T) {
+		output, err := parser.Parse("```json\n{\"destination\": \"physics\", \"next_inputs\": \"What is light?\"}\n```")
+		require.NoError(t, err)
+		require.Equal(t, RouterOutput{
+			Destination: "physics",
+			NextInputs:  schema.ChainValues{"input": "What is light?"},
+		}, output)
+	})
+
+	t.Run("Parse default destination", func(t *testing.T) {
+		output, err := parser.Parse(`{"destination": "DEFAULT"}`)
+		require.NoError(t, err)
+		require.Equal(t, RouterOutput{}, output)
+	})
+
+	t.Run("Parse object next inputs", func(t *testing.T) {
+		output, err := parser.Parse(`{"destination": "math", "next_inputs": {"question": "1+1"}}`)
+		require.NoError(t, err)
+		require.Equal(t, RouterOutput{
+			Destination: "math",
+			NextInputs:  schema.ChainValues{"question": "1+1"},
+		}, output)
+	})
+
+	t.Run("Parse invalid output", func(t *testing.T) {
+		_, err := parser.Parse("no json")
+		require.ErrorIs(t, err, ErrCannotParseOutput)
+	})
+}
@@ -28,6 +28,11 @@ func NewChatTemplateWrapper(chatTemplates ...ChatTemplate) ChatTemplate {
 	}
 }
 
+// ChatTemplates returns the wrapped chat templates.
+func (ct *chatTemplateWrapper) ChatTemplates() []ChatTemplate {
+	return ct.chatTemplates
+}
+
 func (ct *chatTemplateWrapper) Format(values map[string]any) (string, error) {
 	messages, err := ct.FormatMessages(values)
 	if err != nil {
@@ -93,6 +98,11 @@ func NewChatTemplate(messageTemplates []MessageTemplate) ChatTemplate {
 	}
 }
 
+// MessageTemplates returns the message templates of the chat template.
+func (ct *chatTemplate) MessageTemplates() []MessageTemplate {
+	return ct.messageTemplates
+}
+
 func (ct *chatTemplate) Format(values map[string]any) (string, error) {
 	messages, err := ct.FormatMessages(values)
 	if err != nil {
@@ -158,6 +168,11 @@ func NewMessagesPlaceholder(inputKey string) ChatTemplate {
 	}
 }
 
+// InputKey returns the key of the inserted messages.
+func (ct *messagesPlaceholder) InputKey() string {
+	return ct.inputKey
+}
+
 func (ct *messagesPlaceholder) Format(values map[string]any) (string, error) {
 	messages, err := ct.FormatMessages(values)
 	if err != nil {
@@ -261,6 +276,11 @@ func (pt *SystemMessageTemplate) Format(values map[string]any) (schema.ChatMessa
 	return schema.NewSystemChatMessage(text), nil
 }
 
+// Template returns the template text of the system message template.
+func (pt *SystemMessageTemplate) Template() string {
+	return pt.prompt.Template()
+}
+
 // InputVariables returns the input variables used in the system message template.
 func (pt *SystemMessageTemplate) InputVariables() []string {
 	return pt.prompt.InputVariables()
@@ -301,6 +321,11 @@ func (pt *AIMessageTemplate) Format(values map[string]any) (schema.ChatMessage,
 	return schema.NewAIChatMessage(text), nil
 }
 
+// Template returns the template text of the AI message template.
+func (pt *AIMessageTemplate) Template() string {
+	return pt.prompt.Template()
+}
+
 // InputVariables returns the input variables used in the AI message template.
 func (pt *AIMessageTemplate) InputVariables() []string {
 	return pt.prompt.InputVariables()
@@ -341,6 +366,11 @@ func (pt *HumanMessageTemplate) Format(values map[string]any) (schema.ChatMessag
 	return schema.NewHumanChatMessage(text), nil
 }
 
+// Template returns the template text of the human message template.
+func (pt *HumanMessageTemplate) Template() string {
+	return pt.prompt.Template()
+}
+
 // InputVariables returns the input variables used in the human message template.
 func (pt *HumanMessageTemplate) InputVariables() []string {
 	return pt.prompt.InputVariables()
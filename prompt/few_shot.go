@@ -94,6 +94,36 @@ func (p *FewShotTemplate) FormatPrompt(values map[string]any) (schema.PromptValu
 	return StringPromptValue(prompt), nil
 }
 
+// Template returns the main template text.
+func (p *FewShotTemplate) Template() string {
+	return p.template
+}
+
+// Examples returns the examples of the template.
+func (p *FewShotTemplate) Examples() []map[string]any {
+	return p.examples
+}
+
+// ExampleTemplate returns the template used to format each example.
+func (p *FewShotTemplate) ExampleTemplate() *Template {
+	return p.exampleTemplate
+}
+
+// Prefix returns the prefix added before the examples.
+func (p *FewShotTemplate) Prefix() string {
+	return p.opts.Prefix
+}
+
+// Separator returns the separator between the examples and the template.
+func (p *FewShotTemplate) Separator() string {
+	return p.opts.Separator
+}
+
+// PartialValues returns the partial values of the template.
+func (p *FewShotTemplate) PartialValues() map[string]any {
+	return p.opts.PartialValues
+}
+
 // Partial creates a new FewShotTemplate with partial values.
 func (p *FewShotTemplate) Partial(values map[string]any) schema.PromptTemplate {
 	return NewFewShotTemplate(p.template, p.examples, p.exampleTemplate, func(o *FewShotTemplateOptions) {
@@ -58,6 +58,16 @@ func NewTemplate(template string, optFns ...func(o *TemplateOptions)) *Template
 	}
 }
 
+// Template returns the template text.
+func (p *Template) Template() string {
+	return p.template
+}
+
+// PartialValues returns the partial values of the template.
+func (p *Template) PartialValues() map[string]any {
+	return p.opts.PartialValues
+}
+
 // Partial creates a new Template with partial values.
 func (p *Template) Partial(values map[string]any) schema.PromptTemplate {
 	return NewTemplate(p.template, func(o *TemplateOptions) {
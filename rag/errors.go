@@ -3,6 +3,7 @@ package rag
 import "errors"
 
 var (
-	ErrNoInputValues  = errors.New("no input values")
-	ErrNoOutputParser = errors.New("no output parser")
+	ErrNoInputValues     = errors.New("no input values")
+	ErrNoOutputParser    = errors.New("no output parser")
+	ErrNoMappedDocuments = errors.New("no documents could be mapped")
 )
@@ -2,6 +2,8 @@ package rag
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hupe1980/golc"
 	"github.com/hupe1980/golc/callback"
@@ -17,6 +19,22 @@ type MapReduceDocumentsOptions struct {
 	*schema.CallbackOptions
 	InputKey             string
 	DocumentVariableName string
+
+	// MaxConcurrency is the maximum number of documents mapped concurrently.
+	MaxConcurrency int
+
+	// MaxAttempts is the maximum number of attempts to map a single document.
+	MaxAttempts uint
+
+	// Timeout is the maximum duration of a single attempt to map a document. Zero means no timeout.
+	Timeout time.Duration
+
+	// SkipFailedDocuments determines whether documents that could not be mapped are left out
+	// of the combine step instead of failing the chain.
+	SkipFailedDocuments bool
+
+	// OnProgress is called after every mapped document.
+	OnProgress func(finished, total int, result golc.BatchResult)
 }
 
 type MapReduceDocuments struct {
@@ -32,6 +50,8 @@ func NewMapReduceDocuments(mapChain *chain.LLM, combineChain *StuffDocuments, op
 		},
 		InputKey:             "inputDocuments",
 		DocumentVariableName: "text",
+		MaxConcurrency:       5,
+		MaxAttempts:          1,
 	}
 
 	for _, fn := range optFns {
@@ -71,26 +91,42 @@ func (c *MapReduceDocuments) Call(ctx context.Context, inputs schema.ChainValues
 		batchInputs[i] = batchInput
 	}
 
-	mapResults, err := golc.BatchCall(ctx, c.mapChain, batchInputs, func(co *golc.BatchCallOptions) {
+	mapResults, err := golc.BatchCallResults(ctx, c.mapChain, batchInputs, func(co *golc.BatchCallResultsOptions) {
 		co.Callbacks = opts.CallbackManger.GetInheritableCallbacks()
 		co.ParentRunID = opts.CallbackManger.RunID()
+		co.MaxConcurrency = c.opts.MaxConcurrency
+		co.MaxAttempts = c.opts.MaxAttempts
+		co.Timeout = c.opts.Timeout
+		co.OnProgress = c.opts.OnProgress
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	combineDocs := make([]schema.Document, len(docs))
+	combineDocs := make([]schema.Document, 0, len(docs))
 
 	for i, d := range docs {
-		mapResult, err := mapResults[i].GetString(c.mapChain.OutputKeys()[0])
+		if mapResults[i].Err != nil {
+			if c.opts.SkipFailedDocuments {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to map document %d: %w", i, mapResults[i].Err)
+		}
+
+		mapResult, err := mapResults[i].Outputs.GetString(c.mapChain.OutputKeys()[0])
 		if err != nil {
 			return nil, err
 		}
 
-		combineDocs[i] = schema.Document{
+		combineDocs = append(combineDocs, schema.Document{
 			PageContent: mapResult,
 			Metadata:    d.Metadata,
-		}
+		})
+	}
+
+	if len(docs) > 0 && len(combineDocs) == 0 {
+		return nil, ErrNoMappedDocuments
 	}
 
 	combineInputs := rest.Clone()
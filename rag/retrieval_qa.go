@@ -147,6 +147,21 @@ func (c *RetrievalQA) getDocuments(ctx context.Context, query string, opts schem
 	return docs[:numDocs], nil
 }
 
+// Retriever returns the retriever of the chain.
+func (c *RetrievalQA) Retriever() schema.Retriever {
+	return c.retriever
+}
+
+// StuffDocumentsChain returns the chain that answers the question based on the retrieved documents.
+func (c *RetrievalQA) StuffDocumentsChain() *StuffDocuments {
+	return c.stuffDocumentsChain
+}
+
+// ReturnSourceDocuments returns whether the chain returns the source documents.
+func (c *RetrievalQA) ReturnSourceDocuments() bool {
+	return c.opts.ReturnSourceDocuments
+}
+
 // Memory returns the memory associated with the chain.
 func (c *RetrievalQA) Memory() schema.Memory {
 	return nil
@@ -84,6 +84,16 @@ func (c *StuffDocuments) Call(ctx context.Context, inputs schema.ChainValues, op
 	}, nil
 }
 
+// LLMChain returns the llm chain the documents are stuffed into.
+func (c *StuffDocuments) LLMChain() *chain.LLM {
+	return c.llmChain
+}
+
+// DocumentVariableName returns the prompt variable the documents are stuffed into.
+func (c *StuffDocuments) DocumentVariableName() string {
+	return c.opts.DocumentVariableName
+}
+
 // Memory returns the memory associated with the chain.
 func (c *StuffDocuments) Memory() schema.Memory {
 	return nil
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Embedder satisfies the Embedder interface.
+var _ schema.Embedder = (*Embedder)(nil)
+
+// EmbedderOptions contains options for the rate limited embedder.
+type EmbedderOptions struct {
+	// Tokenizer is used to estimate the token costs. Without a tokenizer, only the
+	// requests and the concurrency are limited.
+	Tokenizer schema.Tokenizer
+}
+
+// Embedder is an embedder whose requests are limited by a Limiter.
+type Embedder struct {
+	embedder schema.Embedder
+	limiter  *Limiter
+	opts     EmbedderOptions
+}
+
+// NewEmbedder creates a new rate limited embedder.
+func NewEmbedder(embedder schema.Embedder, limiter *Limiter, optFns ...func(o *EmbedderOptions)) *Embedder {
+	opts := EmbedderOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &Embedder{
+		embedder: embedder,
+		limiter:  limiter,
+		opts:     opts,
+	}
+}
+
+// BatchEmbedText waits for the limiter and embeds a list of texts.
+func (e *Embedder) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	release, err := e.wait(ctx, texts...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return e.embedder.BatchEmbedText(ctx, texts)
+}
+
+// EmbedText waits for the limiter and embeds a single text.
+func (e *Embedder) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	release, err := e.wait(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return e.embedder.EmbedText(ctx, text)
+}
+
+// wait estimates the token costs of the texts and waits for the limiter.
+func (e *Embedder) wait(ctx context.Context, texts ...string) (func(), error) {
+	var tokens uint
+
+	if e.opts.Tokenizer != nil && e.limiter.LimitsTokens() {
+		for _, t := range texts {
+			n, err := e.opts.Tokenizer.GetNumTokens(ctx, t)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens += n
+		}
+	}
+
+	return e.limiter.Wait(ctx, tokens)
+}
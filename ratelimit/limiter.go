@@ -0,0 +1,193 @@
+// Package ratelimit provides client-side rate limiting and concurrency control for models and embedders.
+// A Limiter enforces requests-per-minute and tokens-per-minute budgets and can be shared across
+// goroutines and multiple model instances.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LimiterOptions contains options for the Limiter.
+type LimiterOptions struct {
+	// RequestsPerMinute is the maximum number of requests per minute. Zero means unlimited.
+	RequestsPerMinute uint
+
+	// TokensPerMinute is the maximum number of tokens per minute. Zero means unlimited.
+	TokensPerMinute uint
+
+	// MaxConcurrency is the maximum number of concurrent requests. Zero means unlimited.
+	MaxConcurrency int
+}
+
+// Limiter limits the requests and tokens per minute and the number of concurrent requests.
+// It is safe for concurrent use.
+type Limiter struct {
+	mu       sync.Mutex
+	requests *bucket
+	tokens   *bucket
+	sem      chan struct{}
+	now      func() time.Time
+	opts     LimiterOptions
+}
+
+// NewLimiter creates a new Limiter.
+func NewLimiter(optFns ...func(o *LimiterOptions)) *Limiter {
+	opts := LimiterOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	l := &Limiter{
+		now:  time.Now,
+		opts: opts,
+	}
+
+	now := l.now()
+
+	if opts.RequestsPerMinute > 0 {
+		l.requests = newBucket(float64(opts.RequestsPerMinute), now)
+	}
+
+	if opts.TokensPerMinute > 0 {
+		l.tokens = newBucket(float64(opts.TokensPerMinute), now)
+	}
+
+	if opts.MaxConcurrency > 0 {
+		l.sem = make(chan struct{}, opts.MaxConcurrency)
+	}
+
+	return l
+}
+
+// LimitsTokens reports whether the limiter has a tokens-per-minute budget.
+func (l *Limiter) LimitsTokens() bool {
+	return l.tokens != nil
+}
+
+// Wait blocks until a request with the given number of tokens fits into the budgets and
+// a concurrency slot is free. The returned function must be called to release the slot
+// after the request is done. Wait returns the context error if the context is done before.
+func (l *Limiter) Wait(ctx context.Context, tokens uint) (func(), error) {
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	l.mu.Lock()
+
+	now := l.now()
+
+	var delay time.Duration
+
+	requestCost, tokenCost := 0.0, 0.0
+
+	if l.requests != nil {
+		requestCost = l.requests.clamp(1)
+		if d := l.requests.reserve(requestCost, now); d > delay {
+			delay = d
+		}
+	}
+
+	if l.tokens != nil {
+		tokenCost = l.tokens.clamp(float64(tokens))
+		if d := l.tokens.reserve(tokenCost, now); d > delay {
+			delay = d
+		}
+	}
+
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return release, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return release, nil
+	case <-ctx.Done():
+		// Give back the reserved budget, the request is never sent.
+		l.mu.Lock()
+
+		if l.requests != nil {
+			l.requests.cancel(requestCost)
+		}
+
+		if l.tokens != nil {
+			l.tokens.cancel(tokenCost)
+		}
+
+		l.mu.Unlock()
+
+		release()
+
+		return nil, ctx.Err()
+	}
+}
+
+// bucket is a token bucket that is refilled continuously with its capacity per minute.
+// Reservations may drive the available amount negative, which is the debt later reservations wait for.
+type bucket struct {
+	capacity  float64
+	available float64
+	last      time.Time
+}
+
+func newBucket(capacity float64, now time.Time) *bucket {
+	return &bucket{
+		capacity:  capacity,
+		available: capacity,
+		last:      now,
+	}
+}
+
+// clamp limits the cost to the capacity, so that a single request never waits forever.
+func (b *bucket) clamp(cost float64) float64 {
+	if cost > b.capacity {
+		return b.capacity
+	}
+
+	return cost
+}
+
+// reserve takes the cost from the bucket and returns the time to wait until the cost is covered.
+func (b *bucket) reserve(cost float64, now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.available += elapsed.Minutes() * b.capacity
+		if b.available > b.capacity {
+			b.available = b.capacity
+		}
+
+		b.last = now
+	}
+
+	b.available -= cost
+
+	if b.available >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.available / b.capacity * float64(time.Minute))
+}
+
+// cancel returns a reserved cost to the bucket.
+func (b *bucket) cancel(cost float64) {
+	b.available += cost
+	if b.available > b.capacity {
+		b.available = b.capacity
+	}
+}
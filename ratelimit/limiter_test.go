@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	newLimiter := func(now *time.Time, optFns ...func(o *LimiterOptions)) *Limiter {
+		l := NewLimiter(optFns...)
+		l.now = func() time.Time { return *now }
+
+		return l
+	}
+
+	waitShort := func(l *Limiter, tokens uint) (func(), error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		return l.Wait(ctx, tokens)
+	}
+
+	t.Run("RequestsPerMinute", func(t *testing.T) {
+		now := time.Now()
+		l := newLimiter(&now, func(o *LimiterOptions) {
+			o.RequestsPerMinute = 2
+		})
+
+		for i := 0; i < 2; i++ {
+			release, err := waitShort(l, 0)
+			require.NoError(t, err)
+			release()
+		}
+
+		_, err := waitShort(l, 0)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		now = now.Add(30 * time.Second)
+
+		release, err := waitShort(l, 0)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("TokensPerMinute", func(t *testing.T) {
+		now := time.Now()
+		l := newLimiter(&now, func(o *LimiterOptions) {
+			o.TokensPerMinute = 100
+		})
+
+		require.True(t, l.LimitsTokens())
+
+		// Requests exceeding the budget are clamped to the budget.
+		release, err := waitShort(l, 150)
+		require.NoError(t, err)
+		release()
+
+		_, err = waitShort(l, 10)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		now = now.Add(6 * time.Second)
+
+		release, err = waitShort(l, 10)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("MaxConcurrency", func(t *testing.T) {
+		now := time.Now()
+		l := newLimiter(&now, func(o *LimiterOptions) {
+			o.MaxConcurrency = 1
+		})
+
+		require.False(t, l.LimitsTokens())
+
+		release, err := waitShort(l, 0)
+		require.NoError(t, err)
+
+		_, err = waitShort(l, 0)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+
+		release, err = waitShort(l, 0)
+		require.NoError(t, err)
+		release()
+	})
+}
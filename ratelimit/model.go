@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure LLM satisfies the LLM interface.
+var _ schema.LLM = (*LLM)(nil)
+
+// LLM is an LLM whose requests are limited by a Limiter.
+type LLM struct {
+	schema.LLM
+	limiter *Limiter
+}
+
+// NewLLM creates a new rate limited LLM. The token costs are estimated with the tokenizer of the LLM.
+func NewLLM(llm schema.LLM, limiter *Limiter) *LLM {
+	return &LLM{
+		LLM:     llm,
+		limiter: limiter,
+	}
+}
+
+// Generate waits for the limiter and generates text based on the provided prompt and options.
+func (l *LLM) Generate(ctx context.Context, prompt string, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	var tokens uint
+
+	if l.limiter.LimitsTokens() {
+		n, err := l.LLM.GetNumTokens(ctx, prompt)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = n
+	}
+
+	release, err := l.limiter.Wait(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return l.LLM.Generate(ctx, prompt, optFns...)
+}
+
+// Compile time check to ensure ChatModel satisfies the ChatModel interface.
+var _ schema.ChatModel = (*ChatModel)(nil)
+
+// ChatModel is a chat model whose requests are limited by a Limiter.
+type ChatModel struct {
+	schema.ChatModel
+	limiter *Limiter
+}
+
+// NewChatModel creates a new rate limited chat model. The token costs are estimated with the tokenizer of the chat model.
+func NewChatModel(chatModel schema.ChatModel, limiter *Limiter) *ChatModel {
+	return &ChatModel{
+		ChatModel: chatModel,
+		limiter:   limiter,
+	}
+}
+
+// Generate waits for the limiter and generates text based on the provided chat messages and options.
+func (cm *ChatModel) Generate(ctx context.Context, messages schema.ChatMessages, optFns ...func(o *schema.GenerateOptions)) (*schema.ModelResult, error) {
+	var tokens uint
+
+	if cm.limiter.LimitsTokens() {
+		n, err := cm.ChatModel.GetNumTokensFromMessage(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = n
+	}
+
+	release, err := cm.limiter.Wait(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return cm.ChatModel.Generate(ctx, messages, optFns...)
+}
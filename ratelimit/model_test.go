@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTokenizer struct{}
+
+func (t *mockTokenizer) GetNumTokens(ctx context.Context, text string) (uint, error) {
+	return uint(len(strings.Fields(text))), nil
+}
+
+func (t *mockTokenizer) GetNumTokensFromMessage(ctx context.Context, messages schema.ChatMessages) (uint, error) {
+	var n uint
+
+	for _, m := range messages {
+		n += uint(len(strings.Fields(m.Content())))
+	}
+
+	return n, nil
+}
+
+func TestModels(t *testing.T) {
+	newLimiter := func() *Limiter {
+		return NewLimiter(func(o *LimiterOptions) {
+			o.TokensPerMinute = 5
+		})
+	}
+
+	t.Run("LLM", func(t *testing.T) {
+		// A shared limiter limits all wrapped models.
+		limiter := newLimiter()
+
+		llm1 := NewLLM(llm.NewSimpleFake("foo", func(o *llm.FakeOptions) {
+			o.Tokenizer = &mockTokenizer{}
+		}), limiter)
+
+		llm2 := NewLLM(llm.NewSimpleFake("bar", func(o *llm.FakeOptions) {
+			o.Tokenizer = &mockTokenizer{}
+		}), limiter)
+
+		result, err := llm1.Generate(context.Background(), "one two three")
+		require.NoError(t, err)
+		require.Equal(t, "foo", result.Generations[0].Text)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = llm2.Generate(ctx, "four five six")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("ChatModel", func(t *testing.T) {
+		cm := NewChatModel(chatmodel.NewSimpleFake("foo", func(o *chatmodel.FakeOptions) {
+			o.Tokenizer = &mockTokenizer{}
+		}), newLimiter())
+
+		result, err := cm.Generate(context.Background(), schema.ChatMessages{schema.NewHumanChatMessage("one two three")})
+		require.NoError(t, err)
+		require.Equal(t, "foo", result.Generations[0].Text)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = cm.Generate(ctx, schema.ChatMessages{schema.NewHumanChatMessage("four five six")})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/hupe1980/golc/agent"
+	"github.com/hupe1980/golc/schema"
+)
+
+// LoadAgent creates an agent executor from the spec.
+func (r *Registry) LoadAgent(spec *AgentSpec) (*agent.Executor, error) {
+	if spec.Model == "" {
+		return nil, fmt.Errorf("%w: model", ErrMissingField)
+	}
+
+	m, err := r.model(spec.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]schema.Tool, len(spec.Tools))
+
+	for i, name := range spec.Tools {
+		t, err := r.tool(name)
+		if err != nil {
+			return nil, err
+		}
+
+		tools[i] = t
+	}
+
+	switch spec.Type {
+	case AgentTypeReactDescription:
+		return agent.NewReactDescription(m, tools, func(o *agent.ReactDescriptionOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeConversationalReactDescription:
+		return agent.NewConversationalReactDescription(m, tools, func(o *agent.ConversationalReactDescriptionOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeOpenAIFunctions:
+		chatModel, ok := m.(schema.ChatModel)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrChatModelRequired, spec.Model)
+		}
+
+		return agent.NewOpenAIFunctions(chatModel, tools, func(o *agent.OpenAIFunctionsOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeToolCalling:
+		chatModel, ok := m.(schema.ChatModel)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrChatModelRequired, spec.Model)
+		}
+
+		return agent.NewToolCalling(chatModel, tools, func(o *agent.ToolCallingOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeStructuredChat:
+		return agent.NewStructuredChat(m, tools, func(o *agent.StructuredChatOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeSelfAskWithSearch:
+		if len(tools) != 1 {
+			return nil, fmt.Errorf("%w: %s requires exactly one search tool", ErrInvalidToolCount, spec.Type)
+		}
+
+		return agent.NewSelfAskWithSearch(m, tools[0], func(o *agent.SelfAskWithSearchOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	case AgentTypeReWOO:
+		return agent.NewReWOO(m, tools, func(o *agent.ReWOOOptions) {
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+
+			if spec.MaxIterations > 0 {
+				o.MaxIterations = spec.MaxIterations
+			}
+		})
+	default:
+		return nil, fmt.Errorf("%w: agent type %q", ErrUnknownType, spec.Type)
+	}
+}
+
+// LoadAgentFile reads an agent spec from a file and creates the agent executor.
+func (r *Registry) LoadAgentFile(path string) (*agent.Executor, error) {
+	spec := &AgentSpec{}
+	if err := ReadFile(path, spec); err != nil {
+		return nil, err
+	}
+
+	return r.LoadAgent(spec)
+}
@@ -0,0 +1,195 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/internal/util"
+	"github.com/hupe1980/golc/rag"
+	"github.com/hupe1980/golc/schema"
+)
+
+// LoadChain creates a chain from the spec and validates it against the input and output keys of the spec.
+func (r *Registry) LoadChain(spec *ChainSpec) (schema.Chain, error) {
+	c, err := r.loadChain(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateKeys(c, spec); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// LoadChainFile reads a chain spec from a file and creates the chain.
+func (r *Registry) LoadChainFile(path string) (schema.Chain, error) {
+	spec := &ChainSpec{}
+	if err := ReadFile(path, spec); err != nil {
+		return nil, err
+	}
+
+	return r.LoadChain(spec)
+}
+
+func (r *Registry) loadChain(spec *ChainSpec) (schema.Chain, error) {
+	switch spec.Type {
+	case ChainTypeLLM:
+		return r.loadLLMChain(spec)
+	case ChainTypeConversation:
+		m, err := r.requireModel(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		var p schema.PromptTemplate
+
+		if spec.Prompt != nil {
+			p, err = r.LoadPrompt(spec.Prompt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return chain.NewConversation(m, func(o *chain.ConversationOptions) {
+			o.Prompt = p
+
+			if spec.OutputKey != "" {
+				o.OutputKey = spec.OutputKey
+			}
+		})
+	case ChainTypeSequential:
+		if len(spec.Chains) == 0 {
+			return nil, fmt.Errorf("%w: chains", ErrMissingField)
+		}
+
+		chains := make([]schema.Chain, len(spec.Chains))
+
+		for i := range spec.Chains {
+			c, err := r.LoadChain(&spec.Chains[i])
+			if err != nil {
+				return nil, err
+			}
+
+			chains[i] = c
+		}
+
+		return chain.NewSequential(chains, spec.InputKeys, func(o *chain.SequentialOptions) {
+			o.OutputKeys = spec.OutputKeys
+			o.ReturnAll = spec.ReturnAll
+		})
+	case ChainTypeRetrievalQA:
+		m, err := r.requireModel(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		if spec.Retriever == "" {
+			return nil, fmt.Errorf("%w: retriever", ErrMissingField)
+		}
+
+		retriever, err := r.retriever(spec.Retriever)
+		if err != nil {
+			return nil, err
+		}
+
+		var p schema.PromptTemplate
+
+		if spec.Prompt != nil {
+			p, err = r.LoadPrompt(spec.Prompt)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return rag.NewRetrievalQA(m, retriever, func(o *rag.RetrievalQAOptions) {
+			o.RetrievalQAPrompt = p
+			o.ReturnSourceDocuments = spec.ReturnSourceDocuments
+
+			if spec.InputKey != "" {
+				o.InputKey = spec.InputKey
+			}
+		})
+	case ChainTypeStuffDocuments:
+		if spec.LLMChain == nil {
+			return nil, fmt.Errorf("%w: llmChain", ErrMissingField)
+		}
+
+		llmChain, err := r.loadLLMChain(spec.LLMChain)
+		if err != nil {
+			return nil, err
+		}
+
+		return rag.NewStuffDocuments(llmChain, func(o *rag.StuffDocumentsOptions) {
+			if spec.InputKey != "" {
+				o.InputKey = spec.InputKey
+			}
+
+			if spec.DocumentVariableName != "" {
+				o.DocumentVariableName = spec.DocumentVariableName
+			}
+		})
+	default:
+		return nil, fmt.Errorf("%w: chain type %q", ErrUnknownType, spec.Type)
+	}
+}
+
+func (r *Registry) loadLLMChain(spec *ChainSpec) (*chain.LLM, error) {
+	if spec.Type != ChainTypeLLM {
+		return nil, fmt.Errorf("%w: chain type %q, expected %q", ErrUnknownType, spec.Type, ChainTypeLLM)
+	}
+
+	m, err := r.requireModel(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Prompt == nil {
+		return nil, fmt.Errorf("%w: prompt", ErrMissingField)
+	}
+
+	p, err := r.LoadPrompt(spec.Prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	return chain.NewLLM(m, p, func(o *chain.LLMOptions) {
+		if spec.OutputKey != "" {
+			o.OutputKey = spec.OutputKey
+		}
+	})
+}
+
+func (r *Registry) requireModel(spec *ChainSpec) (schema.Model, error) {
+	if spec.Model == "" {
+		return nil, fmt.Errorf("%w: model", ErrMissingField)
+	}
+
+	return r.model(spec.Model)
+}
+
+// validateKeys checks that the chain accepts exactly the input keys of the spec and
+// returns all output keys of the spec. Empty keys in the spec are not validated.
+func validateKeys(c schema.Chain, spec *ChainSpec) error {
+	if len(spec.InputKeys) > 0 {
+		unexpected, missing := util.Difference(spec.InputKeys, c.InputKeys())
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s chain requires %s", ErrInvalidInputKeys, spec.Type, strings.Join(missing, ","))
+		}
+
+		if len(unexpected) > 0 {
+			return fmt.Errorf("%w: %s chain does not accept %s", ErrInvalidInputKeys, spec.Type, strings.Join(unexpected, ","))
+		}
+	}
+
+	if len(spec.OutputKeys) > 0 {
+		missing, _ := util.Difference(spec.OutputKeys, c.OutputKeys())
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s chain does not return %s", ErrInvalidOutputKeys, spec.Type, strings.Join(missing, ","))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package registry
+
+import "errors"
+
+var (
+	ErrUnknownModel       = errors.New("unknown model")
+	ErrUnknownRetriever   = errors.New("unknown retriever")
+	ErrUnknownTool        = errors.New("unknown tool")
+	ErrUnknownType        = errors.New("unknown type")
+	ErrUnknownFormat      = errors.New("unknown format")
+	ErrMissingField       = errors.New("missing field")
+	ErrInvalidInputKeys   = errors.New("invalid input keys")
+	ErrInvalidOutputKeys  = errors.New("invalid output keys")
+	ErrDuplicateFactory   = errors.New("duplicate factory")
+	ErrChatModelRequired  = errors.New("chat model required")
+	ErrUnsupportedMessage = errors.New("unsupported message role")
+	ErrInvalidToolCount   = errors.New("invalid number of tools")
+	ErrUnsupportedExport  = errors.New("unsupported export")
+)
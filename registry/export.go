@@ -0,0 +1,298 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/hupe1980/golc/agent"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/rag"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ExportOptions contains options for exporting chains and agents to specs. Specs reference models,
+// retrievers and tools by the names they are registered under, so the names must be resolvable.
+type ExportOptions struct {
+	// ModelName returns the name the model is registered under.
+	ModelName func(m schema.Model) (string, error)
+
+	// RetrieverName returns the name the retriever is registered under.
+	RetrieverName func(r schema.Retriever) (string, error)
+
+	// ToolName returns the name the tool is registered under. It defaults to the name of the tool.
+	ToolName func(t schema.Tool) (string, error)
+}
+
+// chatTemplates is implemented by chat templates that wrap other chat templates.
+type chatTemplates interface {
+	ChatTemplates() []prompt.ChatTemplate
+}
+
+// messageTemplates is implemented by chat templates of message templates.
+type messageTemplates interface {
+	MessageTemplates() []prompt.MessageTemplate
+}
+
+// messagesPlaceholder is implemented by chat templates that insert messages.
+type messagesPlaceholder interface {
+	InputKey() string
+}
+
+// modelProvider is implemented by agents that provide their model.
+type modelProvider interface {
+	Model() schema.Model
+}
+
+// PromptToSpec exports a prompt template created by the prompt package to a spec.
+// Output parsers and formatter options are not part of specs and are not exported.
+func PromptToSpec(p schema.PromptTemplate) (*PromptSpec, error) {
+	switch v := p.(type) {
+	case *prompt.Template:
+		return &PromptSpec{
+			Type:          PromptTypeTemplate,
+			Template:      v.Template(),
+			PartialValues: v.PartialValues(),
+		}, nil
+	case *prompt.FewShotTemplate:
+		return &PromptSpec{
+			Type:            PromptTypeFewShot,
+			Template:        v.Template(),
+			PartialValues:   v.PartialValues(),
+			Examples:        v.Examples(),
+			ExampleTemplate: v.ExampleTemplate().Template(),
+			Prefix:          v.Prefix(),
+			Separator:       v.Separator(),
+		}, nil
+	case prompt.ChatTemplate:
+		messages, err := chatMessageSpecs(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return &PromptSpec{
+			Type:     PromptTypeChat,
+			Messages: messages,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: prompt of type %T", ErrUnsupportedExport, p)
+	}
+}
+
+// ChainToSpec exports a chain to a spec. It supports the chain types of ChainSpec.
+func ChainToSpec(c schema.Chain, optFns ...func(o *ExportOptions)) (*ChainSpec, error) {
+	opts := newExportOptions(optFns...)
+
+	return chainToSpec(c, opts)
+}
+
+// AgentToSpec exports an agent executor to a spec. It supports the agent types of AgentSpec.
+func AgentToSpec(e *agent.Executor, optFns ...func(o *ExportOptions)) (*AgentSpec, error) {
+	opts := newExportOptions(optFns...)
+
+	var agentType string
+
+	switch e.Agent().(type) {
+	case *agent.ReactDescription:
+		agentType = AgentTypeReactDescription
+	case *agent.ConversationalReactDescription:
+		agentType = AgentTypeConversationalReactDescription
+	case *agent.OpenAIFunctions:
+		agentType = AgentTypeOpenAIFunctions
+	case *agent.ToolCalling:
+		agentType = AgentTypeToolCalling
+	case *agent.StructuredChat:
+		agentType = AgentTypeStructuredChat
+	case *agent.SelfAskWithSearch:
+		agentType = AgentTypeSelfAskWithSearch
+	case *agent.ReWOO:
+		agentType = AgentTypeReWOO
+	default:
+		return nil, fmt.Errorf("%w: agent of type %T", ErrUnsupportedExport, e.Agent())
+	}
+
+	// All supported agents provide their model.
+	m, err := modelName(e.Agent().(modelProvider).Model(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]string, len(e.Tools()))
+
+	for i, t := range e.Tools() {
+		name, err := opts.ToolName(t)
+		if err != nil {
+			return nil, err
+		}
+
+		tools[i] = name
+	}
+
+	return &AgentSpec{
+		Type:          agentType,
+		Model:         m,
+		Tools:         tools,
+		OutputKey:     e.Agent().OutputKeys()[0],
+		MaxIterations: e.MaxIterations(),
+	}, nil
+}
+
+func newExportOptions(optFns ...func(o *ExportOptions)) ExportOptions {
+	opts := ExportOptions{
+		ToolName: func(t schema.Tool) (string, error) {
+			return t.Name(), nil
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return opts
+}
+
+func chainToSpec(c schema.Chain, opts ExportOptions) (*ChainSpec, error) {
+	switch v := c.(type) {
+	case *chain.LLM:
+		return llmChainToSpec(v, opts)
+	case *chain.Conversation:
+		m, err := modelName(v.Model(), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := PromptToSpec(v.Prompt())
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChainSpec{
+			Type:      ChainTypeConversation,
+			Model:     m,
+			Prompt:    p,
+			OutputKey: v.OutputKeys()[0],
+		}, nil
+	case *chain.Sequential:
+		chains := make([]ChainSpec, len(v.Chains()))
+
+		for i, sc := range v.Chains() {
+			spec, err := chainToSpec(sc, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			chains[i] = *spec
+		}
+
+		return &ChainSpec{
+			Type:       ChainTypeSequential,
+			InputKeys:  v.InputKeys(),
+			OutputKeys: v.OutputKeys(),
+			Chains:     chains,
+		}, nil
+	case *rag.StuffDocuments:
+		llmChain, err := llmChainToSpec(v.LLMChain(), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChainSpec{
+			Type:                 ChainTypeStuffDocuments,
+			InputKey:             v.InputKeys()[0],
+			DocumentVariableName: v.DocumentVariableName(),
+			LLMChain:             llmChain,
+		}, nil
+	case *rag.RetrievalQA:
+		llmChain, err := llmChainToSpec(v.StuffDocumentsChain().LLMChain(), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.RetrieverName == nil {
+			return nil, fmt.Errorf("%w: no name for retriever of type %T", ErrUnknownRetriever, v.Retriever())
+		}
+
+		retriever, err := opts.RetrieverName(v.Retriever())
+		if err != nil {
+			return nil, err
+		}
+
+		return &ChainSpec{
+			Type:                  ChainTypeRetrievalQA,
+			Model:                 llmChain.Model,
+			Retriever:             retriever,
+			Prompt:                llmChain.Prompt,
+			InputKey:              v.InputKeys()[0],
+			ReturnSourceDocuments: v.ReturnSourceDocuments(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: chain of type %T", ErrUnsupportedExport, c)
+	}
+}
+
+func llmChainToSpec(c *chain.LLM, opts ExportOptions) (*ChainSpec, error) {
+	m, err := modelName(c.Model(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := PromptToSpec(c.Prompt())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChainSpec{
+		Type:      ChainTypeLLM,
+		Model:     m,
+		Prompt:    p,
+		OutputKey: c.OutputKeys()[0],
+	}, nil
+}
+
+func modelName(m schema.Model, opts ExportOptions) (string, error) {
+	if opts.ModelName == nil {
+		return "", fmt.Errorf("%w: no name for model of type %s", ErrUnknownModel, m.Type())
+	}
+
+	return opts.ModelName(m)
+}
+
+// chatMessageSpecs exports the message templates of a chat template. Wrapped chat templates are flattened,
+// LoadPrompt groups the messages again.
+func chatMessageSpecs(ct prompt.ChatTemplate) ([]MessageSpec, error) {
+	switch v := ct.(type) {
+	case chatTemplates:
+		messages := []MessageSpec{}
+
+		for _, wrapped := range v.ChatTemplates() {
+			specs, err := chatMessageSpecs(wrapped)
+			if err != nil {
+				return nil, err
+			}
+
+			messages = append(messages, specs...)
+		}
+
+		return messages, nil
+	case messageTemplates:
+		messages := make([]MessageSpec, len(v.MessageTemplates()))
+
+		for i, mt := range v.MessageTemplates() {
+			switch m := mt.(type) {
+			case *prompt.SystemMessageTemplate:
+				messages[i] = MessageSpec{Role: MessageRoleSystem, Template: m.Template()}
+			case *prompt.HumanMessageTemplate:
+				messages[i] = MessageSpec{Role: MessageRoleHuman, Template: m.Template()}
+			case *prompt.AIMessageTemplate:
+				messages[i] = MessageSpec{Role: MessageRoleAI, Template: m.Template()}
+			default:
+				return nil, fmt.Errorf("%w: message template of type %T", ErrUnsupportedExport, mt)
+			}
+		}
+
+		return messages, nil
+	case messagesPlaceholder:
+		return []MessageSpec{{Role: MessageRolePlaceholder, InputKey: v.InputKey()}}, nil
+	default:
+		return nil, fmt.Errorf("%w: chat template of type %T", ErrUnsupportedExport, ct)
+	}
+}
@@ -0,0 +1,247 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/agent"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/rag"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTrip marshals the spec, unmarshals it into target and returns the target.
+func roundTrip[T any](t *testing.T, spec *T) *T {
+	data, err := Marshal(spec, FormatYAML)
+	require.NoError(t, err)
+
+	target := new(T)
+	require.NoError(t, Unmarshal(data, FormatYAML, target))
+
+	return target
+}
+
+// namedAs returns an export option that names all models, retrievers and tools with the given name.
+func namedAs(name string) func(o *ExportOptions) {
+	return func(o *ExportOptions) {
+		o.ModelName = func(m schema.Model) (string, error) { return name, nil }
+		o.RetrieverName = func(r schema.Retriever) (string, error) { return name, nil }
+	}
+}
+
+func TestPromptToSpec(t *testing.T) {
+	r := newTestRegistry(t)
+
+	values := map[string]any{
+		"input":   "c",
+		"history": schema.ChatMessages{schema.NewAIChatMessage("Hello")},
+	}
+
+	testCases := []struct {
+		name   string
+		prompt schema.PromptTemplate
+	}{
+		{
+			name: "Template",
+			prompt: prompt.NewTemplate("{{.greeting}} {{.input}}", func(o *prompt.TemplateOptions) {
+				o.PartialValues = map[string]any{"greeting": "Hi"}
+			}),
+		},
+		{
+			name: "Few shot",
+			prompt: prompt.NewFewShotTemplate("Input: {{.input}}", []map[string]any{{"in": "a", "out": "b"}}, prompt.NewTemplate("Input: {{.in}} Output: {{.out}}"), func(o *prompt.FewShotTemplateOptions) {
+				o.Prefix = "Examples:"
+				o.Separator = "\n"
+			}),
+		},
+		{
+			name: "Chat",
+			prompt: prompt.NewChatTemplateWrapper(
+				prompt.NewChatTemplate([]prompt.MessageTemplate{prompt.NewSystemMessageTemplate("You are golc.")}),
+				prompt.NewMessagesPlaceholder("history"),
+				prompt.NewChatTemplate([]prompt.MessageTemplate{
+					prompt.NewHumanMessageTemplate("{{.input}}"),
+					prompt.NewAIMessageTemplate("Thinking about {{.input}}"),
+				}),
+			),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := PromptToSpec(tc.prompt)
+			require.NoError(t, err)
+
+			loaded, err := r.LoadPrompt(roundTrip(t, spec))
+			require.NoError(t, err)
+
+			loadedSpec, err := PromptToSpec(loaded)
+			require.NoError(t, err)
+			require.Equal(t, spec, loadedSpec)
+
+			expected, err := tc.prompt.Format(values)
+			require.NoError(t, err)
+
+			actual, err := loaded.Format(values)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("Unsupported prompt", func(t *testing.T) {
+		_, err := PromptToSpec(prompt.NewChatTemplate([]prompt.MessageTemplate{&customMessageTemplate{}}))
+		require.ErrorIs(t, err, ErrUnsupportedExport)
+	})
+}
+
+func TestChainToSpec(t *testing.T) {
+	r := newTestRegistry(t)
+
+	require.NoError(t, r.RegisterRetriever("fake", func() (schema.Retriever, error) {
+		return &fakeRetriever{}, nil
+	}))
+
+	model := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+		return &schema.ModelResult{
+			Generations: []schema.Generation{{Text: prompt}},
+			LLMOutput:   map[string]any{},
+		}, nil
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		write, err := chain.NewLLM(model, prompt.NewTemplate("Write about {{.topic}}"))
+		require.NoError(t, err)
+
+		summarize, err := chain.NewLLM(model, prompt.NewTemplate("Summarize: {{.text}}"), func(o *chain.LLMOptions) {
+			o.OutputKey = "summary"
+		})
+		require.NoError(t, err)
+
+		c, err := chain.NewSequential([]schema.Chain{write, summarize}, []string{"topic"})
+		require.NoError(t, err)
+
+		spec, err := ChainToSpec(c, namedAs("fake"))
+		require.NoError(t, err)
+
+		loaded, err := r.LoadChain(roundTrip(t, spec))
+		require.NoError(t, err)
+
+		loadedSpec, err := ChainToSpec(loaded, namedAs("fake"))
+		require.NoError(t, err)
+		require.Equal(t, spec, loadedSpec)
+
+		outputs, err := golc.Call(context.Background(), loaded, schema.ChainValues{"topic": "golc"})
+		require.NoError(t, err)
+		require.Equal(t, "Summarize: Write about golc", outputs["summary"])
+	})
+
+	t.Run("Retrieval QA", func(t *testing.T) {
+		c, err := rag.NewRetrievalQA(model, &fakeRetriever{}, func(o *rag.RetrievalQAOptions) {
+			o.RetrievalQAPrompt = prompt.NewTemplate("{{.text}} - {{.question}}")
+			o.ReturnSourceDocuments = true
+		})
+		require.NoError(t, err)
+
+		spec, err := ChainToSpec(c, namedAs("fake"))
+		require.NoError(t, err)
+
+		loaded, err := r.LoadChain(roundTrip(t, spec))
+		require.NoError(t, err)
+
+		loadedSpec, err := ChainToSpec(loaded, namedAs("fake"))
+		require.NoError(t, err)
+		require.Equal(t, spec, loadedSpec)
+
+		outputs, err := golc.Call(context.Background(), loaded, schema.ChainValues{"question": "golc?"})
+		require.NoError(t, err)
+		require.Equal(t, "golc is a library - golc?", outputs["text"])
+		require.Contains(t, outputs, "sourceDocuments")
+	})
+
+	t.Run("Stuff documents", func(t *testing.T) {
+		llmChain, err := chain.NewLLM(model, prompt.NewTemplate("Docs: {{.context}}"))
+		require.NoError(t, err)
+
+		c, err := rag.NewStuffDocuments(llmChain, func(o *rag.StuffDocumentsOptions) {
+			o.InputKey = "docs"
+			o.DocumentVariableName = "context"
+		})
+		require.NoError(t, err)
+
+		spec, err := ChainToSpec(c, namedAs("fake"))
+		require.NoError(t, err)
+
+		loaded, err := r.LoadChain(roundTrip(t, spec))
+		require.NoError(t, err)
+
+		loadedSpec, err := ChainToSpec(loaded, namedAs("fake"))
+		require.NoError(t, err)
+		require.Equal(t, spec, loadedSpec)
+	})
+
+	t.Run("Unnamed model", func(t *testing.T) {
+		c, err := chain.NewLLM(model, prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		_, err = ChainToSpec(c)
+		require.ErrorIs(t, err, ErrUnknownModel)
+	})
+}
+
+func TestAgentToSpec(t *testing.T) {
+	r := newTestRegistry(t)
+
+	require.NoError(t, r.RegisterTool("Sleep", func() (schema.Tool, error) {
+		return tool.NewSleep(), nil
+	}))
+
+	executor, err := agent.NewReactDescription(llm.NewSimpleFake("Final Answer: done"), []schema.Tool{tool.NewSleep()}, func(o *agent.ReactDescriptionOptions) {
+		o.OutputKey = "answer"
+		o.MaxIterations = 3
+	})
+	require.NoError(t, err)
+
+	spec, err := AgentToSpec(executor, namedAs("fake"))
+	require.NoError(t, err)
+	require.Equal(t, &AgentSpec{
+		Type:          AgentTypeReactDescription,
+		Model:         "fake",
+		Tools:         []string{"Sleep"},
+		OutputKey:     "answer",
+		MaxIterations: 3,
+	}, spec)
+
+	loaded, err := r.LoadAgent(roundTrip(t, spec))
+	require.NoError(t, err)
+
+	loadedSpec, err := AgentToSpec(loaded, namedAs("fake"))
+	require.NoError(t, err)
+	require.Equal(t, spec, loadedSpec)
+}
+
+// Compile time check to ensure fakeRetriever satisfies the Retriever interface.
+var _ schema.Retriever = (*fakeRetriever)(nil)
+
+type fakeRetriever struct{}
+
+func (r *fakeRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	return []schema.Document{{PageContent: "golc is a library"}}, nil
+}
+
+func (r *fakeRetriever) Verbose() bool {
+	return false
+}
+
+func (r *fakeRetriever) Callbacks() []schema.Callback {
+	return nil
+}
+
+// customMessageTemplate is a message template that is not created by the prompt package.
+type customMessageTemplate struct {
+	prompt.MessageTemplate
+}
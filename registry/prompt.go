@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// LoadPrompt creates a prompt template from the spec.
+func (r *Registry) LoadPrompt(spec *PromptSpec) (schema.PromptTemplate, error) {
+	switch spec.Type {
+	case PromptTypeTemplate:
+		if spec.Template == "" {
+			return nil, fmt.Errorf("%w: template", ErrMissingField)
+		}
+
+		return prompt.NewTemplate(spec.Template, func(o *prompt.TemplateOptions) {
+			o.PartialValues = spec.PartialValues
+		}), nil
+	case PromptTypeChat:
+		return loadChatPrompt(spec.Messages)
+	case PromptTypeFewShot:
+		if spec.Template == "" {
+			return nil, fmt.Errorf("%w: template", ErrMissingField)
+		}
+
+		if spec.ExampleTemplate == "" {
+			return nil, fmt.Errorf("%w: exampleTemplate", ErrMissingField)
+		}
+
+		return prompt.NewFewShotTemplate(spec.Template, spec.Examples, prompt.NewTemplate(spec.ExampleTemplate), func(o *prompt.FewShotTemplateOptions) {
+			o.PartialValues = spec.PartialValues
+			o.Prefix = spec.Prefix
+
+			if spec.Separator != "" {
+				o.Separator = spec.Separator
+			}
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: prompt type %q", ErrUnknownType, spec.Type)
+	}
+}
+
+// LoadPromptFile reads a prompt spec from a file and creates the prompt template.
+func (r *Registry) LoadPromptFile(path string) (schema.PromptTemplate, error) {
+	spec := &PromptSpec{}
+	if err := ReadFile(path, spec); err != nil {
+		return nil, err
+	}
+
+	return r.LoadPrompt(spec)
+}
+
+// loadChatPrompt creates a chat template from the message specs. Consecutive messages are grouped
+// into a single chat template, placeholders are inserted between the groups.
+func loadChatPrompt(messages []MessageSpec) (prompt.ChatTemplate, error) {
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("%w: messages", ErrMissingField)
+	}
+
+	chatTemplates := []prompt.ChatTemplate{}
+	messageTemplates := []prompt.MessageTemplate{}
+
+	flush := func() {
+		if len(messageTemplates) > 0 {
+			chatTemplates = append(chatTemplates, prompt.NewChatTemplate(messageTemplates))
+			messageTemplates = []prompt.MessageTemplate{}
+		}
+	}
+
+	for _, m := range messages {
+		switch m.Role {
+		case MessageRoleSystem:
+			messageTemplates = append(messageTemplates, prompt.NewSystemMessageTemplate(m.Template))
+		case MessageRoleHuman:
+			messageTemplates = append(messageTemplates, prompt.NewHumanMessageTemplate(m.Template))
+		case MessageRoleAI:
+			messageTemplates = append(messageTemplates, prompt.NewAIMessageTemplate(m.Template))
+		case MessageRolePlaceholder:
+			if m.InputKey == "" {
+				return nil, fmt.Errorf("%w: inputKey", ErrMissingField)
+			}
+
+			flush()
+
+			chatTemplates = append(chatTemplates, prompt.NewMessagesPlaceholder(m.InputKey))
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedMessage, m.Role)
+		}
+	}
+
+	flush()
+
+	if len(chatTemplates) == 1 {
+		return chatTemplates[0], nil
+	}
+
+	return prompt.NewChatTemplateWrapper(chatTemplates...), nil
+}
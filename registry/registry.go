@@ -0,0 +1,231 @@
+// Package registry provides the declarative serialization and loading of chains, prompts and
+// agents from YAML or JSON specs. Models, retrievers and tools are referenced by name and
+// resolved through factories registered with a Registry. Existing chains, prompts and agents
+// are exported to specs with ChainToSpec, PromptToSpec and AgentToSpec.
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization format of a spec.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath returns the format of a spec file based on its extension.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+}
+
+// Marshal serializes a spec in the given format.
+func Marshal(spec any, format Format) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(spec, "", "  ")
+	case FormatYAML:
+		// Strings that only consist of line breaks, e.g. separators, are lost when the spec is encoded
+		// directly, so the YAML document is built from the JSON representation of the spec.
+		data, err := json.Marshal(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		node := &yaml.Node{}
+		if err := yaml.Unmarshal(data, node); err != nil {
+			return nil, err
+		}
+
+		resetStyle(node)
+
+		return yaml.Marshal(node)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// resetStyle resets the JSON style of the nodes to the default YAML style. Strings that only
+// consist of whitespace stay double quoted.
+func resetStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || strings.TrimSpace(node.Value) != "" {
+		node.Style = 0
+	}
+
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// Unmarshal deserializes a spec in the given format. Unknown fields are rejected, so that
+// misspelled keys do not silently fall back to defaults.
+func Unmarshal(data []byte, format Format, spec any) error {
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+
+		return decoder.Decode(spec)
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(spec); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// SaveFile serializes a spec to a file. The format is determined by the file extension.
+func SaveFile(path string, spec any) error {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := Marshal(spec, format)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// ReadFile deserializes a spec from a file. The format is determined by the file extension.
+func ReadFile(path string, spec any) error {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(data, format, spec)
+}
+
+// ModelFactory creates a model referenced by name in a spec.
+type ModelFactory func() (schema.Model, error)
+
+// RetrieverFactory creates a retriever referenced by name in a spec.
+type RetrieverFactory func() (schema.Retriever, error)
+
+// ToolFactory creates a tool referenced by name in a spec.
+type ToolFactory func() (schema.Tool, error)
+
+// Registry resolves the models, retrievers and tools referenced in specs and loads
+// chains, prompts and agents from specs. It is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	models     map[string]ModelFactory
+	retrievers map[string]RetrieverFactory
+	tools      map[string]ToolFactory
+}
+
+// New creates a new empty Registry.
+func New() *Registry {
+	return &Registry{
+		models:     make(map[string]ModelFactory),
+		retrievers: make(map[string]RetrieverFactory),
+		tools:      make(map[string]ToolFactory),
+	}
+}
+
+// RegisterModel registers a model factory under the given name.
+func (r *Registry) RegisterModel(name string, factory ModelFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return register(r.models, name, factory)
+}
+
+// RegisterRetriever registers a retriever factory under the given name.
+func (r *Registry) RegisterRetriever(name string, factory RetrieverFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return register(r.retrievers, name, factory)
+}
+
+// RegisterTool registers a tool factory under the given name.
+func (r *Registry) RegisterTool(name string, factory ToolFactory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return register(r.tools, name, factory)
+}
+
+// model creates the model registered under the given name.
+func (r *Registry) model(name string) (schema.Model, error) {
+	r.mu.RLock()
+	factory, ok := r.models[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownModel, name)
+	}
+
+	return factory()
+}
+
+// retriever creates the retriever registered under the given name.
+func (r *Registry) retriever(name string) (schema.Retriever, error) {
+	r.mu.RLock()
+	factory, ok := r.retrievers[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRetriever, name)
+	}
+
+	return factory()
+}
+
+// tool creates the tool registered under the given name.
+func (r *Registry) tool(name string) (schema.Tool, error) {
+	r.mu.RLock()
+	factory, ok := r.tools[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTool, name)
+	}
+
+	return factory()
+}
+
+func register[T any](factories map[string]T, name string, factory T) error {
+	if _, ok := factories[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateFactory, name)
+	}
+
+	factories[name] = factory
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model/chatmodel"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	r := New()
+
+	require.NoError(t, r.RegisterModel("fake", func() (schema.Model, error) {
+		return llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: prompt}},
+				LLMOutput:   map[string]any{},
+			}, nil
+		}), nil
+	}))
+
+	require.NoError(t, r.RegisterModel("chat", func() (schema.Model, error) {
+		return chatmodel.NewSimpleFake("answer"), nil
+	}))
+
+	return r
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Duplicate factory", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		err := r.RegisterModel("fake", func() (schema.Model, error) {
+			return llm.NewSimpleFake("foo"), nil
+		})
+		require.ErrorIs(t, err, ErrDuplicateFactory)
+	})
+
+	t.Run("Sequential chain from YAML", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		data := []byte(`
+type: sequential
+inputKeys: [topic]
+outputKeys: [summary]
+chains:
+  - type: llm
+    model: fake
+    outputKey: text
+    inputKeys: [topic]
+    prompt:
+      type: template
+      template: "Write about {{.topic}}"
+  - type: llm
+    model: fake
+    outputKey: summary
+    prompt:
+      type: template
+      template: "Summarize: {{.text}}"
+`)
+
+		spec := &ChainSpec{}
+		require.NoError(t, Unmarshal(data, FormatYAML, spec))
+
+		c, err := r.LoadChain(spec)
+		require.NoError(t, err)
+
+		outputs, err := golc.Call(context.Background(), c, schema.ChainValues{"topic": "golc"})
+		require.NoError(t, err)
+		require.Equal(t, "Summarize: Write about golc", outputs["summary"])
+	})
+
+	t.Run("Invalid input keys", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		_, err := r.LoadChain(&ChainSpec{
+			Type:      ChainTypeLLM,
+			Model:     "fake",
+			InputKeys: []string{"question"},
+			Prompt: &PromptSpec{
+				Type:     PromptTypeTemplate,
+				Template: "{{.input}}",
+			},
+		})
+		require.ErrorIs(t, err, ErrInvalidInputKeys)
+	})
+
+	t.Run("Invalid output keys", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		_, err := r.LoadChain(&ChainSpec{
+			Type:       ChainTypeConversation,
+			Model:      "fake",
+			OutputKeys: []string{"answer"},
+		})
+		require.ErrorIs(t, err, ErrInvalidOutputKeys)
+	})
+
+	t.Run("Unknown model", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		_, err := r.LoadChain(&ChainSpec{
+			Type:  ChainTypeLLM,
+			Model: "unknown",
+			Prompt: &PromptSpec{
+				Type:     PromptTypeTemplate,
+				Template: "{{.input}}",
+			},
+		})
+		require.ErrorIs(t, err, ErrUnknownModel)
+	})
+
+	t.Run("Chat prompt with placeholder", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		p, err := r.LoadPrompt(&PromptSpec{
+			Type: PromptTypeChat,
+			Messages: []MessageSpec{
+				{Role: MessageRoleSystem, Template: "You are {{.name}}."},
+				{Role: MessageRolePlaceholder, InputKey: "history"},
+				{Role: MessageRoleHuman, Template: "{{.input}}"},
+			},
+		})
+		require.NoError(t, err)
+
+		pv, err := p.FormatPrompt(map[string]any{
+			"name":    "golc",
+			"history": schema.ChatMessages{schema.NewAIChatMessage("Hello")},
+			"input":   "Hi",
+		})
+		require.NoError(t, err)
+
+		messages := pv.Messages()
+		require.Len(t, messages, 3)
+		require.Equal(t, "You are golc.", messages[0].Content())
+		require.Equal(t, "Hello", messages[1].Content())
+		require.Equal(t, "Hi", messages[2].Content())
+	})
+
+	t.Run("Few shot prompt", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		p, err := r.LoadPrompt(&PromptSpec{
+			Type:            PromptTypeFewShot,
+			Template:        "Input: {{.input}}",
+			ExampleTemplate: "Input: {{.in}} Output: {{.out}}",
+			Examples:        []map[string]any{{"in": "a", "out": "b"}},
+		})
+		require.NoError(t, err)
+
+		text, err := p.Format(map[string]any{"input": "c"})
+		require.NoError(t, err)
+		require.Equal(t, "Input: a Output: b\n\nInput: c", text)
+	})
+
+	t.Run("File round trip", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		spec := &ChainSpec{
+			Type:      ChainTypeLLM,
+			Model:     "fake",
+			InputKeys: []string{"input"},
+			Prompt: &PromptSpec{
+				Type:     PromptTypeTemplate,
+				Template: "Echo {{.input}}",
+			},
+		}
+
+		for _, name := range []string{"chain.json", "chain.yaml"} {
+			path := filepath.Join(t.TempDir(), name)
+
+			require.NoError(t, SaveFile(path, spec))
+
+			loaded := &ChainSpec{}
+			require.NoError(t, ReadFile(path, loaded))
+			require.Equal(t, spec, loaded)
+
+			c, err := r.LoadChainFile(path)
+			require.NoError(t, err)
+
+			output, err := golc.SimpleCall(context.Background(), c, "foo")
+			require.NoError(t, err)
+			require.Equal(t, "Echo foo", output)
+		}
+
+		_, err := FormatFromPath("chain.txt")
+		require.ErrorIs(t, err, ErrUnknownFormat)
+	})
+
+	t.Run("Agent", func(t *testing.T) {
+		r := newTestRegistry(t)
+
+		_, err := r.LoadAgent(&AgentSpec{
+			Type:  AgentTypeReactDescription,
+			Model: "fake",
+			Tools: []string{"unknown"},
+		})
+		require.ErrorIs(t, err, ErrUnknownTool)
+
+		_, err = r.LoadAgent(&AgentSpec{
+			Type:  AgentTypeOpenAIFunctions,
+			Model: "fake",
+		})
+		require.ErrorIs(t, err, ErrChatModelRequired)
+
+		_, err = r.LoadAgent(&AgentSpec{
+			Type:  AgentTypeToolCalling,
+			Model: "fake",
+		})
+		require.ErrorIs(t, err, ErrChatModelRequired)
+
+		_, err = r.LoadAgent(&AgentSpec{
+			Type:  AgentTypeSelfAskWithSearch,
+			Model: "fake",
+		})
+		require.ErrorIs(t, err, ErrInvalidToolCount)
+
+		executor, err := r.LoadAgent(&AgentSpec{
+			Type:  AgentTypeReactDescription,
+			Model: "fake",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, executor)
+	})
+
+	t.Run("Unknown fields", func(t *testing.T) {
+		spec := &AgentSpec{}
+		require.Error(t, Unmarshal([]byte("type: react_description\nmodel: fake\nmaxIteration: 3\n"), FormatYAML, spec))
+		require.Error(t, Unmarshal([]byte(`{"type": "react_description", "model": "fake", "maxIteration": 3}`), FormatJSON, spec))
+
+		require.NoError(t, Unmarshal([]byte("type: react_description\nmodel: fake\nmaxIterations: 3\n"), FormatYAML, spec))
+		require.Equal(t, 3, spec.MaxIterations)
+	})
+}
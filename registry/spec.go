@@ -0,0 +1,138 @@
+package registry
+
+// Prompt types supported by PromptSpec.
+const (
+	PromptTypeTemplate = "template"
+	PromptTypeChat     = "chat"
+	PromptTypeFewShot  = "few_shot"
+)
+
+// Message roles supported by MessageSpec.
+const (
+	MessageRoleSystem      = "system"
+	MessageRoleHuman       = "human"
+	MessageRoleAI          = "ai"
+	MessageRolePlaceholder = "placeholder"
+)
+
+// Chain types supported by ChainSpec.
+const (
+	ChainTypeLLM            = "llm"
+	ChainTypeConversation   = "conversation"
+	ChainTypeSequential     = "sequential"
+	ChainTypeRetrievalQA    = "retrieval_qa"
+	ChainTypeStuffDocuments = "stuff_documents"
+)
+
+// Agent types supported by AgentSpec.
+const (
+	AgentTypeReactDescription               = "react_description"
+	AgentTypeConversationalReactDescription = "conversational_react_description"
+	AgentTypeOpenAIFunctions                = "openai_functions"
+	AgentTypeToolCalling                    = "tool_calling"
+	AgentTypeStructuredChat                 = "structured_chat"
+	AgentTypeSelfAskWithSearch              = "self_ask_with_search"
+	AgentTypeReWOO                          = "rewoo"
+)
+
+// PromptSpec is the declarative specification of a prompt template.
+type PromptSpec struct {
+	// Type is the type of the prompt: template, chat or few_shot.
+	Type string `json:"type" yaml:"type"`
+
+	// Template is the template text of template and few_shot prompts.
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+
+	// PartialValues are the partial values of template and few_shot prompts.
+	PartialValues map[string]any `json:"partialValues,omitempty" yaml:"partialValues,omitempty"`
+
+	// Messages are the message templates of chat prompts.
+	Messages []MessageSpec `json:"messages,omitempty" yaml:"messages,omitempty"`
+
+	// Examples are the examples of few_shot prompts.
+	Examples []map[string]any `json:"examples,omitempty" yaml:"examples,omitempty"`
+
+	// ExampleTemplate is the template used to format each example of few_shot prompts.
+	ExampleTemplate string `json:"exampleTemplate,omitempty" yaml:"exampleTemplate,omitempty"`
+
+	// Prefix is added before the examples of few_shot prompts.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+
+	// Separator separates the examples of few_shot prompts.
+	Separator string `json:"separator,omitempty" yaml:"separator,omitempty"`
+}
+
+// MessageSpec is the declarative specification of a chat message template.
+type MessageSpec struct {
+	// Role is the role of the message: system, human, ai or placeholder.
+	Role string `json:"role" yaml:"role"`
+
+	// Template is the template text of the message.
+	Template string `json:"template,omitempty" yaml:"template,omitempty"`
+
+	// InputKey is the key of the messages inserted by a placeholder.
+	InputKey string `json:"inputKey,omitempty" yaml:"inputKey,omitempty"`
+}
+
+// ChainSpec is the declarative specification of a chain.
+type ChainSpec struct {
+	// Type is the type of the chain: llm, conversation, sequential, retrieval_qa or stuff_documents.
+	Type string `json:"type" yaml:"type"`
+
+	// Model is the name of a registered model.
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+
+	// Retriever is the name of a registered retriever.
+	Retriever string `json:"retriever,omitempty" yaml:"retriever,omitempty"`
+
+	// Prompt is the prompt of the chain.
+	Prompt *PromptSpec `json:"prompt,omitempty" yaml:"prompt,omitempty"`
+
+	// InputKey is the key of the main input of the chain.
+	InputKey string `json:"inputKey,omitempty" yaml:"inputKey,omitempty"`
+
+	// OutputKey is the key of the main output of the chain.
+	OutputKey string `json:"outputKey,omitempty" yaml:"outputKey,omitempty"`
+
+	// InputKeys are the input keys the chain is expected to accept. For sequential chains,
+	// they are the input keys of the whole sequence.
+	InputKeys []string `json:"inputKeys,omitempty" yaml:"inputKeys,omitempty"`
+
+	// OutputKeys are the output keys the chain is expected to return. For sequential chains,
+	// they select the returned outputs.
+	OutputKeys []string `json:"outputKeys,omitempty" yaml:"outputKeys,omitempty"`
+
+	// Chains are the sub chains of sequential chains.
+	Chains []ChainSpec `json:"chains,omitempty" yaml:"chains,omitempty"`
+
+	// LLMChain is the llm chain of stuff_documents chains.
+	LLMChain *ChainSpec `json:"llmChain,omitempty" yaml:"llmChain,omitempty"`
+
+	// DocumentVariableName is the prompt variable the documents are stuffed into.
+	DocumentVariableName string `json:"documentVariableName,omitempty" yaml:"documentVariableName,omitempty"`
+
+	// ReturnAll determines whether sequential chains return all intermediate outputs.
+	ReturnAll bool `json:"returnAll,omitempty" yaml:"returnAll,omitempty"`
+
+	// ReturnSourceDocuments determines whether retrieval_qa chains return the source documents.
+	ReturnSourceDocuments bool `json:"returnSourceDocuments,omitempty" yaml:"returnSourceDocuments,omitempty"`
+}
+
+// AgentSpec is the declarative specification of an agent executor.
+type AgentSpec struct {
+	// Type is the type of the agent: react_description, conversational_react_description,
+	// openai_functions, tool_calling or structured_chat.
+	Type string `json:"type" yaml:"type"`
+
+	// Model is the name of a registered model.
+	Model string `json:"model" yaml:"model"`
+
+	// Tools are the names of registered tools.
+	Tools []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// OutputKey is the key of the output of the agent.
+	OutputKey string `json:"outputKey,omitempty" yaml:"outputKey,omitempty"`
+
+	// MaxIterations is the maximum number of iterations of the agent.
+	MaxIterations int `json:"maxIterations,omitempty" yaml:"maxIterations,omitempty"`
+}
@@ -2,36 +2,69 @@ package retriever
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/metric"
 	"github.com/hupe1980/golc/schema"
 )
 
 // Compile time check to ensure VectorStore satisfies the Retriever interface.
 var _ schema.Retriever = (*VectorStore)(nil)
 
+// VectorStoreSearchType is the type of search performed by the VectorStore retriever.
 type VectorStoreSearchType string
 
 const (
+	// VectorStoreSearchTypeSimilarity returns the documents most similar to the query.
 	VectorStoreSearchTypeSimilarity VectorStoreSearchType = "similarity"
+	// VectorStoreSearchTypeMMR returns documents selected by maximal marginal relevance, which
+	// balances the similarity to the query with the diversity among the selected documents.
+	VectorStoreSearchTypeMMR VectorStoreSearchType = "mmr"
+	// VectorStoreSearchTypeSimilarityScoreThreshold returns the most similar documents with a score of at least the score threshold.
+	VectorStoreSearchTypeSimilarityScoreThreshold VectorStoreSearchType = "similarity_score_threshold"
 )
 
+// VectorStoreOptions contains options for configuring the VectorStore retriever.
 type VectorStoreOptions struct {
 	*schema.CallbackOptions
+	// SearchType is the type of search. Searches other than a plain similarity search require
+	// a vector store that implements schema.VectorStoreSearcher.
 	SearchType VectorStoreSearchType
+	// K is the number of documents to return. The TopK of the vector store is used if it is zero,
+	// except for MMR, which returns 4 documents by default.
+	K int
+	// FetchK is the number of documents fetched from the vector store, from which MMR selects K documents.
+	FetchK int
+	// Embedder embeds the query for MMR, which compares the query to the documents by the cosine
+	// similarity of their vectors. It is required for MMR and should be the embedder of the vector store.
+	Embedder schema.Embedder
+	// LambdaMult is the diversity of the documents selected by MMR, between 0 for maximum diversity
+	// and 1 for minimum diversity.
+	LambdaMult float32
+	// ScoreThreshold is the minimum score of the documents returned by a similarity score threshold search.
+	ScoreThreshold float32
+	// Filter restricts the search to documents whose metadata matches the filter.
+	Filter *schema.Filter
 }
 
+// VectorStore is a retriever that searches documents in a vector store.
 type VectorStore struct {
 	v    schema.VectorStore
 	opts VectorStoreOptions
 }
 
+// NewVectorStore creates a new VectorStore retriever. The options are validated when documents are
+// retrieved, GetRelevantDocuments returns an error if they are invalid for the search type or the
+// vector store does not support the search type.
 func NewVectorStore(vectorStore schema.VectorStore, optFns ...func(o *VectorStoreOptions)) *VectorStore {
 	opts := VectorStoreOptions{
 		SearchType: VectorStoreSearchTypeSimilarity,
 		CallbackOptions: &schema.CallbackOptions{
 			Verbose: golc.Verbose,
 		},
+		FetchK:     20,
+		LambdaMult: 0.5,
 	}
 
 	for _, fn := range optFns {
@@ -46,7 +79,195 @@ func NewVectorStore(vectorStore schema.VectorStore, optFns ...func(o *VectorStor
 
 // GetRelevantDocuments returns documents using the vector store.
 func (r *VectorStore) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
-	return r.v.SimilaritySearch(ctx, query)
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+
+	switch r.opts.SearchType {
+	case VectorStoreSearchTypeSimilarity:
+		if r.opts.K == 0 && r.opts.Filter == nil {
+			return r.v.SimilaritySearch(ctx, query)
+		}
+
+		return r.search(ctx, query, func(o *schema.VectorStoreSearchOptions) {
+			o.K = r.opts.K
+			o.Filter = r.opts.Filter
+		})
+	case VectorStoreSearchTypeSimilarityScoreThreshold:
+		return r.search(ctx, query, func(o *schema.VectorStoreSearchOptions) {
+			o.K = r.opts.K
+			o.ScoreThreshold = r.opts.ScoreThreshold
+			o.Filter = r.opts.Filter
+		})
+	case VectorStoreSearchTypeMMR:
+		return r.maxMarginalRelevanceSearch(ctx, query)
+	default:
+		return nil, fmt.Errorf("unsupported search type: %s", r.opts.SearchType)
+	}
+}
+
+// validate returns an error if the options are invalid for the search type or the vector store
+// does not support the search type.
+func (r *VectorStore) validate() error {
+	if r.opts.K < 0 {
+		return fmt.Errorf("invalid k %d: must not be negative", r.opts.K)
+	}
+
+	switch r.opts.SearchType {
+	case VectorStoreSearchTypeSimilarity:
+		if r.opts.K == 0 && r.opts.Filter == nil {
+			break
+		}
+
+		if _, ok := r.v.(schema.VectorStoreSearcher); !ok {
+			return fmt.Errorf("k and filter require a vector store that implements schema.VectorStoreSearcher")
+		}
+	case VectorStoreSearchTypeSimilarityScoreThreshold:
+		if r.opts.ScoreThreshold == 0 {
+			return fmt.Errorf("search type %s requires a score threshold", r.opts.SearchType)
+		}
+
+		if _, ok := r.v.(schema.VectorStoreSearcher); !ok {
+			return fmt.Errorf("search type %s requires a vector store that implements schema.VectorStoreSearcher", r.opts.SearchType)
+		}
+	case VectorStoreSearchTypeMMR:
+		if r.opts.FetchK < 0 {
+			return fmt.Errorf("invalid fetch k %d: must not be negative", r.opts.FetchK)
+		}
+
+		if r.opts.LambdaMult < 0 || r.opts.LambdaMult > 1 {
+			return fmt.Errorf("invalid lambda mult %v: must be between 0 and 1", r.opts.LambdaMult)
+		}
+
+		if r.opts.Embedder == nil {
+			return fmt.Errorf("search type %s requires an embedder", r.opts.SearchType)
+		}
+
+		if _, ok := r.v.(schema.VectorStoreSearcher); !ok {
+			return fmt.Errorf("search type %s requires a vector store that implements schema.VectorStoreSearcher", r.opts.SearchType)
+		}
+	default:
+		return fmt.Errorf("unsupported search type: %s", r.opts.SearchType)
+	}
+
+	return nil
+}
+
+// search performs a similarity search with scores and returns the documents.
+func (r *VectorStore) search(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.Document, error) {
+	scoredDocs, err := r.v.(schema.VectorStoreSearcher).SimilaritySearchWithScore(ctx, query, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, doc := range scoredDocs {
+		docs[i] = doc.Document
+	}
+
+	return docs, nil
+}
+
+// maxMarginalRelevanceSearch fetches FetchK documents with their vectors and selects K of them by maximal
+// marginal relevance. The relevance of a document is the cosine similarity of its vector to the query
+// embedding and its redundancy is the highest cosine similarity to the documents selected before.
+func (r *VectorStore) maxMarginalRelevanceSearch(ctx context.Context, query string) ([]schema.Document, error) {
+	k := r.opts.K
+	if k == 0 {
+		k = 4
+	}
+
+	queryVector, err := r.opts.Embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := r.v.(schema.VectorStoreSearcher).SimilaritySearchByVector(ctx, queryVector, func(o *schema.VectorStoreSearchOptions) {
+		o.K = max(r.opts.FetchK, k)
+		o.Filter = r.opts.Filter
+		o.IncludeVectors = true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if candidate.Vector == nil {
+			return nil, fmt.Errorf("search type %s requires a vector store that returns vectors", r.opts.SearchType)
+		}
+	}
+
+	selected, err := maximalMarginalRelevance(queryVector, candidates, k, r.opts.LambdaMult)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]schema.Document, len(selected))
+	for i, index := range selected {
+		docs[i] = candidates[index].Document
+	}
+
+	return docs, nil
+}
+
+// maximalMarginalRelevance returns the indexes of up to k candidates in the order of their selection. Each step
+// selects the candidate with the highest lambdaMult * relevance - (1 - lambdaMult) * redundancy, where the relevance
+// is the cosine similarity to the query vector and the redundancy is the highest cosine similarity to the selected
+// candidates.
+func maximalMarginalRelevance(queryVector []float32, candidates []schema.ScoredDocument, k int, lambdaMult float32) ([]int, error) {
+	k = min(k, len(candidates))
+
+	selected := make([]int, 0, k)
+	relevance := make([]float32, len(candidates))
+	redundancy := make([]float32, len(candidates))
+	isSelected := make([]bool, len(candidates))
+
+	for i, candidate := range candidates {
+		similarity, err := metric.CosineSimilarity(queryVector, candidate.Vector)
+		if err != nil {
+			return nil, err
+		}
+
+		relevance[i] = similarity
+	}
+
+	for len(selected) < k {
+		best := -1
+
+		var bestScore float32
+
+		for i := range candidates {
+			if isSelected[i] {
+				continue
+			}
+
+			score := lambdaMult*relevance[i] - (1-lambdaMult)*redundancy[i]
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		selected = append(selected, best)
+		isSelected[best] = true
+
+		// Update the redundancy of the remaining candidates with their similarity to the selected candidate.
+		for i, candidate := range candidates {
+			if isSelected[i] {
+				continue
+			}
+
+			similarity, err := metric.CosineSimilarity(candidate.Vector, candidates[best].Vector)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(selected) == 1 || similarity > redundancy[i] {
+				redundancy[i] = similarity
+			}
+		}
+	}
+
+	return selected, nil
 }
 
 // Verbose returns the verbosity setting of the retriever.
@@ -0,0 +1,218 @@
+package retriever
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorStore(t *testing.T) {
+	// The query is most similar to "apples", although the vector store scores "apple" higher.
+	embedder := &embedderMock{vector: []float32{1, 0.1}}
+
+	vectorStore := &vectorStoreSearcherMock{
+		docs: []schema.ScoredDocument{
+			{Document: schema.Document{PageContent: "apple"}, Score: 0.95, Vector: []float32{1, 0}},
+			{Document: schema.Document{PageContent: "apples"}, Score: 0.94, Vector: []float32{1, 0.01}},
+			{Document: schema.Document{PageContent: "banana"}, Score: 0.8, Vector: []float32{0, 1}},
+			{Document: schema.Document{PageContent: "cherry"}, Score: 0.5, Vector: []float32{0.7, 0.7}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		optFn    func(o *VectorStoreOptions)
+		expected []string
+	}{
+		{
+			name:     "Similarity",
+			optFn:    func(o *VectorStoreOptions) {},
+			expected: []string{"apple", "apples", "banana", "cherry"},
+		},
+		{
+			name: "Similarity with k",
+			optFn: func(o *VectorStoreOptions) {
+				o.K = 2
+			},
+			expected: []string{"apple", "apples"},
+		},
+		{
+			name: "MMR",
+			optFn: func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeMMR
+				o.Embedder = embedder
+				o.K = 2
+			},
+			expected: []string{"apples", "banana"},
+		},
+		{
+			name: "MMR without diversity",
+			optFn: func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeMMR
+				o.Embedder = embedder
+				o.K = 3
+				o.LambdaMult = 1
+			},
+			expected: []string{"apples", "apple", "cherry"},
+		},
+		{
+			name: "MMR with maximum diversity",
+			optFn: func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeMMR
+				o.Embedder = embedder
+				o.LambdaMult = 0
+			},
+			expected: []string{"apple", "banana", "cherry", "apples"},
+		},
+		{
+			name: "Similarity score threshold",
+			optFn: func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeSimilarityScoreThreshold
+				o.ScoreThreshold = 0.9
+			},
+			expected: []string{"apple", "apples"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewVectorStore(vectorStore, tc.optFn)
+
+			docs, err := r.GetRelevantDocuments(context.Background(), "apple")
+			require.NoError(t, err)
+
+			contents := make([]string, len(docs))
+			for i, doc := range docs {
+				contents[i] = doc.PageContent
+			}
+
+			assert.Equal(t, tc.expected, contents)
+		})
+	}
+
+	t.Run("MMR fetches FetchK documents with vectors", func(t *testing.T) {
+		r := NewVectorStore(vectorStore, func(o *VectorStoreOptions) {
+			o.SearchType = VectorStoreSearchTypeMMR
+			o.Embedder = embedder
+			o.FetchK = 3
+		})
+
+		_, err := r.GetRelevantDocuments(context.Background(), "apple")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{1, 0.1}, vectorStore.vector)
+		assert.Equal(t, 4, vectorStore.opts.K)
+		assert.True(t, vectorStore.opts.IncludeVectors)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		for _, optFn := range []func(o *VectorStoreOptions){
+			func(o *VectorStoreOptions) { o.SearchType = "unknown" },
+			func(o *VectorStoreOptions) { o.K = -1 },
+			func(o *VectorStoreOptions) { o.SearchType = VectorStoreSearchTypeSimilarityScoreThreshold },
+			func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeMMR
+				o.Embedder = embedder
+				o.LambdaMult = 2
+			},
+			func(o *VectorStoreOptions) {
+				o.SearchType = VectorStoreSearchTypeMMR
+				o.Embedder = embedder
+				o.FetchK = -1
+			},
+			func(o *VectorStoreOptions) { o.SearchType = VectorStoreSearchTypeMMR },
+		} {
+			_, err := NewVectorStore(vectorStore, optFn).GetRelevantDocuments(context.Background(), "apple")
+			assert.Error(t, err)
+		}
+
+		// The plain vector store does not return scores and vectors.
+		_, err := NewVectorStore(&vectorStoreMock{}, func(o *VectorStoreOptions) {
+			o.SearchType = VectorStoreSearchTypeMMR
+			o.Embedder = embedder
+		}).GetRelevantDocuments(context.Background(), "apple")
+		assert.ErrorContains(t, err, "schema.VectorStoreSearcher")
+	})
+}
+
+// vectorStoreMock implements the schema.VectorStore interface for testing purposes.
+type vectorStoreMock struct{}
+
+func (m *vectorStoreMock) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	return nil
+}
+
+func (m *vectorStoreMock) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	return nil, nil
+}
+
+// vectorStoreSearcherMock implements the schema.VectorStoreSearcher interface for testing purposes.
+// It returns its documents, which are sorted by score, according to the search options.
+type vectorStoreSearcherMock struct {
+	vectorStoreMock
+	docs   []schema.ScoredDocument
+	opts   schema.VectorStoreSearchOptions
+	vector []float32
+}
+
+func (m *vectorStoreSearcherMock) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs := make([]schema.Document, len(m.docs))
+	for i, doc := range m.docs {
+		docs[i] = doc.Document
+	}
+
+	return docs, nil
+}
+
+func (m *vectorStoreSearcherMock) SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	return m.SimilaritySearchByVector(ctx, nil, optFns...)
+}
+
+func (m *vectorStoreSearcherMock) SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	m.vector = vector
+	m.opts = schema.VectorStoreSearchOptions{K: len(m.docs)}
+
+	for _, fn := range optFns {
+		fn(&m.opts)
+	}
+
+	if m.opts.K == 0 {
+		m.opts.K = len(m.docs)
+	}
+
+	docs := make([]schema.ScoredDocument, 0, m.opts.K)
+
+	for _, doc := range m.docs {
+		if len(docs) == m.opts.K || doc.Score < m.opts.ScoreThreshold {
+			break
+		}
+
+		if !m.opts.IncludeVectors {
+			doc.Vector = nil
+		}
+
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// embedderMock implements the schema.Embedder interface for testing purposes. It embeds every text as its vector.
+type embedderMock struct {
+	vector []float32
+}
+
+func (m *embedderMock) BatchEmbedText(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i := range texts {
+		vectors[i] = m.vector
+	}
+
+	return vectors, nil
+}
+
+func (m *embedderMock) EmbedText(ctx context.Context, text string) ([]float32, error) {
+	return m.vector, nil
+}
@@ -0,0 +1,104 @@
+package runnable
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/model"
+	"github.com/hupe1980/golc/retriever"
+	"github.com/hupe1980/golc/schema"
+)
+
+// ChainOptions contains options for the chain runnable.
+type ChainOptions struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+	Stop        []string
+}
+
+// FromChain returns a Runnable that executes the chain with golc.Call.
+func FromChain(chain schema.Chain, optFns ...func(o *ChainOptions)) Runnable[schema.ChainValues, schema.ChainValues] {
+	opts := ChainOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return Lambda[schema.ChainValues, schema.ChainValues](func(ctx context.Context, input schema.ChainValues) (schema.ChainValues, error) {
+		return golc.Call(ctx, chain, input.Clone(), func(o *golc.CallOptions) {
+			o.Callbacks = opts.Callbacks
+			o.ParentRunID = opts.ParentRunID
+			o.Stop = opts.Stop
+		})
+	})
+}
+
+// ModelOptions contains options for the model runnable.
+type ModelOptions struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+	Stop        []string
+}
+
+// FromModel returns a Runnable that generates a response for the prompt value and
+// returns the first generation of the model result.
+func FromModel(m schema.Model, optFns ...func(o *ModelOptions)) Runnable[schema.PromptValue, schema.Generation] {
+	opts := ModelOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return Lambda[schema.PromptValue, schema.Generation](func(ctx context.Context, input schema.PromptValue) (schema.Generation, error) {
+		result, err := model.GeneratePrompt(ctx, m, input, func(o *model.Options) {
+			o.Callbacks = opts.Callbacks
+			o.ParentRunID = opts.ParentRunID
+			o.Stop = opts.Stop
+		})
+		if err != nil {
+			return schema.Generation{}, err
+		}
+
+		if len(result.Generations) == 0 {
+			return schema.Generation{}, ErrNoGeneration
+		}
+
+		return result.Generations[0], nil
+	})
+}
+
+// RetrieverOptions contains options for the retriever runnable.
+type RetrieverOptions struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+}
+
+// FromRetriever returns a Runnable that retrieves the relevant documents for a query.
+func FromRetriever(r schema.Retriever, optFns ...func(o *RetrieverOptions)) Runnable[string, []schema.Document] {
+	opts := RetrieverOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return Lambda[string, []schema.Document](func(ctx context.Context, input string) ([]schema.Document, error) {
+		return retriever.Run(ctx, r, input, func(o *retriever.Options) {
+			o.Callbacks = opts.Callbacks
+			o.ParentRunID = opts.ParentRunID
+		})
+	})
+}
+
+// FromPromptTemplate returns a Runnable that formats the prompt template with the input values.
+func FromPromptTemplate(prompt schema.PromptTemplate) Runnable[schema.ChainValues, schema.PromptValue] {
+	return Lambda[schema.ChainValues, schema.PromptValue](func(ctx context.Context, input schema.ChainValues) (schema.PromptValue, error) {
+		return prompt.FormatPrompt(input)
+	})
+}
+
+// FromOutputParser returns a Runnable that parses the text of a generation.
+func FromOutputParser[T any](parser schema.OutputParser[T]) Runnable[schema.Generation, T] {
+	return Lambda[schema.Generation, T](func(ctx context.Context, input schema.Generation) (T, error) {
+		return parser.Parse(input.Text)
+	})
+}
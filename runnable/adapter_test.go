@@ -0,0 +1,75 @@
+package runnable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/outputparser"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdapter(t *testing.T) {
+	t.Run("Prompt, Model and OutputParser", func(t *testing.T) {
+		fake := llm.NewFake(func(ctx context.Context, prompt string) (*schema.ModelResult, error) {
+			require.Equal(t, "List three colors", prompt)
+
+			return &schema.ModelResult{
+				Generations: []schema.Generation{{Text: "red, green, blue"}},
+			}, nil
+		})
+
+		parser := outputparser.NewCommaSeparatedList()
+
+		pipeline := Pipe3(
+			FromPromptTemplate(prompt.NewTemplate("List three {{.topic}}")),
+			FromModel(fake),
+			FromOutputParser[any](&parser),
+		)
+
+		output, err := pipeline.Invoke(context.Background(), schema.ChainValues{"topic": "colors"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"red", "green", "blue"}, output)
+	})
+
+	t.Run("Chain", func(t *testing.T) {
+		llmChain, err := chain.NewLLM(llm.NewSimpleFake("Paris"), prompt.NewTemplate("{{.input}}"))
+		require.NoError(t, err)
+
+		output, err := FromChain(llmChain).Invoke(context.Background(), schema.ChainValues{"input": "Capital of France?"})
+		require.NoError(t, err)
+		require.Equal(t, "Paris", output["text"])
+	})
+
+	t.Run("Retriever", func(t *testing.T) {
+		retriever := &mockRetriever{
+			docs: []schema.Document{{PageContent: "foo"}},
+		}
+
+		docs, err := FromRetriever(retriever).Invoke(context.Background(), "query")
+		require.NoError(t, err)
+		require.Equal(t, []schema.Document{{PageContent: "foo"}}, docs)
+	})
+}
+
+// Compile time check to ensure mockRetriever satisfies the Retriever interface.
+var _ schema.Retriever = (*mockRetriever)(nil)
+
+type mockRetriever struct {
+	docs []schema.Document
+}
+
+func (m *mockRetriever) GetRelevantDocuments(ctx context.Context, query string) ([]schema.Document, error) {
+	return m.docs, nil
+}
+
+func (m *mockRetriever) Verbose() bool {
+	return false
+}
+
+func (m *mockRetriever) Callbacks() []schema.Callback {
+	return nil
+}
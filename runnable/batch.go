@@ -0,0 +1,70 @@
+package runnable
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// BatchOptions contains options for batch invocations.
+type BatchOptions struct {
+	// MaxConcurrency is the maximum number of concurrent invocations.
+	// A value less than one means no limit.
+	MaxConcurrency int
+}
+
+// Batch invokes the runnable concurrently for all inputs and returns the outputs
+// in the same order as the inputs. The first error cancels the remaining invocations.
+func Batch[I, O any](ctx context.Context, r Runnable[I, O], inputs []I, optFns ...func(o *BatchOptions)) ([]O, error) {
+	opts := BatchOptions{
+		MaxConcurrency: 5,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	errs, errctx := errgroup.WithContext(ctx)
+
+	errs.SetLimit(concurrencyLimit(opts.MaxConcurrency))
+
+	outputs := make([]O, len(inputs))
+
+	for i, input := range inputs {
+		i, input := i, input
+
+		errs.Go(func() error {
+			output, err := r.Invoke(errctx, input)
+			if err != nil {
+				return err
+			}
+
+			outputs[i] = output
+
+			return nil
+		})
+	}
+
+	if err := errs.Wait(); err != nil {
+		return nil, err
+	}
+
+	return outputs, nil
+}
+
+// concurrencyLimit converts the max concurrency to the limit of an errgroup, which blocks on
+// a limit of zero and has no limit if it is negative.
+func concurrencyLimit(maxConcurrency int) int {
+	if maxConcurrency < 1 {
+		return -1
+	}
+
+	return maxConcurrency
+}
+
+// Map returns a Runnable that applies the runnable to each element of the input slice.
+func Map[I, O any](r Runnable[I, O], optFns ...func(o *BatchOptions)) Runnable[[]I, []O] {
+	return Lambda[[]I, []O](func(ctx context.Context, inputs []I) ([]O, error) {
+		return Batch(ctx, r, inputs, optFns...)
+	})
+}
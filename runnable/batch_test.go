@@ -0,0 +1,46 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	square := Lambda[int, int](func(ctx context.Context, input int) (int, error) {
+		if input < 0 {
+			return 0, errors.New("negative input")
+		}
+
+		return input * input, nil
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		outputs, err := Batch[int, int](context.Background(), square, []int{1, 2, 3, 4}, func(o *BatchOptions) {
+			o.MaxConcurrency = 2
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 4, 9, 16}, outputs)
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		outputs, err := Batch[int, int](context.Background(), square, []int{1, 2}, func(o *BatchOptions) {
+			o.MaxConcurrency = 0
+		})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 4}, outputs)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		_, err := Batch[int, int](context.Background(), square, []int{1, -2, 3})
+		require.EqualError(t, err, "negative input")
+	})
+
+	t.Run("Map", func(t *testing.T) {
+		outputs, err := Map[int, int](square).Invoke(context.Background(), []int{3, 5})
+		require.NoError(t, err)
+		require.Equal(t, []int{9, 25}, outputs)
+	})
+}
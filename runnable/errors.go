@@ -0,0 +1,7 @@
+package runnable
+
+import "errors"
+
+var (
+	ErrNoGeneration = errors.New("model returned no generation")
+)
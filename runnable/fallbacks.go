@@ -0,0 +1,54 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+)
+
+// FallbacksOptions contains options for the fallbacks runnable.
+type FallbacksOptions struct {
+	// FallbackIf decides whether a fallback is invoked for an error. All errors trigger a fallback if nil.
+	FallbackIf func(err error) bool
+}
+
+// WithFallbacks returns a Runnable that invokes the fallbacks in order if the runnable fails.
+// The output of the first successful invocation is returned. If all invocations fail,
+// the joined errors are returned.
+func WithFallbacks[I, O any](r Runnable[I, O], fallbacks []Runnable[I, O], optFns ...func(o *FallbacksOptions)) Runnable[I, O] {
+	opts := FallbacksOptions{}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return Lambda[I, O](func(ctx context.Context, input I) (O, error) {
+		output, err := r.Invoke(ctx, input)
+		if err == nil {
+			return output, nil
+		}
+
+		errs := []error{err}
+
+		for _, fallback := range fallbacks {
+			if opts.FallbackIf != nil && !opts.FallbackIf(err) {
+				break
+			}
+
+			if ctx.Err() != nil {
+				errs = append(errs, ctx.Err())
+				break
+			}
+
+			output, err = fallback.Invoke(ctx, input)
+			if err == nil {
+				return output, nil
+			}
+
+			errs = append(errs, err)
+		}
+
+		var empty O
+
+		return empty, errors.Join(errs...)
+	})
+}
@@ -0,0 +1,55 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithFallbacks(t *testing.T) {
+	errPrimary := errors.New("primary")
+	errFallback := errors.New("fallback")
+
+	fail := func(err error) Runnable[string, string] {
+		return Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			return "", err
+		})
+	}
+
+	t.Run("Primary succeeds", func(t *testing.T) {
+		r := WithFallbacks(Passthrough[string](), []Runnable[string, string]{fail(errFallback)})
+
+		output, err := r.Invoke(context.Background(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, "foo", output)
+	})
+
+	t.Run("Fallback succeeds", func(t *testing.T) {
+		r := WithFallbacks(fail(errPrimary), []Runnable[string, string]{fail(errFallback), Passthrough[string]()})
+
+		output, err := r.Invoke(context.Background(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, "foo", output)
+	})
+
+	t.Run("All fail", func(t *testing.T) {
+		r := WithFallbacks(fail(errPrimary), []Runnable[string, string]{fail(errFallback)})
+
+		_, err := r.Invoke(context.Background(), "foo")
+		require.ErrorIs(t, err, errPrimary)
+		require.ErrorIs(t, err, errFallback)
+	})
+
+	t.Run("FallbackIf", func(t *testing.T) {
+		r := WithFallbacks(fail(errPrimary), []Runnable[string, string]{Passthrough[string]()}, func(o *FallbacksOptions) {
+			o.FallbackIf = func(err error) bool {
+				return !errors.Is(err, errPrimary)
+			}
+		})
+
+		_, err := r.Invoke(context.Background(), "foo")
+		require.ErrorIs(t, err, errPrimary)
+	})
+}
@@ -0,0 +1,80 @@
+package runnable
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hupe1980/golc/schema"
+	"golang.org/x/sync/errgroup"
+)
+
+// ParallelOptions contains options for the Parallel runnable.
+type ParallelOptions struct {
+	// MaxConcurrency is the maximum number of runnables invoked at the same time.
+	// A value less than one means no limit.
+	MaxConcurrency int
+}
+
+// Parallel returns a Runnable that invokes all runnables concurrently with the same input
+// and collects their outputs by name. The first error cancels the remaining invocations.
+func Parallel[I, O any](runnables map[string]Runnable[I, O], optFns ...func(o *ParallelOptions)) Runnable[I, map[string]O] {
+	opts := ParallelOptions{
+		MaxConcurrency: -1,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return Lambda[I, map[string]O](func(ctx context.Context, input I) (map[string]O, error) {
+		errs, errctx := errgroup.WithContext(ctx)
+
+		errs.SetLimit(concurrencyLimit(opts.MaxConcurrency))
+
+		mu := sync.Mutex{}
+		outputs := make(map[string]O, len(runnables))
+
+		for name, r := range runnables {
+			name, r := name, r
+
+			errs.Go(func() error {
+				output, err := r.Invoke(errctx, input)
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				outputs[name] = output
+				mu.Unlock()
+
+				return nil
+			})
+		}
+
+		if err := errs.Wait(); err != nil {
+			return nil, err
+		}
+
+		return outputs, nil
+	})
+}
+
+// Assign returns a Runnable that invokes all runnables concurrently with the input values
+// and returns a copy of the input values extended by the outputs of the runnables.
+func Assign(runnables map[string]Runnable[schema.ChainValues, any], optFns ...func(o *ParallelOptions)) Runnable[schema.ChainValues, schema.ChainValues] {
+	parallel := Parallel(runnables, optFns...)
+
+	return Lambda[schema.ChainValues, schema.ChainValues](func(ctx context.Context, input schema.ChainValues) (schema.ChainValues, error) {
+		outputs, err := parallel.Invoke(ctx, input.Clone())
+		if err != nil {
+			return nil, err
+		}
+
+		result := input.Clone()
+		for k, v := range outputs {
+			result[k] = v
+		}
+
+		return result, nil
+	})
+}
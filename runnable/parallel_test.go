@@ -0,0 +1,72 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallel(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		parallel := Parallel(map[string]Runnable[string, string]{
+			"upper": Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+				return strings.ToUpper(input), nil
+			}),
+			"repeat": Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+				return strings.Repeat(input, 2), nil
+			}),
+		})
+
+		outputs, err := parallel.Invoke(context.Background(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"upper": "FOO", "repeat": "foofoo"}, outputs)
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		parallel := Parallel(map[string]Runnable[string, string]{
+			"passthrough": Passthrough[string](),
+		}, func(o *ParallelOptions) {
+			o.MaxConcurrency = 0
+		})
+
+		outputs, err := parallel.Invoke(context.Background(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"passthrough": "foo"}, outputs)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		parallel := Parallel(map[string]Runnable[string, string]{
+			"ok": Passthrough[string](),
+			"fail": Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+				return "", errors.New("fail")
+			}),
+		})
+
+		_, err := parallel.Invoke(context.Background(), "foo")
+		require.EqualError(t, err, "fail")
+	})
+}
+
+func TestAssign(t *testing.T) {
+	assign := Assign(map[string]Runnable[schema.ChainValues, any]{
+		"length": Lambda[schema.ChainValues, any](func(ctx context.Context, input schema.ChainValues) (any, error) {
+			s, err := input.GetString("input")
+			if err != nil {
+				return nil, err
+			}
+
+			return len(s), nil
+		}),
+	})
+
+	inputs := schema.ChainValues{"input": "foo"}
+
+	outputs, err := assign.Invoke(context.Background(), inputs)
+	require.NoError(t, err)
+	require.Equal(t, schema.ChainValues{"input": "foo", "length": 3}, outputs)
+	require.Equal(t, schema.ChainValues{"input": "foo"}, inputs)
+}
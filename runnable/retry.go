@@ -0,0 +1,65 @@
+package runnable
+
+import (
+	"context"
+	"time"
+
+	"github.com/avast/retry-go"
+)
+
+// RetryOptions contains options for the retry runnable.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of attempts, including the first invocation. Zero is treated as one attempt.
+	MaxAttempts uint
+	// Delay is the delay between two attempts.
+	Delay time.Duration
+	// RetryIf decides whether an error is retried. All errors are retried if nil.
+	RetryIf func(err error) bool
+}
+
+// WithRetry returns a Runnable that retries the invocation of the runnable on failure.
+func WithRetry[I, O any](r Runnable[I, O], optFns ...func(o *RetryOptions)) Runnable[I, O] {
+	opts := RetryOptions{
+		MaxAttempts: 3,
+		Delay:       100 * time.Millisecond,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	// Zero attempts would retry until the invocation succeeds.
+	opts.MaxAttempts = max(opts.MaxAttempts, 1)
+
+	return Lambda[I, O](func(ctx context.Context, input I) (O, error) {
+		retryOpts := []retry.Option{
+			retry.Context(ctx),
+			retry.Attempts(opts.MaxAttempts),
+			retry.Delay(opts.Delay),
+			retry.DelayType(retry.FixedDelay),
+			retry.LastErrorOnly(true),
+		}
+
+		if opts.RetryIf != nil {
+			retryOpts = append(retryOpts, retry.RetryIf(opts.RetryIf))
+		}
+
+		var output O
+
+		err := retry.Do(
+			func() error {
+				o, err := r.Invoke(ctx, input)
+				if err != nil {
+					return err
+				}
+
+				output = o
+
+				return nil
+			},
+			retryOpts...,
+		)
+
+		return output, err
+	})
+}
@@ -0,0 +1,84 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errPermanent := errors.New("permanent")
+
+	t.Run("Success after retries", func(t *testing.T) {
+		attempts := 0
+
+		r := WithRetry[string, string](Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			attempts++
+			if attempts < 3 {
+				return "", errTemporary
+			}
+
+			return input, nil
+		}), func(o *RetryOptions) {
+			o.Delay = 0
+		})
+
+		output, err := r.Invoke(context.Background(), "foo")
+		require.NoError(t, err)
+		require.Equal(t, "foo", output)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("Max attempts exceeded", func(t *testing.T) {
+		attempts := 0
+
+		r := WithRetry[string, string](Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			attempts++
+			return "", errTemporary
+		}), func(o *RetryOptions) {
+			o.MaxAttempts = 2
+			o.Delay = 0
+		})
+
+		_, err := r.Invoke(context.Background(), "foo")
+		require.ErrorIs(t, err, errTemporary)
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("Zero max attempts", func(t *testing.T) {
+		attempts := 0
+
+		r := WithRetry[string, string](Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			attempts++
+			return "", errTemporary
+		}), func(o *RetryOptions) {
+			o.MaxAttempts = 0
+			o.Delay = 0
+		})
+
+		_, err := r.Invoke(context.Background(), "foo")
+		require.ErrorIs(t, err, errTemporary)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("RetryIf", func(t *testing.T) {
+		attempts := 0
+
+		r := WithRetry[string, string](Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			attempts++
+			return "", errPermanent
+		}), func(o *RetryOptions) {
+			o.Delay = 0
+			o.RetryIf = func(err error) bool {
+				return errors.Is(err, errTemporary)
+			}
+		})
+
+		_, err := r.Invoke(context.Background(), "foo")
+		require.ErrorIs(t, err, errPermanent)
+		require.Equal(t, 1, attempts)
+	})
+}
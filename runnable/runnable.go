@@ -0,0 +1,54 @@
+// Package runnable provides composable, type-safe building blocks for creating pipelines
+// out of chains, models, retrievers, prompt templates, output parsers and plain functions.
+package runnable
+
+import (
+	"context"
+)
+
+// Runnable is a unit of work that transforms an input of type I into an output of type O.
+type Runnable[I, O any] interface {
+	// Invoke transforms a single input into an output.
+	Invoke(ctx context.Context, input I) (O, error)
+}
+
+// Compile time check to ensure Lambda satisfies the Runnable interface.
+var _ Runnable[any, any] = (Lambda[any, any])(nil)
+
+// Lambda is a function that can be used as a Runnable.
+type Lambda[I, O any] func(ctx context.Context, input I) (O, error)
+
+// Invoke calls the function with the given input.
+func (l Lambda[I, O]) Invoke(ctx context.Context, input I) (O, error) {
+	return l(ctx, input)
+}
+
+// Passthrough returns a Runnable that returns its input unchanged.
+func Passthrough[T any]() Runnable[T, T] {
+	return Lambda[T, T](func(ctx context.Context, input T) (T, error) {
+		return input, nil
+	})
+}
+
+// Pipe returns a Runnable that passes the output of the first runnable as input to the second runnable.
+func Pipe[I, M, O any](first Runnable[I, M], second Runnable[M, O]) Runnable[I, O] {
+	return Lambda[I, O](func(ctx context.Context, input I) (O, error) {
+		intermediate, err := first.Invoke(ctx, input)
+		if err != nil {
+			var empty O
+			return empty, err
+		}
+
+		return second.Invoke(ctx, intermediate)
+	})
+}
+
+// Pipe3 returns a Runnable that pipes the input through three runnables.
+func Pipe3[I, M1, M2, O any](first Runnable[I, M1], second Runnable[M1, M2], third Runnable[M2, O]) Runnable[I, O] {
+	return Pipe(Pipe(first, second), third)
+}
+
+// Pipe4 returns a Runnable that pipes the input through four runnables.
+func Pipe4[I, M1, M2, M3, O any](first Runnable[I, M1], second Runnable[M1, M2], third Runnable[M2, M3], fourth Runnable[M3, O]) Runnable[I, O] {
+	return Pipe(Pipe3(first, second, third), fourth)
+}
@@ -0,0 +1,54 @@
+package runnable
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipe(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		atoi := Lambda[string, int](func(ctx context.Context, input string) (int, error) {
+			return strconv.Atoi(input)
+		})
+
+		double := Lambda[int, int](func(ctx context.Context, input int) (int, error) {
+			return input * 2, nil
+		})
+
+		itoa := Lambda[int, string](func(ctx context.Context, input int) (string, error) {
+			return strconv.Itoa(input), nil
+		})
+
+		output, err := Pipe3[string, int, int, string](atoi, double, itoa).Invoke(context.Background(), "21")
+		require.NoError(t, err)
+		require.Equal(t, "42", output)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		called := false
+
+		fail := Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			return "", errors.New("fail")
+		})
+
+		upper := Lambda[string, string](func(ctx context.Context, input string) (string, error) {
+			called = true
+			return strings.ToUpper(input), nil
+		})
+
+		_, err := Pipe[string, string, string](fail, upper).Invoke(context.Background(), "foo")
+		require.EqualError(t, err, "fail")
+		require.False(t, called)
+	})
+}
+
+func TestPassthrough(t *testing.T) {
+	output, err := Passthrough[string]().Invoke(context.Background(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, "foo", output)
+}
@@ -76,24 +76,53 @@ func (ti *ToolInput) String() string {
 	return ti.sinput
 }
 
+// toolInputJSON is the JSON representation of a ToolInput.
+type toolInputJSON struct {
+	Input      string `json:"input"`
+	Structured bool   `json:"structured"`
+}
+
+// MarshalJSON marshals the ToolInput including whether it is structured.
+func (ti *ToolInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toolInputJSON{
+		Input:      ti.sinput,
+		Structured: ti.structured,
+	})
+}
+
+// UnmarshalJSON unmarshals a ToolInput marshaled by MarshalJSON.
+func (ti *ToolInput) UnmarshalJSON(data []byte) error {
+	v := toolInputJSON{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	ti.sinput = v.Input
+	ti.structured = v.Structured
+
+	return nil
+}
+
 // AgentAction represents an action that the agent will take.
 type AgentAction struct {
 	// Name of the tool to use for the action.
-	Tool string
+	Tool string `json:"tool"`
 	// Input for the tool action.
-	ToolInput *ToolInput
+	ToolInput *ToolInput `json:"toolInput"`
 	// Log message associated with the action.
-	Log string
+	Log string `json:"log"`
 	// Message log associated with the action.
-	MessageLog ChatMessages
+	MessageLog ChatMessages `json:"messageLog,omitempty"`
+	// ID of the tool call the action was created from, if any.
+	ToolCallID string `json:"toolCallId,omitempty"`
 }
 
 // AgentStep represents a step in the agent's action plan.
 type AgentStep struct {
 	// Action to be taken by the agent.
-	Action *AgentAction
+	Action *AgentAction `json:"action"`
 	// Observation made during the step.
-	Observation string
+	Observation string `json:"observation"`
 }
 
 // AgentFinish represents the return value of the agent.
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -53,3 +54,30 @@ func TestToolInput(t *testing.T) {
 		require.Equal(t, "test input", input.String())
 	})
 }
+
+func TestAgentStepJSON(t *testing.T) {
+	step := AgentStep{
+		Action: &AgentAction{
+			Tool:      "Search",
+			ToolInput: NewToolInputFromArguments(`{"query": "golc"}`),
+			Log:       "Invoking Search",
+			MessageLog: ChatMessages{
+				NewAIChatMessage("", func(o *ChatMessageExtension) {
+					o.ToolCalls = []ToolCall{{ID: "call_1", Name: "Search", Arguments: `{"query": "golc"}`}}
+				}),
+				NewFunctionChatMessage("Search", "result"),
+			},
+			ToolCallID: "call_1",
+		},
+		Observation: "result",
+	}
+
+	data, err := json.Marshal(step)
+	require.NoError(t, err)
+
+	decoded := AgentStep{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	require.Equal(t, step, decoded)
+	require.True(t, decoded.Action.ToolInput.Structured())
+}
@@ -0,0 +1,11 @@
+package schema
+
+import "context"
+
+// Cache is an interface for caching model results.
+type Cache interface {
+	// Lookup returns the cached result for the prompt and model key. It returns nil if there is no cached result.
+	Lookup(ctx context.Context, prompt, modelKey string) (*ModelResult, error)
+	// Update stores the result for the prompt and model key.
+	Update(ctx context.Context, prompt, modelKey string, result *ModelResult) error
+}
@@ -35,6 +35,7 @@ type ModelNewTokenInput struct {
 
 type ModelEndManagerInput struct {
 	Result *ModelResult
+	Cached bool
 }
 
 type ModelEndInput struct {
@@ -215,6 +216,8 @@ type CallbackManagerForToolRun interface {
 	OnToolEnd(ctx context.Context, input *ToolEndManagerInput) error
 	OnToolError(ctx context.Context, input *ToolErrorManagerInput) error
 	OnText(ctx context.Context, input *TextManagerInput) error
+	GetInheritableCallbacks() []Callback
+	RunID() string
 }
 
 type CallbackManagerForRetrieverRun interface {
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -12,6 +13,15 @@ type FunctionCall struct {
 	Arguments string `json:"arguments,omitempty"`
 }
 
+// ToolCall represents a tool call requested by a chat model. Models can request several
+// tool calls in a single message. The ID links the call to the ToolChatMessage holding its result.
+type ToolCall struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// call tool with arguments in JSON format
+	Arguments string `json:"arguments,omitempty"`
+}
+
 // ChatMessageType represents the type of a chat message.
 type ChatMessageType string
 
@@ -21,11 +31,14 @@ const (
 	ChatMessageTypeSystem   ChatMessageType = "system"
 	ChatMessageTypeGeneric  ChatMessageType = "generic"
 	ChatMessageTypeFunction ChatMessageType = "function"
+	ChatMessageTypeTool     ChatMessageType = "tool"
 )
 
 // ChatMessageExtension represents additional data associated with a chat message.
 type ChatMessageExtension struct {
+	// FunctionCall is the legacy single function call. It is set to the first tool call for compatibility.
 	FunctionCall *FunctionCall `json:"functionCall,omitempty"`
+	ToolCalls    []ToolCall    `json:"toolCalls,omitempty"`
 }
 
 // ChatMessage is an interface for different types of chat messages.
@@ -43,10 +56,19 @@ func ChatMessageToMap(cm ChatMessage) map[string]string {
 		"content": cm.Content(),
 	}
 
-	if fm, ok := cm.(*FunctionChatMessage); ok {
-		m["name"] = fm.Name()
-	} else if gm, ok := cm.(*GenericChatMessage); ok {
-		m["role"] = gm.Role()
+	switch v := cm.(type) {
+	case *FunctionChatMessage:
+		m["name"] = v.Name()
+	case *GenericChatMessage:
+		m["role"] = v.Role()
+	case *ToolChatMessage:
+		m["toolCallId"] = v.ToolCallID()
+		m["name"] = v.Name()
+	case *AIChatMessage:
+		if len(v.ext.ToolCalls) > 0 {
+			b, _ := json.Marshal(v.ext.ToolCalls) // tool calls hold only strings, marshaling can't fail
+			m["toolCalls"] = string(b)
+		}
 	}
 
 	return m
@@ -58,13 +80,26 @@ func MapToChatMessage(m map[string]string) (ChatMessage, error) {
 	case ChatMessageTypeHuman:
 		return NewHumanChatMessage(m["content"]), nil
 	case ChatMessageTypeAI:
-		return NewAIChatMessage(m["content"]), nil
+		if m["toolCalls"] == "" {
+			return NewAIChatMessage(m["content"]), nil
+		}
+
+		toolCalls := []ToolCall{}
+		if err := json.Unmarshal([]byte(m["toolCalls"]), &toolCalls); err != nil {
+			return nil, fmt.Errorf("invalid tool calls: %w", err)
+		}
+
+		return NewAIChatMessage(m["content"], func(o *ChatMessageExtension) {
+			o.ToolCalls = toolCalls
+		}), nil
 	case ChatMessageTypeSystem:
 		return NewSystemChatMessage(m["content"]), nil
 	case ChatMessageTypeGeneric:
 		return NewGenericChatMessage(m["content"], m["role"]), nil
 	case ChatMessageTypeFunction:
-		return NewFunctionChatMessage(m["content"], m["name"]), nil
+		return NewFunctionChatMessage(m["name"], m["content"]), nil
+	case ChatMessageTypeTool:
+		return NewToolChatMessage(m["toolCallId"], m["name"], m["content"]), nil
 	default:
 		return nil, fmt.Errorf("unknown chat message type: %s", m["type"])
 	}
@@ -117,6 +152,20 @@ func (m AIChatMessage) Content() string { return m.content }
 // Extension returns the extension data of the chat message.
 func (m AIChatMessage) Extension() ChatMessageExtension { return m.ext }
 
+// ToolCalls returns the tool calls requested with the chat message. Messages holding only a
+// legacy function call return it as a single tool call without ID.
+func (m AIChatMessage) ToolCalls() []ToolCall {
+	if len(m.ext.ToolCalls) > 0 {
+		return m.ext.ToolCalls
+	}
+
+	if m.ext.FunctionCall != nil {
+		return []ToolCall{{Name: m.ext.FunctionCall.Name, Arguments: m.ext.FunctionCall.Arguments}}
+	}
+
+	return nil
+}
+
 // SystemChatMessage represents a chat message from the system.
 type SystemChatMessage struct {
 	content string
@@ -181,15 +230,77 @@ func (m FunctionChatMessage) Content() string { return m.content }
 // Name returns the name of the function associated with the chat message.
 func (m FunctionChatMessage) Name() string { return m.name }
 
+// ToolChatMessage represents a chat message holding the result of a tool call.
+type ToolChatMessage struct {
+	toolCallID string
+	name       string
+	content    string
+}
+
+// NewToolChatMessage creates a new ToolChatMessage instance for the tool call with the given ID.
+func NewToolChatMessage(toolCallID, name, content string) *ToolChatMessage {
+	return &ToolChatMessage{
+		toolCallID: toolCallID,
+		name:       name,
+		content:    content,
+	}
+}
+
+// Type returns the type of the chat message.
+func (m ToolChatMessage) Type() ChatMessageType { return ChatMessageTypeTool }
+
+// Content returns the content of the chat message.
+func (m ToolChatMessage) Content() string { return m.content }
+
+// ToolCallID returns the ID of the tool call the chat message responds to.
+func (m ToolChatMessage) ToolCallID() string { return m.toolCallID }
+
+// Name returns the name of the tool associated with the chat message.
+func (m ToolChatMessage) Name() string { return m.name }
+
 // ChatMessages represents a slice of ChatMessage.
 type ChatMessages []ChatMessage
 
+// MarshalJSON marshals the ChatMessages as list of their map representations.
+func (cm ChatMessages) MarshalJSON() ([]byte, error) {
+	maps := make([]map[string]string, len(cm))
+	for i, m := range cm {
+		maps[i] = ChatMessageToMap(m)
+	}
+
+	return json.Marshal(maps)
+}
+
+// UnmarshalJSON unmarshals ChatMessages from a list of their map representations.
+func (cm *ChatMessages) UnmarshalJSON(data []byte) error {
+	maps := []map[string]string{}
+	if err := json.Unmarshal(data, &maps); err != nil {
+		return err
+	}
+
+	messages := make(ChatMessages, len(maps))
+
+	for i, m := range maps {
+		message, err := MapToChatMessage(m)
+		if err != nil {
+			return err
+		}
+
+		messages[i] = message
+	}
+
+	*cm = messages
+
+	return nil
+}
+
 // StringifyChatMessagesOptions represents options for formatting ChatMessages.
 type StringifyChatMessagesOptions struct {
 	HumanPrefix    string
 	AIPrefix       string
 	SystemPrefix   string
 	FunctionPrefix string
+	ToolPrefix     string
 }
 
 // Format formats the ChatMessages into a single string representation.
@@ -199,6 +310,7 @@ func (cm ChatMessages) Format(optFns ...func(o *StringifyChatMessagesOptions)) (
 		AIPrefix:       "AI",
 		SystemPrefix:   "System",
 		FunctionPrefix: "Function",
+		ToolPrefix:     "Tool",
 	}
 
 	for _, fn := range optFns {
@@ -221,6 +333,8 @@ func (cm ChatMessages) Format(optFns ...func(o *StringifyChatMessagesOptions)) (
 			role = message.(*GenericChatMessage).Role()
 		case ChatMessageTypeFunction:
 			role = opts.FunctionPrefix
+		case ChatMessageTypeTool:
+			role = opts.ToolPrefix
 		default:
 			return "", fmt.Errorf("unknown chat message type: %s", message.Type())
 		}
@@ -47,6 +47,52 @@ func TestMapToChatMessage(t *testing.T) {
 	require.Equal(t, "Hello, I am an AI.", aiMsg.Content())
 }
 
+func TestToolChatMessageRoundTrip(t *testing.T) {
+	toolCalls := []ToolCall{
+		{ID: "call_1", Name: "search", Arguments: `{"query":"golc"}`},
+		{ID: "call_2", Name: "calculator", Arguments: `{"expr":"1+1"}`},
+	}
+
+	aiMsg := NewAIChatMessage("", func(o *ChatMessageExtension) {
+		o.ToolCalls = toolCalls
+	})
+
+	msg, err := MapToChatMessage(ChatMessageToMap(aiMsg))
+	require.NoError(t, err)
+	require.IsType(t, &AIChatMessage{}, msg)
+	require.Equal(t, toolCalls, msg.(*AIChatMessage).ToolCalls())
+
+	toolMsg := NewToolChatMessage("call_1", "search", "result")
+
+	msg, err = MapToChatMessage(ChatMessageToMap(toolMsg))
+	require.NoError(t, err)
+	require.Equal(t, toolMsg, msg)
+}
+
+func TestFunctionChatMessageRoundTrip(t *testing.T) {
+	functionMsg := NewFunctionChatMessage("search", "result")
+
+	msg, err := MapToChatMessage(ChatMessageToMap(functionMsg))
+	require.NoError(t, err)
+	require.Equal(t, functionMsg, msg)
+	require.Equal(t, "search", msg.(*FunctionChatMessage).Name())
+	require.Equal(t, "result", msg.Content())
+}
+
+func TestAIChatMessageToolCalls(t *testing.T) {
+	t.Run("Legacy function call", func(t *testing.T) {
+		msg := NewAIChatMessage("", func(o *ChatMessageExtension) {
+			o.FunctionCall = &FunctionCall{Name: "search", Arguments: "{}"}
+		})
+
+		require.Equal(t, []ToolCall{{Name: "search", Arguments: "{}"}}, msg.ToolCalls())
+	})
+
+	t.Run("No tool calls", func(t *testing.T) {
+		require.Nil(t, NewAIChatMessage("content").ToolCalls())
+	})
+}
+
 func TestStringifyChatMessages(t *testing.T) {
 	chatMessages := ChatMessages{
 		NewHumanChatMessage("Hello, I am a human."),
@@ -54,6 +100,7 @@ func TestStringifyChatMessages(t *testing.T) {
 		NewSystemChatMessage("System message."),
 		NewGenericChatMessage("Generic message.", "role"),
 		NewFunctionChatMessage("function", "Function call message."),
+		NewToolChatMessage("call_1", "tool", "Tool call message."),
 	}
 
 	formatted, err := chatMessages.Format()
@@ -63,4 +110,5 @@ func TestStringifyChatMessages(t *testing.T) {
 	require.Contains(t, formatted, "System: System message.")
 	require.Contains(t, formatted, "role: Generic message.")
 	require.Contains(t, formatted, "Function: Function call message.")
+	require.Contains(t, formatted, "Tool: Tool call message.")
 }
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrInvalidChainValues  = errors.New("invalid chain values")
 	ErrChainValueWrongType = errors.New("chain value is of wrong type")
+	ErrInvalidFilter       = errors.New("invalid filter")
 )
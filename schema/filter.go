@@ -0,0 +1,281 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FilterOperator is the operator of a metadata filter.
+type FilterOperator string
+
+const (
+	// FilterOperatorEq matches documents whose metadata value equals the filter value.
+	FilterOperatorEq FilterOperator = "eq"
+	// FilterOperatorNe matches documents whose metadata value does not equal the filter value or is missing.
+	FilterOperatorNe FilterOperator = "ne"
+	// FilterOperatorGt matches documents whose numeric metadata value is greater than the filter value.
+	FilterOperatorGt FilterOperator = "gt"
+	// FilterOperatorGte matches documents whose numeric metadata value is greater than or equal to the filter value.
+	FilterOperatorGte FilterOperator = "gte"
+	// FilterOperatorLt matches documents whose numeric metadata value is less than the filter value.
+	FilterOperatorLt FilterOperator = "lt"
+	// FilterOperatorLte matches documents whose numeric metadata value is less than or equal to the filter value.
+	FilterOperatorLte FilterOperator = "lte"
+	// FilterOperatorIn matches documents whose metadata value equals one of the filter values.
+	FilterOperatorIn FilterOperator = "in"
+	// FilterOperatorNin matches documents whose metadata value equals none of the filter values or is missing.
+	FilterOperatorNin FilterOperator = "nin"
+	// FilterOperatorAnd matches documents that match all filters.
+	FilterOperatorAnd FilterOperator = "and"
+	// FilterOperatorOr matches documents that match at least one of the filters.
+	FilterOperatorOr FilterOperator = "or"
+)
+
+// Filter is a portable filter expression on the metadata of documents. Vector stores translate
+// filters to their native form, e.g. the metadata filter of Pinecone or the where filter of Weaviate.
+//
+// Filter values are strings, booleans or numbers. Range operators only match numbers.
+type Filter struct {
+	// Operator is the operator of the filter.
+	Operator FilterOperator `json:"operator"`
+	// Key is the metadata key of a comparison.
+	Key string `json:"key,omitempty"`
+	// Value is the value of a comparison. It is a list of values for the in and nin operators.
+	Value any `json:"value,omitempty"`
+	// Filters are the operands of the and and or operators.
+	Filters []*Filter `json:"filters,omitempty"`
+}
+
+// FilterEq returns a filter that matches documents whose metadata value for the key equals the value.
+func FilterEq(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorEq, Key: key, Value: value}
+}
+
+// FilterNe returns a filter that matches documents whose metadata value for the key does not equal the value.
+func FilterNe(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorNe, Key: key, Value: value}
+}
+
+// FilterGt returns a filter that matches documents whose metadata value for the key is greater than the value.
+func FilterGt(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorGt, Key: key, Value: value}
+}
+
+// FilterGte returns a filter that matches documents whose metadata value for the key is greater than or equal to the value.
+func FilterGte(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorGte, Key: key, Value: value}
+}
+
+// FilterLt returns a filter that matches documents whose metadata value for the key is less than the value.
+func FilterLt(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorLt, Key: key, Value: value}
+}
+
+// FilterLte returns a filter that matches documents whose metadata value for the key is less than or equal to the value.
+func FilterLte(key string, value any) *Filter {
+	return &Filter{Operator: FilterOperatorLte, Key: key, Value: value}
+}
+
+// FilterIn returns a filter that matches documents whose metadata value for the key equals one of the values.
+func FilterIn(key string, values ...any) *Filter {
+	return &Filter{Operator: FilterOperatorIn, Key: key, Value: values}
+}
+
+// FilterNin returns a filter that matches documents whose metadata value for the key equals none of the values.
+func FilterNin(key string, values ...any) *Filter {
+	return &Filter{Operator: FilterOperatorNin, Key: key, Value: values}
+}
+
+// FilterAnd returns a filter that matches documents that match all filters.
+func FilterAnd(filters ...*Filter) *Filter {
+	return &Filter{Operator: FilterOperatorAnd, Filters: filters}
+}
+
+// FilterOr returns a filter that matches documents that match at least one of the filters.
+func FilterOr(filters ...*Filter) *Filter {
+	return &Filter{Operator: FilterOperatorOr, Filters: filters}
+}
+
+// Values returns the list of values of the in and nin operators.
+func (f *Filter) Values() []any {
+	values, _ := f.Value.([]any)
+	return values
+}
+
+// Validate checks that the filter and its operands are well-formed.
+func (f *Filter) Validate() error {
+	if f == nil {
+		return fmt.Errorf("%w: nil filter", ErrInvalidFilter)
+	}
+
+	switch f.Operator {
+	case FilterOperatorAnd, FilterOperatorOr:
+		if len(f.Filters) == 0 {
+			return fmt.Errorf("%w: %s without operands", ErrInvalidFilter, f.Operator)
+		}
+
+		for _, operand := range f.Filters {
+			if err := operand.Validate(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case FilterOperatorEq, FilterOperatorNe, FilterOperatorGt, FilterOperatorGte, FilterOperatorLt, FilterOperatorLte, FilterOperatorIn, FilterOperatorNin:
+		if f.Key == "" {
+			return fmt.Errorf("%w: %s without key", ErrInvalidFilter, f.Operator)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported operator %q", ErrInvalidFilter, f.Operator)
+	}
+
+	switch f.Operator {
+	case FilterOperatorEq, FilterOperatorNe:
+		if !isFilterScalar(f.Value) {
+			return fmt.Errorf("%w: unsupported value %v for key %s", ErrInvalidFilter, f.Value, f.Key)
+		}
+	case FilterOperatorGt, FilterOperatorGte, FilterOperatorLt, FilterOperatorLte:
+		if _, ok := filterNumber(f.Value); !ok {
+			return fmt.Errorf("%w: %s requires a number for key %s", ErrInvalidFilter, f.Operator, f.Key)
+		}
+	case FilterOperatorIn, FilterOperatorNin:
+		values, ok := f.Value.([]any)
+		if !ok || len(values) == 0 {
+			return fmt.Errorf("%w: %s requires a list of values for key %s", ErrInvalidFilter, f.Operator, f.Key)
+		}
+
+		for _, v := range values {
+			if !isFilterScalar(v) {
+				return fmt.Errorf("%w: unsupported value %v for key %s", ErrInvalidFilter, v, f.Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Match reports whether the metadata matches the filter. It defines the semantics every vector
+// store translation follows, and is used by stores that filter documents themselves.
+func (f *Filter) Match(metadata map[string]any) bool {
+	switch f.Operator {
+	case FilterOperatorAnd:
+		for _, operand := range f.Filters {
+			if !operand.Match(metadata) {
+				return false
+			}
+		}
+
+		return true
+	case FilterOperatorOr:
+		for _, operand := range f.Filters {
+			if operand.Match(metadata) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	value, ok := metadata[f.Key]
+
+	switch f.Operator {
+	case FilterOperatorEq:
+		return ok && filterEqual(value, f.Value)
+	case FilterOperatorNe:
+		return !ok || !filterEqual(value, f.Value)
+	case FilterOperatorIn, FilterOperatorNin:
+		found := false
+
+		for _, v := range f.Values() {
+			if ok && filterEqual(value, v) {
+				found = true
+				break
+			}
+		}
+
+		return found == (f.Operator == FilterOperatorIn)
+	}
+
+	n, ok := filterNumber(value)
+	if !ok {
+		return false
+	}
+
+	threshold, ok := filterNumber(f.Value)
+	if !ok {
+		return false
+	}
+
+	switch f.Operator {
+	case FilterOperatorGt:
+		return n > threshold
+	case FilterOperatorGte:
+		return n >= threshold
+	case FilterOperatorLt:
+		return n < threshold
+	case FilterOperatorLte:
+		return n <= threshold
+	default:
+		return false
+	}
+}
+
+// filterEqual reports whether two filter values are equal. Numbers are compared by value regardless of their type.
+func filterEqual(a, b any) bool {
+	if x, ok := filterNumber(a); ok {
+		y, ok := filterNumber(b)
+		return ok && x == y
+	}
+
+	if !isFilterScalar(a) {
+		return false
+	}
+
+	return a == b
+}
+
+// isFilterScalar reports whether the value is a string, a boolean or a number.
+func isFilterScalar(v any) bool {
+	switch v.(type) {
+	case string, bool:
+		return true
+	default:
+		_, ok := filterNumber(v)
+		return ok
+	}
+}
+
+// filterNumber returns the value as float64, if it is a number.
+func filterNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("Match", func(t *testing.T) {
+		metadata := map[string]any{
+			"kind":  "fruit",
+			"year":  2020,
+			"price": 1.5,
+			"ripe":  true,
+		}
+
+		tests := []struct {
+			name     string
+			filter   *Filter
+			expected bool
+		}{
+			{"Eq", FilterEq("kind", "fruit"), true},
+			{"Eq number", FilterEq("year", 2020.0), true},
+			{"Eq missing", FilterEq("color", "red"), false},
+			{"Ne", FilterNe("kind", "vegetable"), true},
+			{"Ne missing", FilterNe("color", "red"), true},
+			{"Gt", FilterGt("year", 2019), true},
+			{"Gte", FilterGte("price", 1.5), true},
+			{"Lt", FilterLt("price", 1), false},
+			{"Lte string", FilterLte("kind", 1), false},
+			{"In", FilterIn("kind", "vegetable", "fruit"), true},
+			{"In missing", FilterIn("color", "red"), false},
+			{"Nin", FilterNin("kind", "vegetable"), true},
+			{"Nin missing", FilterNin("color", "red"), true},
+			{"And", FilterAnd(FilterEq("ripe", true), FilterGt("year", 2000)), true},
+			{"And false", FilterAnd(FilterEq("ripe", true), FilterGt("year", 2020)), false},
+			{"Or", FilterOr(FilterEq("kind", "vegetable"), FilterLt("price", 2)), true},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				require.NoError(t, tc.filter.Validate())
+				assert.Equal(t, tc.expected, tc.filter.Match(metadata))
+			})
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		for _, filter := range []*Filter{
+			nil,
+			{Operator: "like", Key: "kind", Value: "f%"},
+			FilterEq("", "fruit"),
+			FilterEq("kind", []string{"fruit"}),
+			FilterGt("kind", "fruit"),
+			FilterIn("kind"),
+			FilterAnd(),
+			FilterOr(FilterEq("kind", "fruit"), nil),
+		} {
+			assert.ErrorIs(t, filter.Validate(), ErrInvalidFilter)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var filter Filter
+
+		err := json.Unmarshal([]byte(`{"operator":"and","filters":[{"operator":"in","key":"kind","value":["fruit"]},{"operator":"gt","key":"year","value":2000}]}`), &filter)
+		require.NoError(t, err)
+		require.NoError(t, filter.Validate())
+		assert.True(t, filter.Match(map[string]any{"kind": "fruit", "year": 2020}))
+	})
+}
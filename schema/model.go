@@ -63,11 +63,47 @@ type FunctionDefinition struct {
 	Parameters  FunctionDefinitionParameters `json:"parameters"`
 }
 
+// Tool choices supported by all chat models. Any other tool choice is interpreted as the name
+// of the tool the model is forced to call.
+const (
+	// ToolChoiceAuto lets the model decide whether to call tools.
+	ToolChoiceAuto = "auto"
+	// ToolChoiceNone prevents the model from calling tools.
+	ToolChoiceNone = "none"
+	// ToolChoiceRequired forces the model to call at least one tool.
+	ToolChoiceRequired = "required"
+)
+
 type GenerateOptions struct {
-	CallbackManger    CallbackManagerForModelRun
-	Stop              []string
-	Functions         []FunctionDefinition
+	CallbackManger CallbackManagerForModelRun
+	Stop           []string
+	// Functions are the tools the model can call.
+	Functions []FunctionDefinition
+	// ForceFunctionCall forces the call of the first function.
+	//
+	// Deprecated: Use ToolChoice instead.
 	ForceFunctionCall bool
+	// ToolChoice controls which tools the model calls: ToolChoiceAuto, ToolChoiceNone,
+	// ToolChoiceRequired or the name of a tool. Defaults to ToolChoiceAuto.
+	ToolChoice string
+}
+
+// ResolveToolChoice returns the effective tool choice of the options. It considers the legacy
+// ForceFunctionCall option and returns an empty string if no functions are provided.
+func (o *GenerateOptions) ResolveToolChoice() string {
+	if len(o.Functions) == 0 {
+		return ""
+	}
+
+	if o.ToolChoice != "" {
+		return o.ToolChoice
+	}
+
+	if o.ForceFunctionCall {
+		return o.Functions[0].Name
+	}
+
+	return ToolChoiceAuto
 }
 
 // LLM is the interface for language models.
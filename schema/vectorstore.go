@@ -0,0 +1,50 @@
+package schema
+
+import "context"
+
+// ScoredDocument is a document returned by a similarity search with its ID and relevance score.
+type ScoredDocument struct {
+	Document
+	// ID is the ID of the document in the vector store.
+	ID string
+	// Score is the relevance of the document to the query. Higher scores are more relevant.
+	// The range of the score depends on the vector store and its distance function, so scores
+	// are only comparable within the same store.
+	Score float32
+	// Vector is the embedding vector of the document. It is only set if IncludeVectors is requested.
+	Vector []float32
+}
+
+// VectorStoreSearchOptions contains the per-call options of a similarity search.
+type VectorStoreSearchOptions struct {
+	// K is the number of documents to return. The TopK of the vector store is used if it is zero.
+	K int
+	// ScoreThreshold omits documents with a score lower than the threshold. It is disabled if it is zero.
+	ScoreThreshold float32
+	// Filter restricts the search to documents whose metadata matches the filter.
+	Filter *Filter
+	// IncludeVectors returns the embedding vectors of the documents, e.g. to compute their similarity among each other.
+	IncludeVectors bool
+}
+
+// VectorStoreSearcher is a vector store that supports similarity searches with scores and per-call options.
+type VectorStoreSearcher interface {
+	VectorStore
+	// SimilaritySearchWithScore returns the documents most similar to the query with their scores.
+	SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *VectorStoreSearchOptions)) ([]ScoredDocument, error)
+	// SimilaritySearchByVector returns the documents most similar to the embedding vector with their scores.
+	SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *VectorStoreSearchOptions)) ([]ScoredDocument, error)
+}
+
+// VectorStoreWriter is a vector store whose documents are identified by IDs, so that they can be updated and deleted.
+// Vector stores whose Delete has a different signature provide an adapter instead, e.g. the Writer method of
+// vectorstore.Weaviate.
+type VectorStoreWriter interface {
+	VectorStore
+	// Add adds the documents and returns their generated IDs.
+	Add(ctx context.Context, docs []Document) ([]string, error)
+	// Upsert inserts the documents with the given IDs or replaces the existing documents with these IDs.
+	Upsert(ctx context.Context, ids []string, docs []Document) error
+	// Delete deletes the documents with the given IDs. Unknown IDs are ignored.
+	Delete(ctx context.Context, ids []string) error
+}
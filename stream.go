@@ -0,0 +1,203 @@
+package golc
+
+import (
+	"context"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+)
+
+// StreamEventType represents the type of a stream event.
+type StreamEventType string
+
+const (
+	StreamEventTypeChainStart    StreamEventType = "chain_start"
+	StreamEventTypeChainEnd      StreamEventType = "chain_end"
+	StreamEventTypeModelToken    StreamEventType = "model_token"
+	StreamEventTypeToolStart     StreamEventType = "tool_start"
+	StreamEventTypeToolEnd       StreamEventType = "tool_end"
+	StreamEventTypeRetrieverDocs StreamEventType = "retriever_docs"
+	StreamEventTypeAgentAction   StreamEventType = "agent_action"
+	StreamEventTypeAgentFinish   StreamEventType = "agent_finish"
+	StreamEventTypeOutput        StreamEventType = "output"
+	StreamEventTypeError         StreamEventType = "error"
+)
+
+const defaultStreamBufferSize = 64
+
+// StreamEvent represents a single event emitted while a chain is executed with Stream.
+// Only the fields related to the event type are populated.
+type StreamEvent struct {
+	// Type is the type of the event.
+	Type StreamEventType
+	// RunID is the id of the run that emitted the event.
+	RunID string
+	// ChainType is the type of the chain for chain start events.
+	ChainType string
+	// Inputs are the inputs of the chain for chain start events.
+	Inputs schema.ChainValues
+	// Outputs are the outputs of a chain for chain end and output events.
+	Outputs schema.ChainValues
+	// Token is the new token for model token events.
+	Token string
+	// ToolName is the name of the tool for tool start events.
+	ToolName string
+	// ToolInput is the input of the tool for tool start events.
+	ToolInput *schema.ToolInput
+	// ToolOutput is the output of the tool for tool end events.
+	ToolOutput string
+	// Docs are the retrieved documents for retriever docs events.
+	Docs []schema.Document
+	// Action is the planned action for agent action events.
+	Action *schema.AgentAction
+	// Finish is the return value of the agent for agent finish events.
+	Finish *schema.AgentFinish
+	// Error is the error for error events.
+	Error error
+}
+
+// StreamOptions contains options for streaming the execution of a chain.
+type StreamOptions struct {
+	Callbacks   []schema.Callback
+	ParentRunID string
+	Stop        []string
+	// BufferSize is the size of the buffer of the returned event channel. A negative size is treated as zero.
+	BufferSize int
+}
+
+// Stream executes a chain in the background and returns a channel emitting the events
+// of the execution. The channel is closed after the final output or error event was sent.
+// Model tokens are only emitted by models with streaming enabled.
+// Cancelling the context stops the execution and closes the channel.
+func Stream(ctx context.Context, chain schema.Chain, inputs schema.ChainValues, optFns ...func(*StreamOptions)) <-chan StreamEvent {
+	opts := StreamOptions{
+		BufferSize: defaultStreamBufferSize,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.BufferSize < 0 {
+		opts.BufferSize = 0
+	}
+
+	events := make(chan StreamEvent, opts.BufferSize)
+
+	handler := &streamHandler{
+		events: events,
+	}
+
+	go func() {
+		defer close(events)
+
+		outputs, err := Call(ctx, chain, inputs, func(o *CallOptions) {
+			o.Callbacks = append([]schema.Callback{handler}, opts.Callbacks...)
+			o.ParentRunID = opts.ParentRunID
+			o.Stop = opts.Stop
+		})
+		if err != nil {
+			_ = handler.send(ctx, StreamEvent{Type: StreamEventTypeError, Error: err})
+			return
+		}
+
+		_ = handler.send(ctx, StreamEvent{Type: StreamEventTypeOutput, Outputs: outputs})
+	}()
+
+	return events
+}
+
+// Compile time check to ensure streamHandler satisfies the Callback interface.
+var _ schema.Callback = (*streamHandler)(nil)
+
+// streamHandler is a callback handler that forwards callback invocations as stream events.
+type streamHandler struct {
+	callback.NoopHandler
+	events chan<- StreamEvent
+}
+
+// AlwaysVerbose returns true, so that the handler receives all events regardless of the verbosity settings.
+func (h *streamHandler) AlwaysVerbose() bool {
+	return true
+}
+
+// RaiseError returns true, so that a cancelled context aborts the execution.
+func (h *streamHandler) RaiseError() bool {
+	return true
+}
+
+func (h *streamHandler) OnChainStart(ctx context.Context, input *schema.ChainStartInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:      StreamEventTypeChainStart,
+		RunID:     input.RunID,
+		ChainType: input.ChainType,
+		Inputs:    input.Inputs,
+	})
+}
+
+func (h *streamHandler) OnChainEnd(ctx context.Context, input *schema.ChainEndInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:    StreamEventTypeChainEnd,
+		RunID:   input.RunID,
+		Outputs: input.Outputs,
+	})
+}
+
+func (h *streamHandler) OnModelNewToken(ctx context.Context, input *schema.ModelNewTokenInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:  StreamEventTypeModelToken,
+		RunID: input.RunID,
+		Token: input.Token,
+	})
+}
+
+func (h *streamHandler) OnAgentAction(ctx context.Context, input *schema.AgentActionInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:   StreamEventTypeAgentAction,
+		RunID:  input.RunID,
+		Action: input.Action,
+	})
+}
+
+func (h *streamHandler) OnAgentFinish(ctx context.Context, input *schema.AgentFinishInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:   StreamEventTypeAgentFinish,
+		RunID:  input.RunID,
+		Finish: input.Finish,
+	})
+}
+
+func (h *streamHandler) OnToolStart(ctx context.Context, input *schema.ToolStartInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:      StreamEventTypeToolStart,
+		RunID:     input.RunID,
+		ToolName:  input.ToolName,
+		ToolInput: input.Input,
+	})
+}
+
+func (h *streamHandler) OnToolEnd(ctx context.Context, input *schema.ToolEndInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:       StreamEventTypeToolEnd,
+		RunID:      input.RunID,
+		ToolOutput: input.Output,
+	})
+}
+
+func (h *streamHandler) OnRetrieverEnd(ctx context.Context, input *schema.RetrieverEndInput) error {
+	return h.send(ctx, StreamEvent{
+		Type:  StreamEventTypeRetrieverDocs,
+		RunID: input.RunID,
+		Docs:  input.Docs,
+	})
+}
+
+// send sends the event to the events channel or returns the context error if the context is done.
+func (h *streamHandler) send(ctx context.Context, event StreamEvent) error {
+	select {
+	case h.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
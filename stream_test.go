@@ -0,0 +1,134 @@
+package golc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hupe1980/golc/callback"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		chain := &streamingChain{tokens: []string{"Hello", " ", "World"}}
+
+		events := collectEvents(Stream(context.Background(), chain, schema.ChainValues{"input": "test"}))
+
+		assert.Equal(t, []StreamEventType{
+			StreamEventTypeChainStart,
+			StreamEventTypeModelToken,
+			StreamEventTypeModelToken,
+			StreamEventTypeModelToken,
+			StreamEventTypeChainEnd,
+			StreamEventTypeOutput,
+		}, eventTypes(events))
+
+		assert.Equal(t, "Mock", events[0].ChainType)
+		assert.Equal(t, "Hello", events[1].Token)
+		assert.Equal(t, schema.ChainValues{"output": "Hello World"}, events[5].Outputs)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		chain := &streamingChain{err: errors.New("chain error")}
+
+		events := collectEvents(Stream(context.Background(), chain, schema.ChainValues{"input": "test"}))
+
+		assert.Equal(t, []StreamEventType{
+			StreamEventTypeChainStart,
+			StreamEventTypeError,
+		}, eventTypes(events))
+
+		assert.EqualError(t, events[1].Error, "chain error")
+	})
+
+	t.Run("NegativeBufferSize", func(t *testing.T) {
+		chain := &streamingChain{tokens: []string{"Hello"}}
+
+		events := collectEvents(Stream(context.Background(), chain, schema.ChainValues{"input": "test"}, func(o *StreamOptions) {
+			o.BufferSize = -1
+		}))
+
+		assert.Equal(t, StreamEventTypeOutput, events[len(events)-1].Type)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		chain := &streamingChain{tokens: []string{"Hello", " ", "World"}}
+
+		events := Stream(ctx, chain, schema.ChainValues{"input": "test"}, func(o *StreamOptions) {
+			o.BufferSize = 0
+		})
+
+		event := <-events
+		assert.Equal(t, StreamEventTypeChainStart, event.Type)
+
+		cancel()
+
+		for e := range events {
+			if e.Type == StreamEventTypeError {
+				assert.ErrorIs(t, e.Error, context.Canceled)
+			}
+		}
+	})
+}
+
+func collectEvents(events <-chan StreamEvent) []StreamEvent {
+	result := []StreamEvent{}
+	for e := range events {
+		result = append(result, e)
+	}
+
+	return result
+}
+
+func eventTypes(events []StreamEvent) []StreamEventType {
+	types := make([]StreamEventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+
+	return types
+}
+
+// streamingChain is a mock chain that emits the given tokens through the callback manager.
+type streamingChain struct {
+	mockChain
+	tokens []string
+	err    error
+}
+
+func (c *streamingChain) Call(ctx context.Context, inputs schema.ChainValues, optFns ...func(o *schema.CallOptions)) (schema.ChainValues, error) {
+	opts := schema.CallOptions{
+		CallbackManger: &callback.NoopManager{},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	cm := callback.NewManager(opts.CallbackManger.GetInheritableCallbacks(), nil, false)
+
+	rm, err := cm.OnLLMStart(ctx, &schema.LLMStartManagerInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	output := ""
+
+	for _, token := range c.tokens {
+		if err := rm.OnModelNewToken(ctx, &schema.ModelNewTokenManagerInput{Token: token}); err != nil {
+			return nil, err
+		}
+
+		output += token
+	}
+
+	return schema.ChainValues{"output": output}, nil
+}
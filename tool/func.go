@@ -0,0 +1,127 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure Func satisfies the Tool and InputValidator interfaces.
+var (
+	_ schema.Tool    = (*Func[struct{}])(nil)
+	_ InputValidator = (*Func[struct{}])(nil)
+)
+
+// FuncOptions contains options for configuring the Func tool.
+type FuncOptions struct {
+	*schema.CallbackOptions
+}
+
+// Func is a tool that calls a function with typed arguments. The arguments type and its JSON schema
+// are derived from the type parameter, so custom tools don't need to implement schema.Tool.
+type Func[T any] struct {
+	name        string
+	description string
+	fn          func(ctx context.Context, args T) (string, error)
+	argsType    reflect.Type
+	schema      *jsonschema.Schema
+	opts        FuncOptions
+}
+
+// NewFunc creates a new Func tool with the given name, description and function. The JSON schema
+// of the arguments is generated from T, including the `jsonschema` and `description` struct tags.
+// It returns an error if the schema cannot be generated from T.
+func NewFunc[T any](name, description string, fn func(ctx context.Context, args T) (string, error), optFns ...func(o *FuncOptions)) (*Func[T], error) {
+	opts := FuncOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	argsType := reflect.TypeOf((*T)(nil)).Elem()
+
+	argsSchema, err := jsonschema.Generate(argsType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Func[T]{
+		name:        name,
+		description: description,
+		fn:          fn,
+		argsType:    argsType,
+		schema:      argsSchema,
+		opts:        opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *Func[T]) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *Func[T]) Description() string {
+	return t.description
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *Func[T]) ArgsType() reflect.Type {
+	return t.argsType
+}
+
+// ValidateInput validates structured inputs against the JSON schema of the arguments.
+func (t *Func[T]) ValidateInput(input *schema.ToolInput) error {
+	if !input.Structured() || t.argsType.Kind() == reflect.String {
+		return nil
+	}
+
+	return t.schema.ValidateJSON([]byte(input.String()))
+}
+
+// Run executes the tool with the given input and returns the output. Besides the arguments, the
+// input may be a JSON string, which is validated against the schema before it is unmarshaled.
+func (t *Func[T]) Run(ctx context.Context, input any) (string, error) {
+	switch v := input.(type) {
+	case T:
+		return t.fn(ctx, v)
+	case string:
+		if t.argsType.Kind() == reflect.String {
+			args, _ := reflect.ValueOf(v).Convert(t.argsType).Interface().(T)
+			return t.fn(ctx, args)
+		}
+
+		if err := t.schema.ValidateJSON([]byte(v)); err != nil {
+			return "", err
+		}
+
+		var args T
+		if err := json.Unmarshal([]byte(v), &args); err != nil {
+			return "", fmt.Errorf("cannot unmarshal arguments: %w", err)
+		}
+
+		return t.fn(ctx, args)
+	default:
+		return "", errors.New("illegal input type")
+	}
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *Func[T]) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *Func[T]) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
@@ -0,0 +1,64 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"The name of the city"`
+	Unit string `json:"unit,omitempty" jsonschema:"enum=celsius,enum=fahrenheit"`
+}
+
+func TestFunc(t *testing.T) {
+	weather, err := NewFunc("Weather", "Returns the current weather.", func(ctx context.Context, args weatherArgs) (string, error) {
+		return fmt.Sprintf("Sunny in %s (%s)", args.City, args.Unit), nil
+	})
+	require.NoError(t, err)
+
+	t.Run("Function definition", func(t *testing.T) {
+		f, err := ToFunction(weather)
+		require.NoError(t, err)
+		assert.Equal(t, "Weather", f.Name)
+		assert.Equal(t, []string{"city"}, f.Parameters.Required)
+		assert.Equal(t, "The name of the city", f.Parameters.Properties["city"].Description)
+		assert.Equal(t, []interface{}{"celsius", "fahrenheit"}, f.Parameters.Properties["unit"].Enum)
+	})
+
+	t.Run("Structured input", func(t *testing.T) {
+		output, err := Run(context.Background(), weather, schema.NewToolInputFromArguments(`{"city": "Berlin", "unit": "celsius"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Sunny in Berlin (celsius)", output)
+	})
+
+	t.Run("Missing required field", func(t *testing.T) {
+		_, err := Run(context.Background(), weather, schema.NewToolInputFromArguments(`{"unit": "celsius"}`))
+		assert.ErrorIs(t, err, jsonschema.ErrValidation)
+	})
+
+	t.Run("JSON string input", func(t *testing.T) {
+		output, err := Run(context.Background(), weather, schema.NewToolInputFromString(`{"city": "Paris", "unit": "fahrenheit"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "Sunny in Paris (fahrenheit)", output)
+
+		_, err = Run(context.Background(), weather, schema.NewToolInputFromString(`{"unit": "kelvin"}`))
+		assert.ErrorIs(t, err, jsonschema.ErrValidation)
+	})
+
+	t.Run("String arguments", func(t *testing.T) {
+		echo, err := NewFunc("Echo", "Echoes the input.", func(ctx context.Context, input string) (string, error) {
+			return input, nil
+		})
+		require.NoError(t, err)
+
+		output, err := Run(context.Background(), echo, schema.NewToolInputFromString("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello", output)
+	})
+}
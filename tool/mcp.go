@@ -0,0 +1,260 @@
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/integration/jsonschema"
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure MCP satisfies the Tool, ArgsSchemaProvider and InputValidator interfaces.
+var (
+	_ schema.Tool        = (*MCP)(nil)
+	_ ArgsSchemaProvider = (*MCP)(nil)
+	_ InputValidator     = (*MCP)(nil)
+)
+
+// ErrInvalidInputSchema is returned if the input schema of a server tool cannot be converted to a JSON schema.
+var ErrInvalidInputSchema = errors.New("invalid input schema")
+
+// MCPOptions contains options for configuring the MCP tool.
+type MCPOptions struct {
+	*schema.CallbackOptions
+}
+
+// MCP is a tool that calls a tool of a Model Context Protocol (MCP) server.
+type MCP struct {
+	client     *mcp.Client
+	tool       mcp.Tool
+	argsSchema *jsonschema.Schema
+	opts       MCPOptions
+}
+
+// NewMCP creates a new MCP tool for the given tool of the MCP server. The arguments of the tool
+// are described by the input schema of the server tool. Type arrays and numeric exclusive bounds
+// of newer JSON schema drafts are converted, other unsupported schemas return ErrInvalidInputSchema.
+func NewMCP(client *mcp.Client, tool mcp.Tool, optFns ...func(o *MCPOptions)) (*MCP, error) {
+	opts := MCPOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	argsSchema, err := parseMCPInputSchema(tool.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("%w of tool %s: %s", ErrInvalidInputSchema, tool.Name, err)
+	}
+
+	argsSchema.Type = jsonschema.TypeObject
+
+	if argsSchema.Properties == nil {
+		argsSchema.Properties = map[string]*jsonschema.Schema{}
+	}
+
+	return &MCP{
+		client:     client,
+		tool:       tool,
+		argsSchema: argsSchema,
+		opts:       opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *MCP) Name() string {
+	return t.tool.Name
+}
+
+// Description returns the description of the tool.
+func (t *MCP) Description() string {
+	return t.tool.Description
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *MCP) ArgsType() reflect.Type {
+	return reflect.TypeOf(map[string]any{})
+}
+
+// ArgsSchema returns the JSON schema of the arguments.
+func (t *MCP) ArgsSchema() *jsonschema.Schema {
+	return t.argsSchema
+}
+
+// ValidateInput validates structured inputs against the input schema of the server tool.
+func (t *MCP) ValidateInput(input *schema.ToolInput) error {
+	if !input.Structured() {
+		return nil
+	}
+
+	return t.argsSchema.ValidateJSON([]byte(input.String()))
+}
+
+// Run executes the tool with the given input and returns the output. A string input is passed as
+// JSON object or, if the tool has a single argument, as value of this argument.
+func (t *MCP) Run(ctx context.Context, input any) (string, error) {
+	var arguments json.RawMessage
+
+	switch v := input.(type) {
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = b
+	case string:
+		b, err := t.stringArguments(v)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = b
+	default:
+		return "", errors.New("illegal input type")
+	}
+
+	result, err := t.client.CallTool(ctx, t.tool.Name, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	if result.IsError {
+		return "", fmt.Errorf("%w: %s", mcp.ErrToolExecution, result.Text())
+	}
+
+	return result.Text(), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *MCP) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *MCP) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// stringArguments converts a string input into the JSON arguments of the tool.
+func (t *MCP) stringArguments(input string) (json.RawMessage, error) {
+	if trimmed := strings.TrimSpace(input); strings.HasPrefix(trimmed, "{") {
+		if err := t.argsSchema.ValidateJSON([]byte(trimmed)); err != nil {
+			return nil, err
+		}
+
+		return json.RawMessage(trimmed), nil
+	}
+
+	if len(t.argsSchema.Properties) != 1 {
+		return nil, fmt.Errorf("tool %s expects a JSON object with its arguments", t.tool.Name)
+	}
+
+	for name := range t.argsSchema.Properties {
+		return json.Marshal(map[string]any{name: input})
+	}
+
+	return nil, nil
+}
+
+// parseMCPInputSchema parses the input schema of a server tool. The schema is normalized to the
+// OpenAPI flavor of jsonschema.Schema first.
+func parseMCPInputSchema(inputSchema json.RawMessage) (*jsonschema.Schema, error) {
+	argsSchema := &jsonschema.Schema{}
+
+	if len(inputSchema) == 0 {
+		return argsSchema, nil
+	}
+
+	var raw any
+	if err := json.Unmarshal(inputSchema, &raw); err != nil {
+		return nil, err
+	}
+
+	normalized, err := json.Marshal(normalizeMCPSchema(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(normalized, argsSchema); err != nil {
+		return nil, err
+	}
+
+	return argsSchema, nil
+}
+
+// normalizeMCPSchema converts keywords of newer JSON schema drafts, which jsonschema.Schema does
+// not support, in the schema and its subschemas:
+//   - a type array becomes a single type, null becomes nullable. Several types allow any type.
+//   - a numeric exclusiveMinimum or exclusiveMaximum becomes the bound with the boolean flag.
+//   - a tuple items array allows any items.
+func normalizeMCPSchema(s any) any {
+	m, ok := s.(map[string]any)
+	if !ok {
+		return s
+	}
+
+	if types, ok := m["type"].([]any); ok {
+		delete(m, "type")
+
+		nonNull := []any{}
+
+		for _, t := range types {
+			if t == "null" {
+				m["nullable"] = true
+				continue
+			}
+
+			nonNull = append(nonNull, t)
+		}
+
+		if len(nonNull) == 1 {
+			m["type"] = nonNull[0]
+		}
+	}
+
+	for _, bound := range []string{"Minimum", "Maximum"} {
+		exclusive := "exclusive" + bound
+		if v, ok := m[exclusive].(float64); ok {
+			m[strings.ToLower(bound)] = v
+			m[exclusive] = true
+		}
+	}
+
+	if _, ok := m["items"].([]any); ok {
+		delete(m, "items")
+	}
+
+	for _, key := range []string{"items", "not", "additionalProperties"} {
+		if v, ok := m[key]; ok {
+			m[key] = normalizeMCPSchema(v)
+		}
+	}
+
+	for _, key := range []string{"properties", "patternProperties"} {
+		if properties, ok := m[key].(map[string]any); ok {
+			for name, property := range properties {
+				properties[name] = normalizeMCPSchema(property)
+			}
+		}
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if schemas, ok := m[key].([]any); ok {
+			for i, schema := range schemas {
+				schemas[i] = normalizeMCPSchema(schema)
+			}
+		}
+	}
+
+	return m
+}
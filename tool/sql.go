@@ -0,0 +1,335 @@
+package tool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hupe1980/golc"
+	"github.com/hupe1980/golc/chain"
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/prompt"
+	"github.com/hupe1980/golc/schema"
+)
+
+// defaultSQLQueryCheckerTemplate defines the default template for double checking SQL queries.
+const defaultSQLQueryCheckerTemplate = `{{.query}}
+Double check the {{.dialect}} query above for common mistakes, including:
+- Using NOT IN with NULL values
+- Using UNION when UNION ALL should have been used
+- Using BETWEEN for exclusive ranges
+- Data type mismatch in predicates
+- Properly quoting identifiers
+- Using the correct number of arguments for functions
+- Casting to the correct data type
+- Using the proper columns for joins
+
+If there are any of the above mistakes, rewrite the query. If there are no mistakes, just reproduce the original query.
+
+Output the final SQL query only.
+
+SQL Query: `
+
+// Compile time check to ensure ListSQLTables satisfies the Tool interface.
+var _ schema.Tool = (*ListSQLTables)(nil)
+
+// ListSQLTables is a tool that lists the tables of an SQL database.
+type ListSQLTables struct {
+	db *sqldb.SQLDB
+}
+
+// NewListSQLTables creates a new instance of the ListSQLTables tool.
+func NewListSQLTables(db *sqldb.SQLDB) *ListSQLTables {
+	return &ListSQLTables{
+		db: db,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *ListSQLTables) Name() string {
+	return "list_tables"
+}
+
+// Description returns the description of the tool.
+func (t *ListSQLTables) Description() string {
+	return `Returns a comma-separated list of the tables in the database. Input is an empty string.`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *ListSQLTables) ArgsType() reflect.Type {
+	return reflect.TypeOf(struct{}{})
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *ListSQLTables) Run(ctx context.Context, input any) (string, error) {
+	names, err := t.db.TableNames(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(names, ", "), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *ListSQLTables) Verbose() bool {
+	return false
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *ListSQLTables) Callbacks() []schema.Callback {
+	return nil
+}
+
+// Compile time check to ensure DescribeSQLTables satisfies the Tool interface.
+var _ schema.Tool = (*DescribeSQLTables)(nil)
+
+// DescribeSQLTables is a tool that returns the schema and sample rows of tables of an SQL database.
+type DescribeSQLTables struct {
+	db *sqldb.SQLDB
+}
+
+// NewDescribeSQLTables creates a new instance of the DescribeSQLTables tool.
+func NewDescribeSQLTables(db *sqldb.SQLDB) *DescribeSQLTables {
+	return &DescribeSQLTables{
+		db: db,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *DescribeSQLTables) Name() string {
+	return "describe_tables"
+}
+
+// Description returns the description of the tool.
+func (t *DescribeSQLTables) Description() string {
+	return `Returns the schema and sample rows of the given tables. Input is a comma-separated list of tables. Be sure that the tables actually exist by calling list_tables first!`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *DescribeSQLTables) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *DescribeSQLTables) Run(ctx context.Context, input any) (string, error) {
+	tables, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	names := []string{}
+
+	for _, name := range strings.Split(tables, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "", errors.New("no tables given")
+	}
+
+	return t.db.TableInfo(ctx, names...)
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *DescribeSQLTables) Verbose() bool {
+	return false
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *DescribeSQLTables) Callbacks() []schema.Callback {
+	return nil
+}
+
+// Compile time check to ensure SQLQueryChecker satisfies the Tool interface.
+var _ schema.Tool = (*SQLQueryChecker)(nil)
+
+// SQLQueryCheckerOptions contains options for configuring the SQLQueryChecker tool.
+type SQLQueryCheckerOptions struct {
+	*schema.CallbackOptions
+	// Template is the prompt template used to double check the query.
+	Template string
+}
+
+// SQLQueryChecker is a tool that uses a model to double check an SQL query for common mistakes
+// before it is executed. Queries that are not read-only are rejected.
+type SQLQueryChecker struct {
+	db       *sqldb.SQLDB
+	llmChain *chain.LLM
+	opts     SQLQueryCheckerOptions
+}
+
+// NewSQLQueryChecker creates a new instance of the SQLQueryChecker tool.
+func NewSQLQueryChecker(model schema.Model, db *sqldb.SQLDB, optFns ...func(o *SQLQueryCheckerOptions)) (*SQLQueryChecker, error) {
+	opts := SQLQueryCheckerOptions{
+		CallbackOptions: &schema.CallbackOptions{
+			Verbose: golc.Verbose,
+		},
+		Template: defaultSQLQueryCheckerTemplate,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	llmChain, err := chain.NewLLM(model, prompt.NewTemplate(opts.Template))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLQueryChecker{
+		db:       db,
+		llmChain: llmChain,
+		opts:     opts,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *SQLQueryChecker) Name() string {
+	return "query_checker"
+}
+
+// Description returns the description of the tool.
+func (t *SQLQueryChecker) Description() string {
+	return `Double checks if a query is correct before executing it. Input is an SQL query. Always use this tool before executing a query with execute_query!`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *SQLQueryChecker) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *SQLQueryChecker) Run(ctx context.Context, input any) (string, error) {
+	query, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	checked, err := golc.SimpleCall(ctx, t.llmChain, schema.ChainValues{
+		"dialect": t.db.Dialect(),
+		"query":   query,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	checked = cleanSQLQuery(checked)
+
+	if !sqldb.NewParser(checked).IsReadOnly() {
+		return "", fmt.Errorf("only read-only SELECT queries are allowed: %s", checked)
+	}
+
+	return checked, nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *SQLQueryChecker) Verbose() bool {
+	return t.opts.Verbose
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *SQLQueryChecker) Callbacks() []schema.Callback {
+	return t.opts.Callbacks
+}
+
+// Compile time check to ensure QuerySQL satisfies the Tool interface.
+var _ schema.Tool = (*QuerySQL)(nil)
+
+// QuerySQLOptions contains options for configuring the QuerySQL tool.
+type QuerySQLOptions struct {
+	// RowLimit is the maximum number of rows returned by the tool. Additional rows are not read.
+	RowLimit uint
+}
+
+// QuerySQL is a tool that executes read-only SQL queries in a read-only transaction. Queries that
+// are not a single SELECT statement are rejected.
+//
+// WARNING: The read-only check of the query is a best-effort keyword check, and whether a read-only
+// transaction rejects writes depends on the database. Always use a database user with the least
+// privileges necessary, e.g. read-only access to the relevant tables.
+type QuerySQL struct {
+	db   *sqldb.SQLDB
+	opts QuerySQLOptions
+}
+
+// NewQuerySQL creates a new instance of the QuerySQL tool.
+func NewQuerySQL(db *sqldb.SQLDB, optFns ...func(o *QuerySQLOptions)) *QuerySQL {
+	opts := QuerySQLOptions{
+		RowLimit: 10,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	return &QuerySQL{
+		db:   db,
+		opts: opts,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *QuerySQL) Name() string {
+	return "execute_query"
+}
+
+// Description returns the description of the tool.
+func (t *QuerySQL) Description() string {
+	return `Executes a read-only SQL query and returns the result. Input is a correct SQL SELECT query. If the query is not correct, an error message is returned. If an error is returned, rewrite the query, check it, and try again.`
+}
+
+// ArgsType returns the type of the input argument expected by the tool.
+func (t *QuerySQL) ArgsType() reflect.Type {
+	return reflect.TypeOf("") // string
+}
+
+// Run executes the tool with the given input and returns the output.
+func (t *QuerySQL) Run(ctx context.Context, input any) (string, error) {
+	query, ok := input.(string)
+	if !ok {
+		return "", errors.New("illegal input type")
+	}
+
+	query = cleanSQLQuery(query)
+
+	if !sqldb.NewParser(query).IsReadOnly() {
+		return "", fmt.Errorf("only read-only SELECT queries are allowed: %s", query)
+	}
+
+	result, err := t.db.QueryReadOnly(ctx, query, t.opts.RowLimit)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Truncated {
+		return fmt.Sprintf("%s(truncated to the first %d rows)", result, t.opts.RowLimit), nil
+	}
+
+	return result.String(), nil
+}
+
+// Verbose returns the verbosity setting of the tool.
+func (t *QuerySQL) Verbose() bool {
+	return false
+}
+
+// Callbacks returns the registered callbacks of the tool.
+func (t *QuerySQL) Callbacks() []schema.Callback {
+	return nil
+}
+
+// cleanSQLQuery removes markdown code fences, comments and a trailing semicolon from the query.
+func cleanSQLQuery(query string) string {
+	query = strings.TrimSpace(query)
+	query = strings.TrimPrefix(query, "```sql")
+	query = strings.TrimPrefix(query, "```")
+	query = strings.TrimSuffix(query, "```")
+
+	query = sqldb.CleanQuery(query)
+
+	return strings.TrimSpace(strings.TrimSuffix(query, ";"))
+}
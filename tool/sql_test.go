@@ -0,0 +1,120 @@
+package tool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/model/llm"
+	"github.com/hupe1980/golc/schema"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQL(t *testing.T) {
+	db := newTestSQLDB(t)
+
+	t.Run("ListSQLTables", func(t *testing.T) {
+		output, err := Run(context.Background(), NewListSQLTables(db), schema.NewToolInputFromArguments(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "customers, orders", output)
+	})
+
+	t.Run("DescribeSQLTables", func(t *testing.T) {
+		output, err := NewDescribeSQLTables(db).Run(context.Background(), "orders")
+		require.NoError(t, err)
+		assert.Contains(t, output, "CREATE TABLE `orders`")
+		assert.NotContains(t, output, "CREATE TABLE `customers`")
+
+		_, err = NewDescribeSQLTables(db).Run(context.Background(), "orders, unknown")
+		require.ErrorIs(t, err, sqldb.ErrTableNotFound)
+	})
+
+	t.Run("QuerySQL", func(t *testing.T) {
+		querySQL := NewQuerySQL(db, func(o *QuerySQLOptions) {
+			o.RowLimit = 2
+		})
+
+		output, err := querySQL.Run(context.Background(), "SELECT name FROM customers WHERE id = 1;")
+		require.NoError(t, err)
+		assert.Equal(t, "name\ncustomer 1\n", output)
+
+		output, err = querySQL.Run(context.Background(), "SELECT id FROM customers ORDER BY id")
+		require.NoError(t, err)
+		assert.Equal(t, "id\n0\n1\n(truncated to the first 2 rows)", output)
+
+		_, err = querySQL.Run(context.Background(), "SELECT unknown FROM customers")
+		assert.ErrorContains(t, err, "no such column")
+	})
+
+	t.Run("QuerySQL read-only", func(t *testing.T) {
+		querySQL := NewQuerySQL(db)
+
+		for _, query := range []string{
+			"DELETE FROM customers",
+			"SELECT id FROM customers; DROP TABLE customers",
+			"SELECT * INTO backup FROM customers",
+			"SELECT id FROM customers FOR UPDATE",
+			"WITH deleted AS (DELETE FROM customers RETURNING id) SELECT id FROM deleted",
+			"",
+		} {
+			_, err := querySQL.Run(context.Background(), query)
+			assert.Error(t, err, query)
+		}
+
+		output, err := querySQL.Run(context.Background(), "SELECT COUNT(*) FROM customers")
+		require.NoError(t, err)
+		assert.Equal(t, "COUNT(*)\n3\n", output)
+
+		output, err = querySQL.Run(context.Background(), "WITH named AS (SELECT id FROM customers WHERE name = 'customer 1') SELECT COUNT(*) FROM named")
+		require.NoError(t, err)
+		assert.Equal(t, "COUNT(*)\n1\n", output)
+
+		output, err = querySQL.Run(context.Background(), "SELECT COUNT(*) FROM customers WHERE name = 'a;b' OR name = 'it''s; into'")
+		require.NoError(t, err)
+		assert.Equal(t, "COUNT(*)\n0\n", output)
+	})
+
+	t.Run("SQLQueryChecker", func(t *testing.T) {
+		checker, err := NewSQLQueryChecker(llm.NewSimpleFake("```sql\nSELECT id FROM orders;\n```"), db)
+		require.NoError(t, err)
+
+		output, err := checker.Run(context.Background(), "SELECT id FROM order")
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT id FROM orders", output)
+
+		checker, err = NewSQLQueryChecker(llm.NewSimpleFake("DROP TABLE orders"), db)
+		require.NoError(t, err)
+
+		_, err = checker.Run(context.Background(), "SELECT id FROM orders")
+		assert.ErrorContains(t, err, "only read-only SELECT queries are allowed")
+	})
+}
+
+// newTestSQLDB creates an in-memory SQLite database with customers and orders.
+func newTestSQLDB(t *testing.T) *sqldb.SQLDB {
+	t.Helper()
+
+	engine, err := sqldb.NewSQLite3(":memory:")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { engine.Close() })
+
+	_, err = engine.Exec(context.Background(), "CREATE TABLE customers (id int NOT NULL, name text);")
+	require.NoError(t, err)
+
+	_, err = engine.Exec(context.Background(), "CREATE TABLE orders (id int NOT NULL, customer_id int NOT NULL);")
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = engine.Exec(context.Background(), "INSERT INTO customers (id, name) VALUES (?, ?);", i, fmt.Sprintf("customer %d", i))
+		require.NoError(t, err)
+	}
+
+	db, err := sqldb.New(engine)
+	require.NoError(t, err)
+
+	return db
+}
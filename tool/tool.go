@@ -10,6 +10,29 @@ import (
 	"github.com/hupe1980/golc/schema"
 )
 
+// InputValidator is implemented by tools that validate structured inputs before they are unmarshaled.
+type InputValidator interface {
+	// ValidateInput returns an error if the input does not match the arguments of the tool.
+	ValidateInput(input *schema.ToolInput) error
+}
+
+// ArgsSchemaProvider is implemented by tools that provide the JSON schema of their arguments
+// instead of deriving it from their ArgsType, e.g. tools with dynamic arguments.
+type ArgsSchemaProvider interface {
+	// ArgsSchema returns the JSON schema of the arguments.
+	ArgsSchema() *jsonschema.Schema
+}
+
+// runManagerKey is the context key of the callback manager of the current tool run.
+type runManagerKey struct{}
+
+// RunManagerFromContext returns the callback manager of the tool run, if the tool is executed by Run.
+// Tools that call chains or models use it to pass the callbacks and the run ID to the nested runs.
+func RunManagerFromContext(ctx context.Context) (schema.CallbackManagerForToolRun, bool) {
+	rm, ok := ctx.Value(runManagerKey{}).(schema.CallbackManagerForToolRun)
+	return rm, ok
+}
+
 type Options struct {
 	Callbacks   []schema.Callback
 	ParentRunID string
@@ -22,7 +45,9 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 		fn(&opts)
 	}
 
-	cm := callback.NewManager(opts.Callbacks, t.Callbacks(), t.Verbose())
+	cm := callback.NewManager(opts.Callbacks, t.Callbacks(), t.Verbose(), func(mo *callback.ManagerOptions) {
+		mo.ParentRunID = opts.ParentRunID
+	})
 
 	rm, err := cm.OnToolStart(ctx, &schema.ToolStartManagerInput{
 		ToolName: t.Name(),
@@ -32,6 +57,18 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 		return "", err
 	}
 
+	if v, ok := t.(InputValidator); ok {
+		if err := v.ValidateInput(input); err != nil {
+			if cbErr := rm.OnToolError(ctx, &schema.ToolErrorManagerInput{
+				Error: err,
+			}); cbErr != nil {
+				return "", cbErr
+			}
+
+			return "", err
+		}
+	}
+
 	var inputValue any
 
 	if input.Structured() {
@@ -47,7 +84,7 @@ func Run(ctx context.Context, t schema.Tool, input *schema.ToolInput, optFns ...
 		inputValue, _ = input.GetString()
 	}
 
-	output, err := t.Run(ctx, inputValue)
+	output, err := t.Run(context.WithValue(ctx, runManagerKey{}, rm), inputValue)
 	if err != nil {
 		if cbErr := rm.OnToolError(ctx, &schema.ToolErrorManagerInput{
 			Error: err,
@@ -74,6 +111,18 @@ func ToFunction(t schema.Tool) (*schema.FunctionDefinition, error) {
 		Description: t.Description(),
 	}
 
+	if p, ok := t.(ArgsSchemaProvider); ok {
+		argsSchema := p.ArgsSchema()
+
+		function.Parameters = schema.FunctionDefinitionParameters{
+			Type:       "object",
+			Properties: argsSchema.Properties,
+			Required:   argsSchema.Required,
+		}
+
+		return function, nil
+	}
+
 	argsType := t.ArgsType()
 
 	if argsType.Kind() == reflect.String {
@@ -96,9 +145,15 @@ func ToFunction(t schema.Tool) (*schema.FunctionDefinition, error) {
 		return nil, err
 	}
 
+	properties := jsonSchema.Properties
+	if properties == nil {
+		// Tools without arguments must still declare an empty object.
+		properties = map[string]*jsonschema.Schema{}
+	}
+
 	function.Parameters = schema.FunctionDefinitionParameters{
 		Type:       "object",
-		Properties: jsonSchema.Properties,
+		Properties: properties,
 		Required:   jsonSchema.Required,
 	}
 
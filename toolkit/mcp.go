@@ -0,0 +1,62 @@
+package toolkit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// MCP represents a collection of schema.Tool objects that call the tools of a Model Context Protocol (MCP) server.
+type MCP struct {
+	tools   []schema.Tool
+	skipped map[string]error
+}
+
+// NewMCP creates a new MCP object from the given client. It initializes the session of the
+// client, if necessary, and creates a schema.Tool for every tool listed by the server. Tools with
+// an unsupported input schema are skipped, see Skipped.
+func NewMCP(ctx context.Context, client *mcp.Client, optFns ...func(o *tool.MCPOptions)) (*MCP, error) {
+	if _, err := client.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	mcpTools, err := client.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := make([]schema.Tool, 0, len(mcpTools))
+	skipped := map[string]error{}
+
+	for _, t := range mcpTools {
+		mcpTool, err := tool.NewMCP(client, t, optFns...)
+		if err != nil {
+			if errors.Is(err, tool.ErrInvalidInputSchema) {
+				skipped[t.Name] = err
+				continue
+			}
+
+			return nil, err
+		}
+
+		tools = append(tools, mcpTool)
+	}
+
+	return &MCP{
+		tools:   tools,
+		skipped: skipped,
+	}, nil
+}
+
+// Skipped returns the errors of the server tools that were skipped because of their input schema, by tool name.
+func (tk *MCP) Skipped() map[string]error {
+	return tk.skipped
+}
+
+// Tools returns the list of schema.Tool objects associated with the MCP server.
+func (tk *MCP) Tools() []schema.Tool {
+	return tk.tools
+}
@@ -0,0 +1,182 @@
+package toolkit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hupe1980/golc/integration/mcp"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMCP(t *testing.T) {
+	transport, err := mcp.NewStdioTransport(os.Args[0], []string{"-test.run=^TestMCPStubServer$"}, func(o *mcp.StdioTransportOptions) {
+		o.Env = append(os.Environ(), "GOLC_MCP_STUB_SERVER=1")
+	})
+	require.NoError(t, err)
+
+	client := mcp.New(transport)
+
+	defer client.Close()
+
+	toolkit, err := NewMCP(context.Background(), client)
+	require.NoError(t, err)
+
+	tools := toolkit.Tools()
+	require.Len(t, tools, 3)
+
+	assertToolExists(t, tools, "add")
+	assertToolExists(t, tools, "fail")
+	assertToolExists(t, tools, "search")
+
+	require.Len(t, toolkit.Skipped(), 1)
+	assert.ErrorIs(t, toolkit.Skipped()["invalid"], tool.ErrInvalidInputSchema)
+
+	add := tools[0]
+
+	t.Run("Function definition", func(t *testing.T) {
+		f, err := tool.ToFunction(add)
+		require.NoError(t, err)
+		assert.Equal(t, "add", f.Name)
+		assert.Equal(t, "Adds two numbers.", f.Description)
+		assert.Equal(t, "object", f.Parameters.Type)
+		assert.Equal(t, []string{"a", "b"}, f.Parameters.Required)
+		assert.Equal(t, "number", f.Parameters.Properties["a"].Type)
+	})
+
+	t.Run("Schema of newer drafts", func(t *testing.T) {
+		f, err := tool.ToFunction(tools[2])
+		require.NoError(t, err)
+		assert.Equal(t, "string", f.Parameters.Properties["query"].Type)
+		assert.True(t, f.Parameters.Properties["query"].Nullable)
+		assert.Equal(t, 0.0, *f.Parameters.Properties["limit"].Minimum)
+		assert.True(t, *f.Parameters.Properties["limit"].ExclusiveMinimum)
+	})
+
+	t.Run("Structured input", func(t *testing.T) {
+		output, err := tool.Run(context.Background(), add, schema.NewToolInputFromArguments(`{"a": 2, "b": 3}`))
+		require.NoError(t, err)
+		assert.Equal(t, "5", output)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := tool.Run(context.Background(), add, schema.NewToolInputFromArguments(`{"a": 2}`))
+		require.Error(t, err)
+	})
+
+	t.Run("Tool error", func(t *testing.T) {
+		_, err := tool.Run(context.Background(), tools[1], schema.NewToolInputFromArguments(`{"reason": "boom"}`))
+		require.ErrorIs(t, err, mcp.ErrToolExecution)
+		assert.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("Plain input", func(t *testing.T) {
+		output, err := tools[1].Run(context.Background(), "boom")
+		require.ErrorIs(t, err, mcp.ErrToolExecution)
+		assert.Empty(t, output)
+	})
+}
+
+// TestMCPStubServer is not a real test. It serves a stub MCP server over stdio when the test binary is
+// started as server process by TestNewMCP.
+func TestMCPStubServer(t *testing.T) {
+	if os.Getenv("GOLC_MCP_STUB_SERVER") != "1" {
+		t.Skip("stub server process")
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	encoder := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		req := mcp.Message{}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil || !req.IsRequest() {
+			continue
+		}
+
+		result, err := handleStubRequest(&req)
+
+		resp := mcp.Message{JSONRPC: "2.0", ID: req.ID}
+		if err != nil {
+			resp.Error = &mcp.RPCError{Code: -32601, Message: err.Error()}
+		} else {
+			resp.Result, _ = json.Marshal(result)
+		}
+
+		_ = encoder.Encode(resp)
+	}
+
+	os.Exit(0)
+}
+
+// handleStubRequest returns the result of a request to the stub MCP server.
+func handleStubRequest(req *mcp.Message) (any, error) {
+	params := struct {
+		Cursor    string          `json:"cursor"`
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}{}
+
+	_ = json.Unmarshal(req.Params, &params)
+
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": mcp.LatestProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "stub", "version": "0.1.0"},
+		}, nil
+	case "tools/list":
+		// The tools are listed on two pages to exercise the pagination of the client.
+		if params.Cursor == "" {
+			return map[string]any{
+				"tools": []map[string]any{{
+					"name":        "add",
+					"description": "Adds two numbers.",
+					"inputSchema": json.RawMessage(`{"type":"object","properties":{"a":{"type":"number"},"b":{"type":"number"}},"required":["a","b"]}`),
+				}},
+				"nextCursor": "page-2",
+			}, nil
+		}
+
+		return map[string]any{
+			"tools": []map[string]any{{
+				"name":        "fail",
+				"description": "Always fails.",
+				"inputSchema": json.RawMessage(`{"type":"object","properties":{"reason":{"type":"string"}}}`),
+			}, {
+				"name":        "search",
+				"description": "Searches with a schema of a newer draft.",
+				"inputSchema": json.RawMessage(`{"type":"object","properties":{"query":{"type":["string","null"]},"limit":{"type":"integer","exclusiveMinimum":0}}}`),
+			}, {
+				"name":        "invalid",
+				"description": "Has an unsupported schema.",
+				"inputSchema": json.RawMessage(`{"type":"object","properties":{"query":{"type":"string","required":true}}}`),
+			}},
+		}, nil
+	case "tools/call":
+		args := map[string]any{}
+		_ = json.Unmarshal(params.Arguments, &args)
+
+		if params.Name == "add" {
+			a, _ := args["a"].(float64)
+			b, _ := args["b"].(float64)
+
+			return map[string]any{
+				"content": []map[string]any{{"type": "text", "text": fmt.Sprintf("%g", a+b)}},
+			}, nil
+		}
+
+		return map[string]any{
+			"content": []map[string]any{{"type": "text", "text": fmt.Sprintf("failed: %v", args["reason"])}},
+			"isError": true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("method not found: %s", req.Method)
+	}
+}
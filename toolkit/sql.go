@@ -0,0 +1,53 @@
+package toolkit
+
+import (
+	"github.com/hupe1980/golc/integration/sqldb"
+	"github.com/hupe1980/golc/schema"
+	"github.com/hupe1980/golc/tool"
+)
+
+// SQLOptions contains options for configuring the SQL toolkit.
+type SQLOptions struct {
+	// RowLimit is the maximum number of rows returned by the execute_query tool.
+	RowLimit uint
+}
+
+// SQL represents a collection of schema.Tool objects that enable interaction with an SQL database.
+type SQL struct {
+	tools []schema.Tool
+}
+
+// NewSQL creates a new SQL object from the given model and database. The model is used by the
+// query_checker tool to double check queries before they are executed.
+func NewSQL(model schema.Model, db *sqldb.SQLDB, optFns ...func(o *SQLOptions)) (*SQL, error) {
+	opts := SQLOptions{
+		RowLimit: 10,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	queryChecker, err := tool.NewSQLQueryChecker(model, db)
+	if err != nil {
+		return nil, err
+	}
+
+	tools := []schema.Tool{
+		tool.NewListSQLTables(db),
+		tool.NewDescribeSQLTables(db),
+		queryChecker,
+		tool.NewQuerySQL(db, func(o *tool.QuerySQLOptions) {
+			o.RowLimit = opts.RowLimit
+		}),
+	}
+
+	return &SQL{
+		tools: tools,
+	}, nil
+}
+
+// Tools returns the list of schema.Tool objects associated with the SQL database.
+func (tk *SQL) Tools() []schema.Tool {
+	return tk.tools
+}
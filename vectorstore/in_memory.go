@@ -4,18 +4,23 @@ import (
 	"container/heap"
 	"context"
 	"encoding/gob"
+	"fmt"
 	"io"
 
-	"github.com/hupe1980/golc/internal/util"
+	"github.com/google/uuid"
 	"github.com/hupe1980/golc/metric"
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure InMemory satisfies the VectorStore interface.
-var _ schema.VectorStore = (*InMemory)(nil)
+// Compile time check to ensure InMemory satisfies the VectorStore interfaces.
+var (
+	_ schema.VectorStoreSearcher = (*InMemory)(nil)
+	_ schema.VectorStoreWriter   = (*InMemory)(nil)
+)
 
-// InMemoryItem represents an item stored in memory with its content, vector, and metadata.
+// InMemoryItem represents an item stored in memory with its ID, content, vector, and metadata.
 type InMemoryItem struct {
+	ID       string         `json:"id"`
 	Content  string         `json:"content"`
 	Vector   []float32      `json:"vector"`
 	Metadata map[string]any `json:"metadata"`
@@ -72,10 +77,14 @@ func (pq *priorityQueue) Top() any {
 // DistanceFunc represents a function for calculating the distance between two vectors
 type DistanceFunc func(v1, v2 []float32) (float32, error)
 
+// RelevanceScoreFunc represents a function for converting a distance into a relevance score, where higher scores are more relevant.
+type RelevanceScoreFunc func(distance float32) float32
+
 // InMemoryOptions represents options for the in-memory vector store.
 type InMemoryOptions struct {
-	TopK         int
-	DistanceFunc DistanceFunc
+	TopK               int
+	DistanceFunc       DistanceFunc
+	RelevanceScoreFunc RelevanceScoreFunc
 }
 
 // InMemory represents an in-memory vector store.
@@ -90,6 +99,9 @@ func NewInMemory(embedder schema.Embedder, optFns ...func(*InMemoryOptions)) *In
 	opts := InMemoryOptions{
 		TopK:         3,
 		DistanceFunc: metric.SquaredL2,
+		RelevanceScoreFunc: func(distance float32) float32 {
+			return 1 / (1 + distance)
+		},
 	}
 
 	for _, fn := range optFns {
@@ -105,6 +117,34 @@ func NewInMemory(embedder schema.Embedder, optFns ...func(*InMemoryOptions)) *In
 
 // AddDocuments adds a batch of documents to the InMemory vector store.
 func (vs *InMemory) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	_, err := vs.Add(ctx, docs)
+	return err
+}
+
+// Add adds the documents to the InMemory vector store and returns their generated IDs.
+func (vs *InMemory) Add(ctx context.Context, docs []schema.Document) ([]string, error) {
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+
+	if err := vs.Upsert(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert inserts the documents with the given IDs or replaces the existing documents with these IDs.
+func (vs *InMemory) Upsert(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return fmt.Errorf("number of ids (%d) does not match number of documents (%d)", len(ids), len(docs))
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -115,17 +155,53 @@ func (vs *InMemory) AddDocuments(ctx context.Context, docs []schema.Document) er
 		return err
 	}
 
+	index := make(map[string]int, len(vs.data))
+	for i, item := range vs.data {
+		if item.ID != "" {
+			index[item.ID] = i
+		}
+	}
+
 	for i, doc := range docs {
-		vs.data = append(vs.data, InMemoryItem{
+		item := InMemoryItem{
+			ID:       ids[i],
 			Content:  doc.PageContent,
 			Vector:   vectors[i],
 			Metadata: doc.Metadata,
-		})
+		}
+
+		if j, ok := index[item.ID]; ok {
+			vs.data[j] = item
+			continue
+		}
+
+		index[item.ID] = len(vs.data)
+		vs.data = append(vs.data, item)
 	}
 
 	return nil
 }
 
+// Delete deletes the documents with the given IDs from the InMemory vector store.
+func (vs *InMemory) Delete(ctx context.Context, ids []string) error {
+	deleted := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		deleted[id] = struct{}{}
+	}
+
+	data := make([]InMemoryItem, 0, len(vs.data))
+
+	for _, item := range vs.data {
+		if _, ok := deleted[item.ID]; !ok {
+			data = append(data, item)
+		}
+	}
+
+	vs.data = data
+
+	return nil
+}
+
 // AddItem adds a single item to the InMemory vector store.
 func (vs *InMemory) AddItem(item InMemoryItem) {
 	vs.data = append(vs.data, item)
@@ -138,31 +214,49 @@ func (vs *InMemory) Data() []InMemoryItem {
 
 // SimilaritySearch performs a similarity search with the given query in the InMemory vector store.
 func (vs *InMemory) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs, err := vs.SimilaritySearchWithScore(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocuments(docs), nil
+}
+
+// SimilaritySearchWithScore performs a similarity search with the given query and returns the documents with their relevance scores.
+func (vs *InMemory) SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
 	queryVector, err := vs.embedder.EmbedText(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	topCandidates := &priorityQueue{}
-	heap.Init(topCandidates)
+	return vs.SimilaritySearchByVector(ctx, queryVector, optFns...)
+}
 
-	type searchResult struct {
-		Item       InMemoryItem
-		Similarity float32
+// SimilaritySearchByVector performs a similarity search with the given embedding vector and returns the documents with their relevance scores.
+// Filters are evaluated on the metadata of the stored items.
+func (vs *InMemory) SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	opts, err := newSearchOptions(vs.opts.TopK, optFns...)
+	if err != nil {
+		return nil, err
 	}
 
-	results := make([]searchResult, len(vs.data))
+	topCandidates := &priorityQueue{}
+	heap.Init(topCandidates)
 
 	for _, item := range vs.data {
-		similarity, err := vs.opts.DistanceFunc(queryVector, item.Vector)
+		if opts.Filter != nil && !opts.Filter.Match(item.Metadata) {
+			continue
+		}
+
+		distance, err := vs.opts.DistanceFunc(vector, item.Vector)
 		if err != nil {
 			return nil, err
 		}
 
-		if topCandidates.Len() < vs.opts.TopK {
+		if topCandidates.Len() < opts.K {
 			heap.Push(topCandidates, &priorityQueueItem{
 				Data:     item,
-				Distance: similarity,
+				Distance: distance,
 			})
 
 			continue
@@ -170,30 +264,36 @@ func (vs *InMemory) SimilaritySearch(ctx context.Context, query string) ([]schem
 
 		largestDist, _ := topCandidates.Top().(*priorityQueueItem)
 
-		if similarity < largestDist.Distance {
+		if distance < largestDist.Distance {
 			_ = heap.Pop(topCandidates)
 
 			heap.Push(topCandidates, &priorityQueueItem{
 				Data:     item,
-				Distance: similarity,
+				Distance: distance,
 			})
 		}
 	}
 
-	docLen := util.Min(len(results), vs.opts.TopK)
-
 	// Extract documents from sorted results
-	documents := make([]schema.Document, docLen)
+	documents := make([]schema.ScoredDocument, topCandidates.Len())
 
 	for i := topCandidates.Len() - 1; i >= 0; i-- {
 		item, _ := heap.Pop(topCandidates).(*priorityQueueItem)
-		documents[i] = schema.Document{
-			PageContent: item.Data.Content,
-			Metadata:    item.Data.Metadata,
+		documents[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: item.Data.Content,
+				Metadata:    item.Data.Metadata,
+			},
+			ID:    item.Data.ID,
+			Score: vs.opts.RelevanceScoreFunc(item.Distance),
+		}
+
+		if opts.IncludeVectors {
+			documents[i].Vector = item.Data.Vector
 		}
 	}
 
-	return documents, nil
+	return filterByScore(documents, opts.ScoreThreshold), nil
 }
 
 func (vs *InMemory) Load(r io.Reader) error {
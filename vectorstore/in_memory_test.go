@@ -55,6 +55,74 @@ func TestInMemory(t *testing.T) {
 		}
 	})
 
+	t.Run("SimilaritySearchWithScore", func(t *testing.T) {
+		vs := NewInMemory(&keywordEmbedder{}, func(o *InMemoryOptions) {
+			o.TopK = 2
+		})
+
+		ids, err := vs.Add(context.Background(), []schema.Document{
+			{PageContent: "apple", Metadata: map[string]any{"kind": "fruit", "price": 2}},
+			{PageContent: "banana", Metadata: map[string]any{"kind": "fruit", "price": 1}},
+			{PageContent: "carrot", Metadata: map[string]any{"kind": "vegetable", "price": 1}},
+		})
+		require.NoError(t, err)
+		require.Len(t, ids, 3)
+
+		docs, err := vs.SimilaritySearchWithScore(context.Background(), "apple")
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, ids[0], docs[0].ID)
+		assert.Equal(t, float32(1), docs[0].Score)
+		assert.Greater(t, docs[0].Score, docs[1].Score)
+
+		docs, err = vs.SimilaritySearchWithScore(context.Background(), "apple", func(o *schema.VectorStoreSearchOptions) {
+			o.K = 3
+			o.Filter = schema.FilterLte("price", 1)
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "banana", docs[0].PageContent)
+		assert.Equal(t, "carrot", docs[1].PageContent)
+
+		docs, err = vs.SimilaritySearchByVector(context.Background(), []float32{1, 0.1, 0}, func(o *schema.VectorStoreSearchOptions) {
+			o.ScoreThreshold = 0.9
+			o.IncludeVectors = true
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, "apple", docs[0].PageContent)
+		assert.Equal(t, []float32{1, 0.1, 0}, docs[0].Vector)
+
+		_, err = vs.SimilaritySearchWithScore(context.Background(), "apple", func(o *schema.VectorStoreSearchOptions) {
+			o.Filter = schema.FilterGt("kind", "fruit")
+		})
+		assert.ErrorIs(t, err, schema.ErrInvalidFilter)
+	})
+
+	t.Run("UpsertAndDelete", func(t *testing.T) {
+		vs := NewInMemory(&keywordEmbedder{})
+
+		ids, err := vs.Add(context.Background(), []schema.Document{{PageContent: "apple"}, {PageContent: "banana"}})
+		require.NoError(t, err)
+
+		err = vs.Upsert(context.Background(), []string{ids[0], "custom"}, []schema.Document{{PageContent: "avocado"}, {PageContent: "cherry"}})
+		require.NoError(t, err)
+		require.Len(t, vs.Data(), 3)
+		assert.Equal(t, "avocado", vs.Data()[0].Content)
+		assert.Equal(t, "custom", vs.Data()[2].ID)
+
+		require.NoError(t, vs.Delete(context.Background(), []string{ids[1], "unknown"}))
+
+		docs, err := vs.SimilaritySearch(context.Background(), "banana")
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.Equal(t, "avocado", docs[0].PageContent)
+		assert.Equal(t, "cherry", docs[1].PageContent)
+
+		err = vs.Upsert(context.Background(), []string{"id"}, nil)
+		assert.Error(t, err)
+	})
+
 	t.Run("SaveAndLoad", func(t *testing.T) {
 		originalData := []InMemoryItem{
 			{Content: "item1", Vector: []float32{1.0, 2.0, 3.0}, Metadata: map[string]any{"key1": "value1"}},
@@ -1,98 +1,731 @@
 package vectorstore
 
-// import (
-// 	"context"
-// 	"database/sql"
-
-// 	"github.com/hupe1980/golc/schema"
-// 	pgv "github.com/pgvector/pgvector-go"
-// )
-
-// // Compile time check to ensure PGVector satisfies the VectorStore interface.
-// var _ schema.VectorStore = (*PGVector)(nil)
-
-// type PGVectorOptions struct {
-// 	DriverName string
-// }
-
-// type PGVector struct {
-// 	db       *sql.DB
-// 	embedder schema.Embedder
-// 	opts     PGVectorOptions
-// }
-
-// func NewPGVector(dataSourceName string, embedder schema.Embedder, optFns ...func(*PGVectorOptions)) (*PGVector, error) {
-// 	opts := PGVectorOptions{
-// 		DriverName: "pgx",
-// 	}
-
-// 	for _, fn := range optFns {
-// 		fn(&opts)
-// 	}
-
-// 	db, err := sql.Open(opts.DriverName, dataSourceName)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	return &PGVector{
-// 		db:       db,
-// 		embedder: embedder,
-// 		opts:     opts,
-// 	}, nil
-// }
-
-// func (vs *PGVector) AddDocuments(ctx context.Context, docs []schema.Document) error {
-// 	texts := make([]string, len(docs))
-// 	for i, doc := range docs {
-// 		texts[i] = doc.PageContent
-// 	}
-
-// 	vectors, err := vs.embedder.EmbedDocuments(ctx, texts)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	for i, doc := range docs {
-// 		data := map[string]any{}
-// 		data[store.embeddingStoreColumnName] = pgv.NewVector(float64ToFloat32(vectors[i]))
-// 		data[store.textColumnName] = doc.PageContent
-
-// 		if _, err := vs.db.ExecContext(ctx, ""); err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// func (vs *PGVector) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
-// 	return nil, nil
-// }
-
-// func (vs *PGVector) CreateVectorExtensionIfNotExists(ctx context.Context) error {
-// 	tx, err := vs.db.BeginTx(ctx, nil)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	// Inspired by https://github.com/langchain-ai/langchain/blob/v0.0.340/libs/langchain/langchain/vectorstores/pgvector.py#L167
-// 	// The advisor lock fixes issue arising from concurrent
-// 	// creation of the vector extension.
-// 	// https://github.com/langchain-ai/langchain/issues/12933
-// 	// For more information see:
-// 	// https://www.postgresql.org/docs/16/explicit-locking.html#ADVISORY-LOCKS
-// 	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(1573678846307946495)"); err != nil {
-// 		return err
-// 	}
-
-// 	if _, err := tx.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS vector"); err != nil {
-// 		return err
-// 	}
-
-// 	return tx.Commit()
-// }
-
-// func (vs *PGVector) Close() error {
-// 	return vs.db.Close()
-// }
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/hupe1980/golc/schema"
+)
+
+// Compile time check to ensure PGVector satisfies the VectorStore interfaces.
+var (
+	_ schema.VectorStoreSearcher = (*PGVector)(nil)
+	_ schema.VectorStoreWriter   = (*PGVector)(nil)
+)
+
+// PGVectorDistance is the distance function used to compare vectors.
+type PGVectorDistance string
+
+const (
+	// PGVectorDistanceCosine uses the cosine distance.
+	PGVectorDistanceCosine PGVectorDistance = "cosine"
+	// PGVectorDistanceL2 uses the euclidean distance.
+	PGVectorDistanceL2 PGVectorDistance = "l2"
+	// PGVectorDistanceInnerProduct uses the negative inner product.
+	PGVectorDistanceInnerProduct PGVectorDistance = "inner_product"
+)
+
+// PGVectorIndex is the type of the approximate nearest neighbor index.
+type PGVectorIndex string
+
+const (
+	// PGVectorIndexHNSW creates a HNSW index.
+	PGVectorIndexHNSW PGVectorIndex = "hnsw"
+	// PGVectorIndexIVFFlat creates an IVFFlat index. The lists of the index are computed from the
+	// rows at index creation, so it is not created with the table, but by calling CreateIndex after
+	// the table has been filled.
+	PGVectorIndexIVFFlat PGVectorIndex = "ivfflat"
+	// PGVectorIndexNone uses exact nearest neighbor search.
+	PGVectorIndexNone PGVectorIndex = "none"
+)
+
+// pgVectorIdentifier matches valid table names, optionally qualified by a schema.
+var pgVectorIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// PGVectorOptions contains options for configuring the PGVector vector store.
+type PGVectorOptions struct {
+	// DriverName is the name of the database/sql driver. It defaults to pgx. The driver must be
+	// registered by the caller, e.g. by importing github.com/jackc/pgx/v5/stdlib.
+	DriverName string
+
+	// TableName is the name of the table to store the documents.
+	TableName string
+
+	// CollectionName is the name of the collection of the documents. Multiple collections can share a table.
+	CollectionName string
+
+	// Dimensions is the number of dimensions of the embeddings. If it is zero, it is determined by the first embedding.
+	Dimensions int
+
+	// Distance is the distance function used for similarity search.
+	Distance PGVectorDistance
+
+	// Index is the type of the index created for the embeddings.
+	Index PGVectorIndex
+
+	// HNSWM is the maximum number of connections per layer of the HNSW index.
+	HNSWM int
+
+	// HNSWEfConstruction is the size of the dynamic candidate list for constructing the HNSW index.
+	HNSWEfConstruction int
+
+	// HNSWEfSearch is the size of the dynamic candidate list for search. The default of the server is used if it is zero.
+	HNSWEfSearch int
+
+	// IVFFlatLists is the number of lists of the IVFFlat index.
+	IVFFlatLists int
+
+	// IVFFlatProbes is the number of lists probed during search. The default of the server is used if it is zero.
+	IVFFlatProbes int
+
+	// TopK is the number of documents to retrieve in similarity search.
+	TopK int
+
+	// BatchSize is the number of documents embedded and inserted at once.
+	BatchSize int
+}
+
+// PGVector represents a vector store backed by Postgres with the pgvector extension. Documents are
+// stored with their content, embedding and metadata as JSONB in a table that is created on the
+// first write. Searches never modify the schema and return no documents while the table is missing.
+type PGVector struct {
+	db       *sql.DB
+	embedder schema.Embedder
+	mu       sync.Mutex
+	created  bool
+	opts     PGVectorOptions
+}
+
+// NewPGVector creates a new PGVector vector store for the given data source.
+func NewPGVector(dataSourceName string, embedder schema.Embedder, optFns ...func(*PGVectorOptions)) (*PGVector, error) {
+	opts, err := newPGVectorOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(opts.DriverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PGVector{
+		db:       db,
+		embedder: embedder,
+		opts:     opts,
+	}, nil
+}
+
+// NewPGVectorFromDB creates a new PGVector vector store using an existing database connection.
+func NewPGVectorFromDB(db *sql.DB, embedder schema.Embedder, optFns ...func(*PGVectorOptions)) (*PGVector, error) {
+	opts, err := newPGVectorOptions(optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PGVector{
+		db:       db,
+		embedder: embedder,
+		opts:     opts,
+	}, nil
+}
+
+// newPGVectorOptions returns the validated options of the PGVector vector store.
+func newPGVectorOptions(optFns ...func(*PGVectorOptions)) (PGVectorOptions, error) {
+	opts := PGVectorOptions{
+		DriverName:         "pgx",
+		TableName:          "golc_embeddings",
+		CollectionName:     "default",
+		Distance:           PGVectorDistanceCosine,
+		Index:              PGVectorIndexHNSW,
+		HNSWM:              16,
+		HNSWEfConstruction: 64,
+		IVFFlatLists:       100,
+		TopK:               4,
+		BatchSize:          100,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if !pgVectorIdentifier.MatchString(opts.TableName) {
+		return opts, fmt.Errorf("invalid table name: %s", opts.TableName)
+	}
+
+	if _, err := pgVectorOperator(opts.Distance); err != nil {
+		return opts, err
+	}
+
+	if opts.Index != PGVectorIndexHNSW && opts.Index != PGVectorIndexIVFFlat && opts.Index != PGVectorIndexNone {
+		return opts, fmt.Errorf("unsupported index type: %s", opts.Index)
+	}
+
+	if opts.BatchSize <= 0 {
+		return opts, fmt.Errorf("invalid batch size: %d", opts.BatchSize)
+	}
+
+	return opts, nil
+}
+
+// CreateTableIfNotExists creates the vector extension, the table and the indexes, if they do not exist.
+// It is called on the first write with the dimensions of the first embedding. An IVFFlat index is not created,
+// see CreateIndex.
+func (vs *PGVector) CreateTableIfNotExists(ctx context.Context, dimensions int) error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if vs.created {
+		return nil
+	}
+
+	if vs.opts.Dimensions > 0 {
+		dimensions = vs.opts.Dimensions
+	}
+
+	if dimensions <= 0 {
+		return fmt.Errorf("invalid dimensions: %d", dimensions)
+	}
+
+	tx, err := vs.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	// Inspired by https://github.com/langchain-ai/langchain/blob/v0.0.340/libs/langchain/langchain/vectorstores/pgvector.py#L167
+	// The advisor lock fixes issue arising from concurrent
+	// creation of the vector extension.
+	// https://github.com/langchain-ai/langchain/issues/12933
+	// For more information see:
+	// https://www.postgresql.org/docs/16/explicit-locking.html#ADVISORY-LOCKS
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(1573678846307946495)"); err != nil {
+		return err
+	}
+
+	for _, stmt := range vs.createStatements(dimensions) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	vs.created = true
+
+	return nil
+}
+
+// CreateIndex creates the index of the embeddings, if it does not exist. It is required for an IVFFlat
+// index, which should be created after the table has been filled, and a no-op for the other indexes.
+func (vs *PGVector) CreateIndex(ctx context.Context) error {
+	stmt := vs.indexStatement()
+	if stmt == "" {
+		return nil
+	}
+
+	_, err := vs.db.ExecContext(ctx, stmt)
+
+	return err
+}
+
+// AddDocuments adds a batch of documents to the PGVector vector store.
+func (vs *PGVector) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	_, err := vs.Add(ctx, docs)
+	return err
+}
+
+// Add adds the documents to the PGVector vector store and returns their generated IDs.
+func (vs *PGVector) Add(ctx context.Context, docs []schema.Document) ([]string, error) {
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+
+	if err := vs.Upsert(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert inserts the documents with the given IDs or updates the existing documents with these IDs.
+// The documents are embedded and written in batches within a single transaction.
+func (vs *PGVector) Upsert(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return fmt.Errorf("number of ids (%d) does not match number of documents (%d)", len(ids), len(docs))
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var tx *sql.Tx
+
+	defer func() {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for start := 0; start < len(docs); start += vs.opts.BatchSize {
+		end := min(start+vs.opts.BatchSize, len(docs))
+
+		texts := make([]string, end-start)
+		for i, doc := range docs[start:end] {
+			texts[i] = doc.PageContent
+		}
+
+		vectors, err := vs.embedder.BatchEmbedText(ctx, texts)
+		if err != nil {
+			return err
+		}
+
+		if len(vectors) != len(texts) {
+			return fmt.Errorf("embedder returned %d vectors for %d documents", len(vectors), len(texts))
+		}
+
+		if tx == nil {
+			if err := vs.CreateTableIfNotExists(ctx, len(vectors[0])); err != nil {
+				return err
+			}
+
+			tx, err = vs.db.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		query, args, err := vs.upsertStatement(ids[start:end], docs[start:end], vectors)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	err := tx.Commit()
+	tx = nil
+
+	return err
+}
+
+// Delete deletes the documents with the given IDs from the collection.
+func (vs *PGVector) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, vs.opts.CollectionName)
+
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+2)
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE collection = $1 AND id IN (%s)", vs.opts.TableName, strings.Join(placeholders, ", "))
+
+	_, err := vs.db.ExecContext(ctx, query, args...)
+	if isUndefinedTable(err) {
+		return nil
+	}
+
+	return err
+}
+
+// DeleteCollection deletes all documents of the collection.
+func (vs *PGVector) DeleteCollection(ctx context.Context) error {
+	_, err := vs.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE collection = $1", vs.opts.TableName), vs.opts.CollectionName)
+	if isUndefinedTable(err) {
+		return nil
+	}
+
+	return err
+}
+
+// SimilaritySearch performs a similarity search with the given query in the PGVector vector store.
+func (vs *PGVector) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs, err := vs.SimilaritySearchWithScore(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocuments(docs), nil
+}
+
+// SimilaritySearchWithFilter performs a similarity search with the given query and returns only
+// documents whose metadata contains the given filter, e.g. {"source": "wiki"}.
+func (vs *PGVector) SimilaritySearchWithFilter(ctx context.Context, query string, filter map[string]any) ([]schema.Document, error) {
+	docs, err := vs.SimilaritySearchWithScore(ctx, query, func(o *schema.VectorStoreSearchOptions) {
+		if len(filter) == 0 {
+			return
+		}
+
+		keys := make([]string, 0, len(filter))
+		for key := range filter {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		operands := make([]*schema.Filter, len(keys))
+		for i, key := range keys {
+			operands[i] = schema.FilterEq(key, filter[key])
+		}
+
+		o.Filter = schema.FilterAnd(operands...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocuments(docs), nil
+}
+
+// SimilaritySearchWithScore performs a similarity search with the given query and returns the documents with their relevance scores.
+func (vs *PGVector) SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	vector, err := vs.embedder.EmbedText(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs.SimilaritySearchByVector(ctx, vector, optFns...)
+}
+
+// SimilaritySearchByVector performs a similarity search with the given embedding vector and returns the documents with their relevance scores.
+// The score is the cosine similarity for the cosine distance, the inner product for the inner product distance and 1 / (1 + distance)
+// for the euclidean distance. Filters are translated to a SQL/JSON path predicate on the metadata.
+func (vs *PGVector) SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	opts, err := newSearchOptions(vs.opts.TopK, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery, args, err := vs.searchStatement(vector, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := vs.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	if vs.opts.Index == PGVectorIndexHNSW && vs.opts.HNSWEfSearch > 0 {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL hnsw.ef_search = %d", vs.opts.HNSWEfSearch)); err != nil {
+			return nil, err
+		}
+	}
+
+	if vs.opts.Index == PGVectorIndexIVFFlat && vs.opts.IVFFlatProbes > 0 {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL ivfflat.probes = %d", vs.opts.IVFFlatProbes)); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		// Nothing has been written yet.
+		if isUndefinedTable(err) {
+			return []schema.ScoredDocument{}, nil
+		}
+
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	docs := make([]schema.ScoredDocument, 0, opts.K)
+
+	for rows.Next() {
+		var (
+			id        string
+			content   string
+			metadata  []byte
+			distance  float64
+			embedding string
+		)
+
+		dest := []any{&id, &content, &metadata, &distance}
+		if opts.IncludeVectors {
+			dest = append(dest, &embedding)
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		doc := schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: content,
+			},
+			ID:    id,
+			Score: vs.score(distance),
+		}
+
+		if err := json.Unmarshal(metadata, &doc.Metadata); err != nil {
+			return nil, err
+		}
+
+		// The text representation of a vector, e.g. [1,2,3], is a valid JSON array.
+		if opts.IncludeVectors {
+			if err := json.Unmarshal([]byte(embedding), &doc.Vector); err != nil {
+				return nil, err
+			}
+		}
+
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return filterByScore(docs, opts.ScoreThreshold), nil
+}
+
+// Close closes the database connection.
+func (vs *PGVector) Close() error {
+	return vs.db.Close()
+}
+
+// createStatements returns the statements to create the extension, the table and the indexes,
+// except for an IVFFlat index.
+func (vs *PGVector) createStatements(dimensions int) []string {
+	table := vs.opts.TableName
+	indexPrefix := strings.ReplaceAll(table, ".", "_")
+
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS vector",
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	collection text NOT NULL,
+	id text NOT NULL,
+	content text NOT NULL,
+	metadata jsonb NOT NULL DEFAULT '{}',
+	embedding vector(%d) NOT NULL,
+	PRIMARY KEY (collection, id)
+)`, table, dimensions),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_metadata_idx ON %s USING gin (metadata jsonb_path_ops)", indexPrefix, table),
+	}
+
+	if vs.opts.Index == PGVectorIndexHNSW {
+		stmts = append(stmts, vs.indexStatement())
+	}
+
+	return stmts
+}
+
+// indexStatement returns the statement to create the index of the embeddings or an empty string without index.
+func (vs *PGVector) indexStatement() string {
+	table := vs.opts.TableName
+	indexPrefix := strings.ReplaceAll(table, ".", "_")
+
+	opclass := map[PGVectorDistance]string{
+		PGVectorDistanceCosine:       "vector_cosine_ops",
+		PGVectorDistanceL2:           "vector_l2_ops",
+		PGVectorDistanceInnerProduct: "vector_ip_ops",
+	}[vs.opts.Distance]
+
+	switch vs.opts.Index {
+	case PGVectorIndexHNSW:
+		return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_embedding_%s_hnsw_idx ON %s USING hnsw (embedding %s) WITH (m = %d, ef_construction = %d)",
+			indexPrefix, vs.opts.Distance, table, opclass, vs.opts.HNSWM, vs.opts.HNSWEfConstruction)
+	case PGVectorIndexIVFFlat:
+		return fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_embedding_%s_ivfflat_idx ON %s USING ivfflat (embedding %s) WITH (lists = %d)",
+			indexPrefix, vs.opts.Distance, table, opclass, vs.opts.IVFFlatLists)
+	default:
+		return ""
+	}
+}
+
+// upsertStatement returns a multi-row upsert statement for the documents and their vectors.
+func (vs *PGVector) upsertStatement(ids []string, docs []schema.Document, vectors [][]float32) (string, []any, error) {
+	values := make([]string, len(docs))
+	args := make([]any, 0, len(docs)*5)
+
+	for i, doc := range docs {
+		metadata := doc.Metadata
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+
+		rawMetadata, err := json.Marshal(metadata)
+		if err != nil {
+			return "", nil, err
+		}
+
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d::jsonb, $%d::vector)", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, vs.opts.CollectionName, ids[i], doc.PageContent, string(rawMetadata), pgVectorString(vectors[i]))
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (collection, id, content, metadata, embedding) VALUES %s
+ON CONFLICT (collection, id) DO UPDATE SET content = EXCLUDED.content, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding`,
+		vs.opts.TableName, strings.Join(values, ", "))
+
+	return query, args, nil
+}
+
+// searchStatement returns the nearest neighbor query for the vector and the search options.
+func (vs *PGVector) searchStatement(vector []float32, opts schema.VectorStoreSearchOptions) (string, []any, error) {
+	operator, err := pgVectorOperator(vs.opts.Distance)
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := []any{pgVectorString(vector), vs.opts.CollectionName}
+	where := "collection = $2"
+
+	if opts.Filter != nil {
+		predicate, err := pgVectorJSONPath(opts.Filter)
+		if err != nil {
+			return "", nil, err
+		}
+
+		args = append(args, predicate)
+		where += " AND metadata @@ $3::jsonpath"
+	}
+
+	args = append(args, opts.K)
+
+	columns := fmt.Sprintf("id, content, metadata, embedding %s $1::vector AS distance", operator)
+	if opts.IncludeVectors {
+		columns += ", embedding::text"
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY distance LIMIT $%d", columns, vs.opts.TableName, where, len(args))
+
+	return query, args, nil
+}
+
+// score returns the relevance score of the distance.
+func (vs *PGVector) score(distance float64) float32 {
+	switch vs.opts.Distance {
+	case PGVectorDistanceCosine:
+		return float32(1 - distance)
+	case PGVectorDistanceInnerProduct:
+		// The <#> operator returns the negative inner product.
+		return float32(-distance)
+	default:
+		return float32(1 / (1 + distance))
+	}
+}
+
+// pgVectorJSONPath translates the filter to a SQL/JSON path predicate, e.g. $."kind" == "fruit".
+// Negations use exists, so that documents with missing keys or values of another type match them.
+func pgVectorJSONPath(filter *schema.Filter) (string, error) {
+	switch filter.Operator {
+	case schema.FilterOperatorAnd, schema.FilterOperatorOr:
+		operands := make([]string, len(filter.Filters))
+
+		for i, operand := range filter.Filters {
+			predicate, err := pgVectorJSONPath(operand)
+			if err != nil {
+				return "", err
+			}
+
+			operands[i] = predicate
+		}
+
+		separator := " && "
+		if filter.Operator == schema.FilterOperatorOr {
+			separator = " || "
+		}
+
+		return "(" + strings.Join(operands, separator) + ")", nil
+	}
+
+	key, err := json.Marshal(filter.Key)
+	if err != nil {
+		return "", err
+	}
+
+	path := "$." + string(key)
+
+	values := []any{filter.Value}
+	if filter.Operator == schema.FilterOperatorIn || filter.Operator == schema.FilterOperatorNin {
+		values = filter.Values()
+	}
+
+	literals := make([]string, len(values))
+
+	for i, v := range values {
+		literal, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+
+		literals[i] = string(literal)
+	}
+
+	comparisons := func(operand string) string {
+		parts := make([]string, len(literals))
+		for i, literal := range literals {
+			parts[i] = operand + " == " + literal
+		}
+
+		return strings.Join(parts, " || ")
+	}
+
+	switch filter.Operator {
+	case schema.FilterOperatorEq, schema.FilterOperatorIn:
+		return "(" + comparisons(path) + ")", nil
+	case schema.FilterOperatorNe, schema.FilterOperatorNin:
+		return fmt.Sprintf("!exists(%s ? (%s))", path, comparisons("@")), nil
+	case schema.FilterOperatorGt:
+		return fmt.Sprintf("%s > %s", path, literals[0]), nil
+	case schema.FilterOperatorGte:
+		return fmt.Sprintf("%s >= %s", path, literals[0]), nil
+	case schema.FilterOperatorLt:
+		return fmt.Sprintf("%s < %s", path, literals[0]), nil
+	case schema.FilterOperatorLte:
+		return fmt.Sprintf("%s <= %s", path, literals[0]), nil
+	default:
+		return "", fmt.Errorf("%w: unsupported operator %q", schema.ErrInvalidFilter, filter.Operator)
+	}
+}
+
+// pgVectorOperator returns the pgvector operator of the distance.
+func pgVectorOperator(distance PGVectorDistance) (string, error) {
+	switch distance {
+	case PGVectorDistanceCosine:
+		return "<=>", nil
+	case PGVectorDistanceL2:
+		return "<->", nil
+	case PGVectorDistanceInnerProduct:
+		return "<#>", nil
+	default:
+		return "", fmt.Errorf("unsupported distance: %s", distance)
+	}
+}
+
+// pgVectorString returns the text representation of the vector, e.g. [1,2,3].
+func pgVectorString(vector []float32) string {
+	parts := make([]string, len(vector))
+	for i, v := range vector {
+		parts[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// isUndefinedTable reports whether the error is caused by a missing table (SQLSTATE 42P01).
+func isUndefinedTable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "42P01")
+}
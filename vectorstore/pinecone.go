@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/hupe1980/golc/integration/pinecone"
 	"github.com/hupe1980/golc/schema"
 )
 
-// Compile time check to ensure Pinecone satisfies the VectorStore interface.
-var _ schema.VectorStore = (*Pinecone)(nil)
+// Compile time check to ensure Pinecone satisfies the VectorStore interfaces.
+var (
+	_ schema.VectorStoreSearcher = (*Pinecone)(nil)
+	_ schema.VectorStoreWriter   = (*Pinecone)(nil)
+)
 
 type PineconeOptions struct {
 	Namespace string
@@ -40,7 +44,36 @@ func NewPinecone(client pinecone.Client, embedder schema.Embedder, textKey strin
 	}, nil
 }
 
+// AddDocuments adds a batch of documents to the Pinecone vector store.
 func (vs *Pinecone) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	_, err := vs.Add(ctx, docs)
+	return err
+}
+
+// Add adds the documents to the Pinecone vector store and returns their generated IDs.
+func (vs *Pinecone) Add(ctx context.Context, docs []schema.Document) ([]string, error) {
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+
+	if err := vs.Upsert(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert inserts the documents with the given IDs or replaces the existing documents with these IDs.
+func (vs *Pinecone) Upsert(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return fmt.Errorf("number of ids (%d) does not match number of documents (%d)", len(ids), len(docs))
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -51,7 +84,7 @@ func (vs *Pinecone) AddDocuments(ctx context.Context, docs []schema.Document) er
 		return err
 	}
 
-	metadata := make([]map[string]any, 0, len(docs))
+	pineconeVectors := make([]*pinecone.Vector, 0, len(docs))
 
 	for i := 0; i < len(docs); i++ {
 		m := make(map[string]any, len(docs[i].Metadata))
@@ -61,44 +94,82 @@ func (vs *Pinecone) AddDocuments(ctx context.Context, docs []schema.Document) er
 
 		m[vs.textKey] = texts[i]
 
-		metadata = append(metadata, m)
+		pineconeVectors = append(pineconeVectors, &pinecone.Vector{
+			ID:       ids[i],
+			Values:   vectors[i],
+			Metadata: m,
+		})
 	}
 
-	pineconeVectors, err := pinecone.ToPineconeVectors(vectors, metadata)
-	if err != nil {
-		return err
-	}
+	_, err = vs.client.Upsert(ctx, &pinecone.UpsertRequest{
+		Vectors:   pineconeVectors,
+		Namespace: vs.opts.Namespace,
+	})
 
-	req := &pinecone.UpsertRequest{
-		Vectors: pineconeVectors,
-	}
+	return err
+}
 
-	if vs.opts.Namespace != "" {
-		req.Namespace = vs.opts.Namespace
+// Delete deletes the documents with the given IDs from the Pinecone vector store.
+func (vs *Pinecone) Delete(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
 	}
 
-	_, err = vs.client.Upsert(ctx, req)
+	_, err := vs.client.Delete(ctx, &pinecone.DeleteRequest{
+		IDs:       ids,
+		Namespace: vs.opts.Namespace,
+	})
 
 	return err
 }
 
+// SimilaritySearch performs a similarity search with the given query in the Pinecone vector store.
 func (vs *Pinecone) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs, err := vs.SimilaritySearchWithScore(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocuments(docs), nil
+}
+
+// SimilaritySearchWithScore performs a similarity search with the given query and returns the documents with their scores.
+func (vs *Pinecone) SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
 	vector, err := vs.embedder.EmbedText(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := vs.client.Query(ctx, &pinecone.QueryRequest{
+	return vs.SimilaritySearchByVector(ctx, vector, optFns...)
+}
+
+// SimilaritySearchByVector performs a similarity search with the given embedding vector and returns the documents with their scores.
+// The score is the score returned by Pinecone for the metric of the index, e.g. the cosine similarity. Filters are translated
+// to the metadata filter language of Pinecone.
+func (vs *Pinecone) SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	opts, err := newSearchOptions(int(vs.opts.TopK), optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pinecone.QueryRequest{
 		Namespace:       vs.opts.Namespace,
-		TopK:            vs.opts.TopK,
+		TopK:            int64(opts.K),
 		IncludeMetadata: true,
+		IncludeValues:   opts.IncludeVectors,
 		Vector:          vector,
-	})
+	}
+
+	if opts.Filter != nil {
+		req.Filter = pineconeFilter(opts.Filter)
+	}
+
+	res, err := vs.client.Query(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	docs := make([]schema.Document, 0, len(res.Matches))
+	docs := make([]schema.ScoredDocument, 0, len(res.Matches))
 
 	for _, match := range res.Matches {
 		pageContent, ok := match.Metadata[vs.textKey].(string)
@@ -108,13 +179,34 @@ func (vs *Pinecone) SimilaritySearch(ctx context.Context, query string) ([]schem
 
 		delete(match.Metadata, vs.textKey)
 
-		doc := schema.Document{
-			PageContent: pageContent,
-			Metadata:    match.Metadata,
+		docs = append(docs, schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    match.Metadata,
+			},
+			ID:     match.ID,
+			Score:  float32(match.Score),
+			Vector: match.Values,
+		})
+	}
+
+	return filterByScore(docs, opts.ScoreThreshold), nil
+}
+
+// pineconeFilter translates the filter to the metadata filter language of Pinecone, e.g. {"kind": {"$eq": "fruit"}}.
+// See https://docs.pinecone.io/guides/data/filter-with-metadata for more information.
+func pineconeFilter(filter *schema.Filter) map[string]any {
+	switch filter.Operator {
+	case schema.FilterOperatorAnd, schema.FilterOperatorOr:
+		operands := make([]any, len(filter.Filters))
+		for i, operand := range filter.Filters {
+			operands[i] = pineconeFilter(operand)
 		}
 
-		docs = append(docs, doc)
+		return map[string]any{"$" + string(filter.Operator): operands}
+	case schema.FilterOperatorIn, schema.FilterOperatorNin:
+		return map[string]any{filter.Key: map[string]any{"$" + string(filter.Operator): filter.Values()}}
+	default:
+		return map[string]any{filter.Key: map[string]any{"$" + string(filter.Operator): filter.Value}}
 	}
-
-	return docs, nil
 }
@@ -0,0 +1,98 @@
+package vectorstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hupe1980/golc/integration/pinecone"
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestPinecone(t *testing.T) {
+	client := &mockPineconeClient{}
+
+	vs, err := NewPinecone(client, &keywordEmbedder{}, "text")
+	require.NoError(t, err)
+
+	t.Run("Upsert", func(t *testing.T) {
+		err := vs.Upsert(context.Background(), []string{"1"}, []schema.Document{{PageContent: "apple", Metadata: map[string]any{"kind": "fruit"}}})
+		require.NoError(t, err)
+		require.Len(t, client.upsertReq.Vectors, 1)
+		assert.Equal(t, "1", client.upsertReq.Vectors[0].ID)
+		assert.Equal(t, map[string]any{"kind": "fruit", "text": "apple"}, client.upsertReq.Vectors[0].Metadata)
+
+		ids, err := vs.Add(context.Background(), []schema.Document{{PageContent: "banana"}})
+		require.NoError(t, err)
+		assert.Equal(t, ids[0], client.upsertReq.Vectors[0].ID)
+	})
+
+	t.Run("SimilaritySearchWithScore", func(t *testing.T) {
+		client.queryRes = &pinecone.QueryResponse{
+			Matches: []*pinecone.Match{
+				{ID: "1", Score: 0.9, Metadata: map[string]any{"kind": "fruit", "text": "apple"}},
+				{ID: "2", Score: 0.4, Metadata: map[string]any{"kind": "fruit", "text": "banana"}},
+			},
+		}
+
+		docs, err := vs.SimilaritySearchWithScore(context.Background(), "apple", func(o *schema.VectorStoreSearchOptions) {
+			o.K = 2
+			o.ScoreThreshold = 0.5
+			o.Filter = schema.FilterAnd(schema.FilterIn("kind", "fruit", "nut"), schema.FilterGte("year", 2020))
+		})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, schema.ScoredDocument{
+			Document: schema.Document{PageContent: "apple", Metadata: map[string]any{"kind": "fruit"}},
+			ID:       "1",
+			Score:    0.9,
+		}, docs[0])
+
+		assert.Equal(t, int64(2), client.queryReq.TopK)
+		assert.False(t, client.queryReq.IncludeValues)
+		assert.Equal(t, map[string]any{
+			"$and": []any{
+				map[string]any{"kind": map[string]any{"$in": []any{"fruit", "nut"}}},
+				map[string]any{"year": map[string]any{"$gte": 2020}},
+			},
+		}, client.queryReq.Filter)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, vs.Delete(context.Background(), []string{"1", "2"}))
+		assert.Equal(t, []string{"1", "2"}, client.deleteReq.IDs)
+	})
+}
+
+// mockPineconeClient implements the pinecone.Client interface for testing purposes.
+type mockPineconeClient struct {
+	upsertReq *pinecone.UpsertRequest
+	queryReq  *pinecone.QueryRequest
+	queryRes  *pinecone.QueryResponse
+	deleteReq *pinecone.DeleteRequest
+}
+
+func (m *mockPineconeClient) Upsert(ctx context.Context, req *pinecone.UpsertRequest) (*pinecone.UpsertResponse, error) {
+	m.upsertReq = req
+	return &pinecone.UpsertResponse{UpsertedCount: uint32(len(req.Vectors))}, nil
+}
+
+func (m *mockPineconeClient) Fetch(ctx context.Context, req *pinecone.FetchRequest) (*pinecone.FetchResponse, error) {
+	return &pinecone.FetchResponse{}, nil
+}
+
+func (m *mockPineconeClient) Query(ctx context.Context, req *pinecone.QueryRequest) (*pinecone.QueryResponse, error) {
+	m.queryReq = req
+	return m.queryRes, nil
+}
+
+func (m *mockPineconeClient) Delete(ctx context.Context, req *pinecone.DeleteRequest) (*pinecone.DeleteResponse, error) {
+	m.deleteReq = req
+	return &pinecone.DeleteResponse{}, nil
+}
+
+func (m *mockPineconeClient) Close() error {
+	return nil
+}
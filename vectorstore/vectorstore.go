@@ -10,3 +10,53 @@ import (
 func ToRetriever(vectorStore schema.VectorStore, optFns ...func(o *retriever.VectorStoreOptions)) schema.Retriever {
 	return retriever.NewVectorStore(vectorStore, optFns...)
 }
+
+// newSearchOptions returns the validated search options, using topK if no k is given.
+func newSearchOptions(topK int, optFns ...func(o *schema.VectorStoreSearchOptions)) (schema.VectorStoreSearchOptions, error) {
+	opts := schema.VectorStoreSearchOptions{
+		K: topK,
+	}
+
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+
+	if opts.K <= 0 {
+		opts.K = topK
+	}
+
+	if opts.Filter != nil {
+		if err := opts.Filter.Validate(); err != nil {
+			return opts, err
+		}
+	}
+
+	return opts, nil
+}
+
+// filterByScore returns the documents with a score of at least the threshold. A zero threshold keeps all documents.
+func filterByScore(docs []schema.ScoredDocument, threshold float32) []schema.ScoredDocument {
+	if threshold == 0 {
+		return docs
+	}
+
+	filtered := make([]schema.ScoredDocument, 0, len(docs))
+
+	for _, doc := range docs {
+		if doc.Score >= threshold {
+			filtered = append(filtered, doc)
+		}
+	}
+
+	return filtered
+}
+
+// toDocuments returns the documents of the scored documents.
+func toDocuments(scoredDocs []schema.ScoredDocument) []schema.Document {
+	docs := make([]schema.Document, len(scoredDocs))
+	for i, doc := range scoredDocs {
+		docs[i] = doc.Document
+	}
+
+	return docs
+}
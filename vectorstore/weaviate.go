@@ -2,19 +2,28 @@ package vectorstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/hupe1980/golc/schema"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
 )
 
-// Compile time check to ensure Weaviate satisfies the VectorStore interface.
-var _ schema.VectorStore = (*Weaviate)(nil)
+// Compile time check to ensure Weaviate satisfies the VectorStore interfaces.
+var (
+	_ schema.VectorStoreSearcher = (*Weaviate)(nil)
+	_ schema.VectorStoreWriter   = (*WeaviateWriter)(nil)
+)
 
 // WeaviateOptions contains options for configuring the Weaviate vector store.
 type WeaviateOptions struct {
@@ -83,6 +92,40 @@ func (vs *Weaviate) CreateClassIfNotExist(ctx context.Context) error {
 
 // AddDocuments adds a batch of documents to the Weaviate vector store.
 func (vs *Weaviate) AddDocuments(ctx context.Context, docs []schema.Document) error {
+	_, err := vs.Add(ctx, docs)
+	return err
+}
+
+// Add adds the documents to the Weaviate vector store and returns their generated UUIDs.
+func (vs *Weaviate) Add(ctx context.Context, docs []schema.Document) ([]string, error) {
+	ids := make([]string, len(docs))
+	for i := range docs {
+		ids[i] = uuid.New().String()
+	}
+
+	if err := vs.Upsert(ctx, ids, docs); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// Upsert inserts the documents with the given UUIDs or replaces the existing objects with these UUIDs.
+func (vs *Weaviate) Upsert(ctx context.Context, ids []string, docs []schema.Document) error {
+	if len(ids) != len(docs) {
+		return fmt.Errorf("number of ids (%d) does not match number of documents (%d)", len(ids), len(docs))
+	}
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("invalid weaviate id %s: %w", id, err)
+		}
+	}
+
 	texts := make([]string, len(docs))
 	for i, doc := range docs {
 		texts[i] = doc.PageContent
@@ -105,7 +148,7 @@ func (vs *Weaviate) AddDocuments(ctx context.Context, docs []schema.Document) er
 
 		objects = append(objects, &models.Object{
 			Class:      vs.opts.IndexName,
-			ID:         strfmt.UUID(uuid.New().String()),
+			ID:         strfmt.UUID(ids[i]),
 			Vector:     vectors[i],
 			Properties: metadata,
 		})
@@ -118,32 +161,106 @@ func (vs *Weaviate) AddDocuments(ctx context.Context, docs []schema.Document) er
 	return nil
 }
 
+// Delete removes a document from the Weaviate vector store based on its UUID.
+func (vs *Weaviate) Delete(ctx context.Context, uuid string) error {
+	return vs.client.Data().Deleter().WithID(uuid).Do(ctx)
+}
+
+// DeleteMany removes the documents with the given UUIDs from the Weaviate vector store. Unknown UUIDs are ignored.
+func (vs *Weaviate) DeleteMany(ctx context.Context, ids []string) error {
+	for _, id := range ids {
+		err := vs.client.Data().Deleter().WithClassName(vs.opts.IndexName).WithID(id).Do(ctx)
+
+		var clientErr *fault.WeaviateClientError
+		if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Writer returns the Weaviate vector store as a schema.VectorStoreWriter. Weaviate itself does not
+// satisfy the interface, because its Delete deletes a single document.
+func (vs *Weaviate) Writer() *WeaviateWriter {
+	return &WeaviateWriter{vs}
+}
+
+// WeaviateWriter adapts the Weaviate vector store to the schema.VectorStoreWriter interface, whose
+// Delete deletes several documents at once.
+type WeaviateWriter struct {
+	*Weaviate
+}
+
+// Delete removes the documents with the given UUIDs from the Weaviate vector store. Unknown UUIDs are ignored.
+func (w *WeaviateWriter) Delete(ctx context.Context, ids []string) error {
+	return w.DeleteMany(ctx, ids)
+}
+
 // SimilaritySearch performs a similarity search with the given query in the Weaviate vector store.
 func (vs *Weaviate) SimilaritySearch(ctx context.Context, query string) ([]schema.Document, error) {
+	docs, err := vs.SimilaritySearchWithScore(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDocuments(docs), nil
+}
+
+// SimilaritySearchWithScore performs a similarity search with the given query and returns the documents with their relevance scores.
+func (vs *Weaviate) SimilaritySearchWithScore(ctx context.Context, query string, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
 	vector, err := vs.embedder.EmbedText(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
+	return vs.SimilaritySearchByVector(ctx, vector, optFns...)
+}
+
+// SimilaritySearchByVector performs a similarity search with the given embedding vector and returns the documents with their relevance scores.
+// The score is 1 - distance, which is the cosine similarity for the default cosine distance of Weaviate. Filters are translated
+// to a where filter on the properties of the objects.
+func (vs *Weaviate) SimilaritySearchByVector(ctx context.Context, vector []float32, optFns ...func(o *schema.VectorStoreSearchOptions)) ([]schema.ScoredDocument, error) {
+	opts, err := newSearchOptions(vs.opts.TopK, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
 	nearVector := vs.client.GraphQL().NearVectorArgBuilder().WithVector(vector)
 
 	fields := []graphql.Field{
 		{Name: vs.opts.TextKey},
 	}
 
+	additionalFields := []graphql.Field{{Name: "id"}, {Name: "distance"}}
+	if opts.IncludeVectors {
+		additionalFields = append(additionalFields, graphql.Field{Name: "vector"})
+	}
+
+	fields = append(fields, graphql.Field{Name: "_additional", Fields: additionalFields})
+
 	for _, fieldName := range vs.opts.AdditionalFields {
 		fields = append(fields, graphql.Field{
 			Name: fieldName,
 		})
 	}
 
-	res, err := vs.client.GraphQL().
+	builder := vs.client.GraphQL().
 		Get().
 		WithNearVector(nearVector).
 		WithClassName(vs.opts.IndexName).
 		WithFields(fields...).
-		WithLimit(vs.opts.TopK).
-		Do(ctx)
+		WithLimit(opts.K)
+
+	if opts.Filter != nil {
+		builder = builder.WithWhere(weaviateFilter(opts.Filter))
+	}
+
+	res, err := builder.Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -163,13 +280,37 @@ func (vs *Weaviate) SimilaritySearch(ctx context.Context, query string) ([]schem
 	}
 
 	items, _ := data.([]any)
-	docs := make([]schema.Document, len(items))
+	docs := make([]schema.ScoredDocument, len(items))
 
 	for i, item := range items {
 		metadata, _ := item.(map[string]any)
 
-		docs[i] = schema.Document{
-			PageContent: metadata[vs.opts.TextKey].(string),
+		pageContent, ok := metadata[vs.opts.TextKey].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid response: no content for text key %s", vs.opts.TextKey)
+		}
+
+		docs[i] = schema.ScoredDocument{
+			Document: schema.Document{
+				PageContent: pageContent,
+				Metadata:    make(map[string]any, len(vs.opts.AdditionalFields)),
+			},
+		}
+
+		if additional, ok := metadata["_additional"].(map[string]any); ok {
+			docs[i].ID, _ = additional["id"].(string)
+
+			if distance, ok := additional["distance"].(float64); ok {
+				docs[i].Score = float32(1 - distance)
+			}
+
+			if vector, ok := additional["vector"].([]any); ok {
+				docs[i].Vector = make([]float32, len(vector))
+				for j, v := range vector {
+					f, _ := v.(float64)
+					docs[i].Vector[j] = float32(f)
+				}
+			}
 		}
 
 		for _, field := range vs.opts.AdditionalFields {
@@ -179,10 +320,78 @@ func (vs *Weaviate) SimilaritySearch(ctx context.Context, query string) ([]schem
 		}
 	}
 
-	return docs, nil
+	return filterByScore(docs, opts.ScoreThreshold), nil
 }
 
-// Delete removes a document from the Weaviate vector store based on its UUID.
-func (vs *Weaviate) Delete(ctx context.Context, uuid string) error {
-	return vs.client.Data().Deleter().WithID(uuid).Do(ctx)
+// weaviateFilter translates the filter to a where filter of Weaviate. The in and nin operators
+// are expressed as disjunction of equalities and conjunction of inequalities.
+func weaviateFilter(filter *schema.Filter) *filters.WhereBuilder {
+	switch filter.Operator {
+	case schema.FilterOperatorAnd, schema.FilterOperatorOr:
+		operands := make([]*filters.WhereBuilder, len(filter.Filters))
+		for i, operand := range filter.Filters {
+			operands[i] = weaviateFilter(operand)
+		}
+
+		operator := filters.And
+		if filter.Operator == schema.FilterOperatorOr {
+			operator = filters.Or
+		}
+
+		return filters.Where().WithOperator(operator).WithOperands(operands)
+	case schema.FilterOperatorIn, schema.FilterOperatorNin:
+		values := filter.Values()
+		operands := make([]*filters.WhereBuilder, len(values))
+
+		operator, comparison := filters.Or, schema.FilterOperatorEq
+		if filter.Operator == schema.FilterOperatorNin {
+			operator, comparison = filters.And, schema.FilterOperatorNe
+		}
+
+		for i, v := range values {
+			operands[i] = weaviateFilter(&schema.Filter{Operator: comparison, Key: filter.Key, Value: v})
+		}
+
+		return filters.Where().WithOperator(operator).WithOperands(operands)
+	}
+
+	operator := map[schema.FilterOperator]filters.WhereOperator{
+		schema.FilterOperatorEq:  filters.Equal,
+		schema.FilterOperatorNe:  filters.NotEqual,
+		schema.FilterOperatorGt:  filters.GreaterThan,
+		schema.FilterOperatorGte: filters.GreaterThanEqual,
+		schema.FilterOperatorLt:  filters.LessThan,
+		schema.FilterOperatorLte: filters.LessThanEqual,
+	}[filter.Operator]
+
+	where := filters.Where().WithPath([]string{filter.Key}).WithOperator(operator)
+
+	// Integers are compared with valueInt and other numbers with valueNumber, matching the
+	// int and number data types of the properties.
+	if n, ok := filter.Value.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return where.WithValueInt(i)
+		}
+
+		f, _ := n.Float64()
+
+		return where.WithValueNumber(f)
+	}
+
+	v := reflect.ValueOf(filter.Value)
+
+	switch v.Kind() {
+	case reflect.String:
+		return where.WithValueText(v.String())
+	case reflect.Bool:
+		return where.WithValueBoolean(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return where.WithValueInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return where.WithValueInt(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return where.WithValueNumber(v.Float())
+	default:
+		return where
+	}
 }
@@ -0,0 +1,55 @@
+package vectorstore
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hupe1980/golc/schema"
+)
+
+func TestWeaviateFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filter   *schema.Filter
+		expected string
+	}{
+		{
+			name:     "Eq",
+			filter:   schema.FilterEq("source", "wiki"),
+			expected: `where:{operator: Equal path: ["source"] valueText: "wiki"}`,
+		},
+		{
+			name:     "In",
+			filter:   schema.FilterIn("kind", "fruit", "nut"),
+			expected: `where:{operator: Or operands:[{operator: Equal path: ["kind"] valueText: "fruit"},{operator: Equal path: ["kind"] valueText: "nut"}]}`,
+		},
+		{
+			name:     "Nin",
+			filter:   schema.FilterNin("color", "red", "green"),
+			expected: `where:{operator: And operands:[{operator: NotEqual path: ["color"] valueText: "red"},{operator: NotEqual path: ["color"] valueText: "green"}]}`,
+		},
+		{
+			name:     "JSON integer",
+			filter:   &schema.Filter{Operator: schema.FilterOperatorGte, Key: "year", Value: json.Number("2020")},
+			expected: `where:{operator: GreaterThanEqual path: ["year"] valueInt: 2020}`,
+		},
+		{
+			name:     "JSON number",
+			filter:   &schema.Filter{Operator: schema.FilterOperatorLt, Key: "price", Value: json.Number("2.5")},
+			expected: `where:{operator: LessThan path: ["price"] valueNumber: 2.5}`,
+		},
+		{
+			name:     "Nested",
+			filter:   schema.FilterAnd(schema.FilterGt("count", uint(3)), schema.FilterOr(schema.FilterNe("ripe", true), schema.FilterLte("weight", 1.5))),
+			expected: `where:{operator: And operands:[{operator: GreaterThan path: ["count"] valueInt: 3},{operator: Or operands:[{operator: NotEqual path: ["ripe"] valueBoolean: true},{operator: LessThanEqual path: ["weight"] valueNumber: 1.5}]}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, weaviateFilter(tc.filter).String())
+		})
+	}
+}